@@ -0,0 +1,68 @@
+package configtypes
+
+import (
+	"encoding/binary"
+)
+
+// The binary encoding of every Opt type, as produced by MarshalBinary and GobEncode, starts with a
+// byte that indicates whether the value is defined. An empty value has nothing after that byte; a
+// defined value is followed by a type-specific encoding of the wrapped value.
+const (
+	optBinaryEmpty   byte = 0
+	optBinaryDefined byte = 1
+)
+
+func marshalOptBinary(defined bool, payload []byte) []byte {
+	if !defined {
+		return []byte{optBinaryEmpty}
+	}
+	return append([]byte{optBinaryDefined}, payload...)
+}
+
+// unmarshalOptBinary checks the first byte of the binary encoding of an Opt type, and returns the rest
+// of the data if the value is defined.
+func unmarshalOptBinary(data []byte) (payload []byte, defined bool, err error) {
+	switch {
+	case len(data) == 1 && data[0] == optBinaryEmpty:
+		return nil, false, nil
+	case len(data) >= 1 && data[0] == optBinaryDefined:
+		return data[1:], true, nil
+	default:
+		return nil, false, errBinaryFormat()
+	}
+}
+
+func marshalOptBinaryVarint(defined bool, value int64) []byte {
+	return marshalOptBinary(defined, binary.AppendVarint(nil, value))
+}
+
+// unmarshalOptBinaryVarint is the same as unmarshalOptBinary, for a value that is encoded as a
+// single varint.
+func unmarshalOptBinaryVarint(data []byte) (value int64, defined bool, err error) {
+	payload, defined, err := unmarshalOptBinary(data)
+	if err != nil || !defined {
+		return 0, false, err
+	}
+	value, n := binary.Varint(payload)
+	if n <= 0 || n != len(payload) {
+		return 0, false, errBinaryFormat()
+	}
+	return value, true, nil
+}
+
+func marshalOptBinaryUvarint(defined bool, value uint64) []byte {
+	return marshalOptBinary(defined, binary.AppendUvarint(nil, value))
+}
+
+// unmarshalOptBinaryUvarint is the same as unmarshalOptBinaryVarint, for an unsigned value.
+func unmarshalOptBinaryUvarint(data []byte) (value uint64, defined bool, err error) {
+	payload, defined, err := unmarshalOptBinary(data)
+	if err != nil || !defined {
+		return 0, false, err
+	}
+	value, n := binary.Uvarint(payload)
+	if n <= 0 || n != len(payload) {
+		return 0, false, errBinaryFormat()
+	}
+	return value, true, nil
+}
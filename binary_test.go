@@ -0,0 +1,198 @@
+package configtypes
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/units"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type binaryMarshalerAndUnmarshaler interface {
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+}
+
+func TestBinaryRoundTrip(t *testing.T) {
+	someURL, _ := NewOptURLAbsoluteFromString("http://a/b?c=d")
+	zonedTime := time.Date(2020, 1, 2, 3, 4, 5, 6, time.FixedZone("", -7*60*60))
+
+	for _, p := range []struct {
+		value  encoding.BinaryMarshaler
+		target binaryMarshalerAndUnmarshaler
+	}{
+		{OptBool{}, &OptBool{}},
+		{NewOptBool(false), &OptBool{}},
+		{NewOptBool(true), &OptBool{}},
+		{OptInt{}, &OptInt{}},
+		{NewOptInt(0), &OptInt{}},
+		{NewOptInt(-3), &OptInt{}},
+		{OptIntGreaterThanZero{}, &OptIntGreaterThanZero{}},
+		{mustOptIntGreaterThanZero(3), &OptIntGreaterThanZero{}},
+		{OptInt64{}, &OptInt64{}},
+		{NewOptInt64(math.MinInt64), &OptInt64{}},
+		{NewOptInt64(math.MaxInt64), &OptInt64{}},
+		{mustOptInt64GreaterThanZero(3), &OptInt64GreaterThanZero{}},
+		{OptUint{}, &OptUint{}},
+		{NewOptUint(math.MaxUint), &OptUint{}},
+		{mustOptUintGreaterThanZero(3), &OptUintGreaterThanZero{}},
+		{OptUint64{}, &OptUint64{}},
+		{NewOptUint64(0), &OptUint64{}},
+		{NewOptUint64(math.MaxUint64), &OptUint64{}},
+		{mustOptUint64GreaterThanZero(3), &OptUint64GreaterThanZero{}},
+		{OptFloat64{}, &OptFloat64{}},
+		{NewOptFloat64(0), &OptFloat64{}},
+		{NewOptFloat64(-1.5), &OptFloat64{}},
+		{NewOptFloat64(math.Inf(1)), &OptFloat64{}},
+		{OptDuration{}, &OptDuration{}},
+		{NewOptDuration(-time.Second), &OptDuration{}},
+		{OptDurationNonNegative{}, &OptDurationNonNegative{}},
+		{mustOptDurationNonNegative(0), &OptDurationNonNegative{}},
+		{OptString{}, &OptString{}},
+		{NewOptString(""), &OptString{}},
+		{NewOptString("a"), &OptString{}},
+		{OptStringNonEmpty{}, &OptStringNonEmpty{}},
+		{NewOptStringNonEmpty("a"), &OptStringNonEmpty{}},
+		{OptSecret{}, &OptSecret{}},
+		{NewOptSecret(testLongSecret), &OptSecret{}},
+		{mustReqSecret(testLongSecret), &ReqSecret{}},
+		{OptStringList{}, &OptStringList{}},
+		{NewOptStringList([]string{}), &OptStringList{}},
+		{NewOptStringList([]string{"a,b", "", "c"}), &OptStringList{}},
+		{OptURL{}, &OptURL{}},
+		{someURL.opt, &OptURL{}},
+		{OptURLAbsolute{}, &OptURLAbsolute{}},
+		{someURL, &OptURLAbsolute{}},
+		{OptBase2Bytes{}, &OptBase2Bytes{}},
+		{NewOptBase2Bytes(3 * units.GiB), &OptBase2Bytes{}},
+		{OptTime{}, &OptTime{}},
+		{NewOptTime(zonedTime), &OptTime{}},
+		{OptIntBetween[testIntBounds]{}, &OptIntBetween[testIntBounds]{}},
+		{mustOptIntBetween(10), &OptIntBetween[testIntBounds]{}},
+		{mustOptInt64Between(math.MaxInt64), &OptInt64Between[testInt64Bounds]{}},
+		{mustOptFloat64Between(0.5), &OptFloat64Between[testFloat64Bounds]{}},
+		{mustOptDurationBetween(time.Minute), &OptDurationBetween[testDurationBounds]{}},
+	} {
+		data, err := p.value.MarshalBinary()
+		require.NoError(t, err)
+		require.NoError(t, p.target.UnmarshalBinary(data), "%T %v", p.value, p.value)
+		assert.Equal(t, p.value, derefBinaryTarget(p.target))
+	}
+}
+
+func TestBinaryUnmarshalReplacesPreviousValue(t *testing.T) {
+	empty, _ := OptStringList{}.MarshalBinary()
+	other, _ := NewOptStringList([]string{"b"}).MarshalBinary()
+
+	list := NewOptStringList([]string{"a"})
+	require.NoError(t, list.UnmarshalBinary(other))
+	assert.Equal(t, NewOptStringList([]string{"b"}), list)
+
+	require.NoError(t, list.UnmarshalBinary(empty))
+	assert.Equal(t, OptStringList{}, list)
+}
+
+func TestBinaryUnmarshalFails(t *testing.T) {
+	negativeDuration, _ := NewOptDuration(-time.Second).MarshalBinary()
+	zeroInt, _ := NewOptInt(0).MarshalBinary()
+	emptySecret, _ := OptSecret{}.MarshalBinary()
+	relativeURL, _ := NewOptURLFromString("/a")
+	relativeURLData, _ := relativeURL.MarshalBinary()
+
+	for _, p := range []struct {
+		name   string
+		target encoding.BinaryUnmarshaler
+		data   []byte
+		err    error
+	}{
+		{"no data", &OptBool{}, nil, errBinaryFormat()},
+		{"unknown prefix", &OptBool{}, []byte{2, 1}, errBinaryFormat()},
+		{"empty value with extra data", &OptString{}, []byte{0, 1}, errBinaryFormat()},
+		{"bad bool", &OptBool{}, []byte{1, 2}, errBinaryFormat()},
+		{"missing bool", &OptBool{}, []byte{1}, errBinaryFormat()},
+		{"missing int", &OptInt{}, []byte{1}, errBinaryFormat()},
+		{"int with extra data", &OptInt{}, []byte{1, 2, 3}, errBinaryFormat()},
+		{"short float", &OptFloat64{}, []byte{1, 0, 0}, errBinaryFormat()},
+		{"truncated list", &OptStringList{}, []byte{1, 3, 'a'}, errBinaryFormat()},
+		{"bad time", &OptTime{}, []byte{1, 99}, errBinaryFormat()},
+		{"OptIntGreaterThanZero", &OptIntGreaterThanZero{}, zeroInt, errMustBeGreaterThanZero()},
+		{"missing uint", &OptUint64{}, []byte{1}, errBinaryFormat()},
+		{"uint with extra data", &OptUint64{}, []byte{1, 2, 3}, errBinaryFormat()},
+		{"OptInt64GreaterThanZero", &OptInt64GreaterThanZero{}, zeroInt, errMustBeGreaterThanZero()},
+		{"OptUint64GreaterThanZero", &OptUint64GreaterThanZero{}, []byte{1, 0}, errMustBeGreaterThanZero()},
+		{"OptDurationNonNegative", &OptDurationNonNegative{}, negativeDuration, errMustBeNonNegative()},
+		{"ReqSecret", &ReqSecret{}, emptySecret, errRequired()},
+		{"OptURLAbsolute", &OptURLAbsolute{}, relativeURLData, errURLNotAbsolute()},
+		{"OptIntBetween", &OptIntBetween[testIntBounds]{}, zeroInt, errOutOfRange("between 1 and 10")},
+		{"OptInt64Between", &OptInt64Between[testInt64Bounds]{}, zeroInt, errOutOfRange("greater than 0")},
+		{
+			"OptDurationBetween", &OptDurationBetween[testDurationBounds]{}, negativeDuration,
+			errOutOfRange("at least 1s"),
+		},
+	} {
+		t.Run(p.name, func(t *testing.T) {
+			assert.Equal(t, p.err, p.target.UnmarshalBinary(p.data))
+		})
+	}
+}
+
+type testStructForGob struct {
+	Enabled  OptBool
+	Port     OptIntGreaterThanZero
+	Ratio    OptFloat64
+	Timeout  OptDurationNonNegative
+	Name     OptString
+	Label    OptStringNonEmpty
+	Password ReqSecret
+	Hosts    OptStringList
+	URL      OptURLAbsolute
+	Size     OptBase2Bytes
+	Expires  OptTime
+	Backup   OptSecret
+}
+
+func TestGobRoundTrip(t *testing.T) {
+	someURL, _ := NewOptURLAbsoluteFromString("http://a/b")
+	s := testStructForGob{
+		Enabled:  NewOptBool(false),
+		Port:     mustOptIntGreaterThanZero(8080),
+		Ratio:    NewOptFloat64(0.5),
+		Timeout:  mustOptDurationNonNegative(time.Minute),
+		Name:     NewOptString(""),
+		Label:    NewOptStringNonEmpty("x"),
+		Password: mustReqSecret(testLongSecret),
+		Hosts:    NewOptStringList([]string{"a", "b"}),
+		URL:      someURL,
+		Size:     NewOptBase2Bytes(units.MiB),
+		Expires:  NewOptTime(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)),
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, gob.NewEncoder(&buf).Encode(s))
+	var result testStructForGob
+	require.NoError(t, gob.NewDecoder(&buf).Decode(&result))
+	assert.Equal(t, s, result)
+}
+
+func TestGobDecodeValidates(t *testing.T) {
+	type durations struct{ Timeout OptDuration }
+	type nonNegativeDurations struct{ Timeout OptDurationNonNegative }
+
+	var buf bytes.Buffer
+	require.NoError(t, gob.NewEncoder(&buf).Encode(durations{Timeout: NewOptDuration(-time.Second)}))
+	var result nonNegativeDurations
+	err := gob.NewDecoder(&buf).Decode(&result)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), errMustBeNonNegative().Error())
+}
+
+func derefBinaryTarget(target binaryMarshalerAndUnmarshaler) interface{} {
+	return reflect.ValueOf(target).Elem().Interface()
+}
@@ -0,0 +1,155 @@
+package configtypes
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Number is the set of types that can be used with a NumericRange.
+type Number interface {
+	int | int64 | float64 | time.Duration
+}
+
+// NumericRange describes the allowed values for a bounded type such as OptIntBetween. Each bound is
+// optional, and can be inclusive or exclusive.
+//
+// The functions RangeBetween, RangeAtLeast, RangeGreaterThan, RangeAtMost, and RangeLessThan return
+// the most common kinds of range; for other combinations, such as a range that includes its minimum
+// but not its maximum, set the fields directly.
+type NumericRange[T Number] struct {
+	// Min is the lower bound. It is ignored unless HasMin is true.
+	Min T
+	// Max is the upper bound. It is ignored unless HasMax is true.
+	Max T
+	// HasMin is true if there is a lower bound.
+	HasMin bool
+	// HasMax is true if there is an upper bound.
+	HasMax bool
+	// MinExclusive is true if the lower bound itself is not allowed.
+	MinExclusive bool
+	// MaxExclusive is true if the upper bound itself is not allowed.
+	MaxExclusive bool
+}
+
+// Bounds is implemented by a type that specifies the range for a bounded type such as OptIntBetween.
+// Since the range is part of the bounded type, it is specified by a type parameter rather than by a
+// value; the Range method is called on the zero value of that type, which is normally an empty struct.
+//
+//	type PortBounds struct{}
+//
+//	func (PortBounds) Range() configtypes.NumericRange[int] { return configtypes.RangeBetween(1, 65535) }
+type Bounds[T Number] interface {
+	Range() NumericRange[T]
+}
+
+// RangeBetween returns a NumericRange that allows values from min to max, inclusive.
+func RangeBetween[T Number](min, max T) NumericRange[T] {
+	return NumericRange[T]{Min: min, Max: max, HasMin: true, HasMax: true}
+}
+
+// RangeAtLeast returns a NumericRange that allows values greater than or equal to min.
+func RangeAtLeast[T Number](min T) NumericRange[T] {
+	return NumericRange[T]{Min: min, HasMin: true}
+}
+
+// RangeGreaterThan returns a NumericRange that allows values greater than min.
+func RangeGreaterThan[T Number](min T) NumericRange[T] {
+	return NumericRange[T]{Min: min, HasMin: true, MinExclusive: true}
+}
+
+// RangeAtMost returns a NumericRange that allows values less than or equal to max.
+func RangeAtMost[T Number](max T) NumericRange[T] {
+	return NumericRange[T]{Max: max, HasMax: true}
+}
+
+// RangeLessThan returns a NumericRange that allows values less than max.
+func RangeLessThan[T Number](max T) NumericRange[T] {
+	return NumericRange[T]{Max: max, HasMax: true, MaxExclusive: true}
+}
+
+// Contains returns true if the value is within the range. NaN is not within any range that has a
+// bound.
+func (r NumericRange[T]) Contains(value T) bool {
+	if (r.HasMin || r.HasMax) && math.IsNaN(float64(value)) {
+		return false
+	}
+	if r.HasMin && (value < r.Min || (r.MinExclusive && value == r.Min)) {
+		return false
+	}
+	if r.HasMax && (value > r.Max || (r.MaxExclusive && value == r.Max)) {
+		return false
+	}
+	return true
+}
+
+// String returns a description of the range, such as "between 1 and 10" or "greater than 0". This
+// is the form used in the error for a value that is out of range, which is "value must be " followed
+// by the description.
+func (r NumericRange[T]) String() string {
+	var lower, upper string
+	switch {
+	case !r.HasMin:
+	case r.MinExclusive:
+		lower = fmt.Sprintf("greater than %v", r.Min)
+	default:
+		lower = fmt.Sprintf("at least %v", r.Min)
+	}
+	switch {
+	case !r.HasMax:
+	case r.MaxExclusive:
+		upper = fmt.Sprintf("less than %v", r.Max)
+	default:
+		upper = fmt.Sprintf("at most %v", r.Max)
+	}
+	switch {
+	case r.HasMin && r.HasMax && !r.MinExclusive && !r.MaxExclusive:
+		return fmt.Sprintf("between %v and %v", r.Min, r.Max)
+	case lower != "" && upper != "":
+		return lower + " and " + upper
+	case lower != "" || upper != "":
+		return lower + upper
+	default:
+		return "any value"
+	}
+}
+
+// rangeOf returns the NumericRange specified by a Bounds type.
+func rangeOf[T Number, B Bounds[T]]() NumericRange[T] {
+	var b B
+	return b.Range()
+}
+
+// checkRange returns an error if the value is defined and is not within the range of a Bounds type.
+func checkRange[T Number, B Bounds[T]](defined bool, value T) error {
+	if r := rangeOf[T, B](); defined && !r.Contains(value) {
+		return errOutOfRange(r.String())
+	}
+	return nil
+}
+
+// rangeJSONSchema returns the JSON Schema keywords for a range of numbers.
+func rangeJSONSchema[T int | int64 | float64](r NumericRange[T], schema jsonSchemaObject) jsonSchemaObject {
+	if r.HasMin {
+		if r.MinExclusive {
+			schema["exclusiveMinimum"] = r.Min
+		} else {
+			schema["minimum"] = r.Min
+		}
+	}
+	if r.HasMax {
+		if r.MaxExclusive {
+			schema["exclusiveMaximum"] = r.Max
+		} else {
+			schema["maximum"] = r.Max
+		}
+	}
+	return schema
+}
+
+// boundedValue is implemented by all of the bounded types, so that functions such as DescribeVars
+// and JSONSchema can describe their ranges without knowing their type parameters.
+type boundedValue interface {
+	describeVarFormat() string
+	jsonSchema() jsonSchemaObject
+}
@@ -0,0 +1,84 @@
+package configtypes
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testIntBounds struct{}
+
+func (testIntBounds) Range() NumericRange[int] { return RangeBetween(1, 10) }
+
+type testInt64Bounds struct{}
+
+func (testInt64Bounds) Range() NumericRange[int64] { return RangeGreaterThan[int64](0) }
+
+type testFloat64Bounds struct{}
+
+func (testFloat64Bounds) Range() NumericRange[float64] {
+	return NumericRange[float64]{Min: 0, Max: 1, HasMin: true, HasMax: true, MaxExclusive: true}
+}
+
+type testDurationBounds struct{}
+
+func (testDurationBounds) Range() NumericRange[time.Duration] { return RangeAtLeast(time.Second) }
+
+func TestNumericRange(t *testing.T) {
+	t.Run("Contains", func(t *testing.T) {
+		for _, p := range []struct {
+			r        NumericRange[float64]
+			value    float64
+			expected bool
+		}{
+			{RangeBetween(1.0, 2.0), 1, true},
+			{RangeBetween(1.0, 2.0), 2, true},
+			{RangeBetween(1.0, 2.0), 0.5, false},
+			{RangeBetween(1.0, 2.0), 2.5, false},
+			{RangeAtLeast(1.0), 1, true},
+			{RangeAtLeast(1.0), 0, false},
+			{RangeGreaterThan(1.0), 1, false},
+			{RangeGreaterThan(1.0), 1.5, true},
+			{RangeAtMost(1.0), 1, true},
+			{RangeAtMost(1.0), 2, false},
+			{RangeLessThan(1.0), 1, false},
+			{RangeLessThan(1.0), -1, true},
+			{RangeAtLeast(0.0), math.Inf(1), true},
+			{RangeAtLeast(0.0), math.NaN(), false},
+			{NumericRange[float64]{}, math.NaN(), true},
+		} {
+			assert.Equal(t, p.expected, p.r.Contains(p.value), "%+v %v", p.r, p.value)
+		}
+	})
+
+	t.Run("String", func(t *testing.T) {
+		for expected, r := range map[string]interface{ String() string }{
+			"between 1 and 10":             RangeBetween(1, 10),
+			"at least 1s":                  RangeAtLeast(time.Second),
+			"greater than 0":               RangeGreaterThan[int64](0),
+			"at most 0.5":                  RangeAtMost(0.5),
+			"less than 1m0s":               RangeLessThan(time.Minute),
+			"at least 0 and less than 1":   testFloat64Bounds{}.Range(),
+			"greater than 0 and at most 1": NumericRange[int]{Max: 1, HasMin: true, HasMax: true, MinExclusive: true},
+			"greater than -1 and less than 1": NumericRange[int]{Min: -1, Max: 1, HasMin: true, HasMax: true,
+				MinExclusive: true, MaxExclusive: true},
+			"any value": NumericRange[int]{},
+		} {
+			assert.Equal(t, expected, r.String())
+		}
+	})
+
+	t.Run("describeVarFormat", func(t *testing.T) {
+		for expected, value := range map[string]interface{}{
+			"integer between 1 and 10":          OptIntBetween[testIntBounds]{},
+			"integer greater than 0":            OptInt64Between[testInt64Bounds]{},
+			"number at least 0 and less than 1": OptFloat64Between[testFloat64Bounds]{},
+			"duration like 1m30s, at least 1s":  &OptDurationBetween[testDurationBounds]{},
+		} {
+			assert.Equal(t, expected, describeVarFormat(reflect.TypeOf(value)))
+		}
+	})
+}
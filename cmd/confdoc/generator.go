@@ -0,0 +1,161 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+const (
+	beginMarker = "<!-- confdoc:begin -->"
+	endMarker   = "<!-- confdoc:end -->"
+)
+
+type params struct {
+	dir          string
+	typeName     string
+	defaultsFunc string
+	prefix       string
+	suffix       string
+	format       string
+	recursive    bool
+}
+
+// programTemplate is the source of the program that confdoc runs to produce the table.
+//
+//nolint:gochecknoglobals
+var programTemplate = template.Must(template.New("program").Parse(`package main
+
+import (
+	"fmt"
+	"os"
+
+	configtypes "github.com/launchdarkly/go-configtypes/v2"
+	target {{printf "%q" .ImportPath}}
+)
+
+func main() {
+{{- if eq .Format "jsonschema"}}
+	schema, err := configtypes.JSONSchema({{.Value}}, configtypes.JSONSchemaOptions{})
+	if err == nil {
+		_, err = fmt.Println(string(schema))
+	}
+{{- else}}
+	options := configtypes.VarDocOptions{Prefix: {{printf "%q" .Prefix}}, Suffix: {{printf "%q" .Suffix}}}
+	docs, err := configtypes.DescribeVars({{.Value}}, {{.Recursive}}, options)
+	if err == nil {
+		err = configtypes.WriteVarDocs(os.Stdout, docs, configtypes.{{.Format}})
+	}
+{{- end}}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+`))
+
+// generate builds and runs a program that calls configtypes.DescribeVars or configtypes.JSONSchema,
+// and returns its output.
+func generate(p params) ([]byte, error) {
+	formats := map[string]string{
+		"markdown":   "VarDocFormatMarkdown",
+		"text":       "VarDocFormatText",
+		"jsonschema": "jsonschema",
+	}
+	format, ok := formats[p.format]
+	if !ok {
+		return nil, fmt.Errorf("unknown format %q", p.format)
+	}
+	// These names are inserted into the program's source, so they must be exactly one identifier.
+	if !token.IsIdentifier(p.typeName) || !token.IsExported(p.typeName) {
+		return nil, fmt.Errorf("invalid type name %q: must be the name of an exported type", p.typeName)
+	}
+	if p.defaultsFunc != "" && (!token.IsIdentifier(p.defaultsFunc) || !token.IsExported(p.defaultsFunc)) {
+		return nil, fmt.Errorf("invalid defaults function name %q: must be the name of an exported function",
+			p.defaultsFunc)
+	}
+	value := "target." + p.typeName + "{}"
+	if p.defaultsFunc != "" {
+		value = "target." + p.defaultsFunc + "()"
+	}
+
+	pkgInfo, err := goCommand(p.dir, "list", "-f", "{{.Name}} {{.ImportPath}}", ".")
+	if err != nil {
+		return nil, err
+	}
+	pkgName, importPath, _ := strings.Cut(strings.TrimSpace(string(pkgInfo)), " ")
+	if pkgName == "main" {
+		return nil, errors.New("cannot document a type in a main package")
+	}
+
+	var src bytes.Buffer
+	err = programTemplate.Execute(&src, map[string]interface{}{
+		"ImportPath": importPath,
+		"Prefix":     p.prefix,
+		"Suffix":     p.suffix,
+		"Value":      value,
+		"Recursive":  p.recursive,
+		"Format":     format,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The program must be within the same module as the target package, so that it can import it even
+	// if it is internal, and so it uses the same dependency versions.
+	programDir, err := os.MkdirTemp(p.dir, "confdoc_tmp_")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(programDir) //nolint:errcheck
+	if err := os.WriteFile(filepath.Join(programDir, "main.go"), src.Bytes(), 0o600); err != nil {
+		return nil, err
+	}
+	return goCommand(p.dir, "run", "./"+filepath.Base(programDir))
+}
+
+func goCommand(dir string, args ...string) ([]byte, error) {
+	cmd := exec.Command("go", args...)
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("go %s failed: %w\n%s", args[0], err, stderr.String())
+	}
+	return out, nil
+}
+
+// writeOutput writes the table to standard output or to a file. If the file contains the begin and
+// end markers, only the text between them is replaced.
+func writeOutput(path string, table []byte) error {
+	if path == "" {
+		_, err := os.Stdout.Write(table)
+		return err
+	}
+	if existing, err := os.ReadFile(path); err == nil { //nolint:gosec
+		if updated, ok := replaceMarkedSection(string(existing), string(table)); ok {
+			table = []byte(updated)
+		}
+	}
+	return os.WriteFile(path, table, 0o644) //nolint:gosec
+}
+
+func replaceMarkedSection(document, table string) (string, bool) {
+	begin := strings.Index(document, beginMarker)
+	if begin < 0 {
+		return "", false
+	}
+	contentStart := begin + len(beginMarker)
+	end := strings.Index(document[contentStart:], endMarker)
+	if end < 0 {
+		return "", false
+	}
+	return document[:contentStart] + "\n" + table + document[contentStart+end:], true
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPackageSource = `package testpkg
+
+import "github.com/launchdarkly/go-configtypes/v2"
+
+type Config struct {
+	Port    configtypes.OptIntGreaterThanZero ` + "`conf:\"PORT,required,desc=HTTP port\"`" + `
+	Timeout configtypes.OptDuration ` + "`conf:\"TIMEOUT\"`" + `
+}
+
+func DefaultConfig() *Config {
+	return &Config{Timeout: configtypes.NewOptDuration(90000000000)}
+}
+`
+
+// makeTestPackage creates a package within this module, since confdoc can only document types that
+// it can import.
+func makeTestPackage(t *testing.T) string {
+	dir, err := os.MkdirTemp(".", "testpkg_")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.go"), []byte(testPackageSource), 0o600))
+	return dir
+}
+
+func TestGenerate(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds a program with the go command")
+	}
+	dir := makeTestPackage(t)
+
+	t.Run("Markdown with defaults", func(t *testing.T) {
+		out, err := generate(params{dir: dir, typeName: "Config", defaultsFunc: "DefaultConfig",
+			prefix: "APP_", format: "markdown", recursive: true})
+		require.NoError(t, err)
+		assert.Equal(t, strings.Join([]string{
+			"| Variable | Type | Format | Required | Default | Description |",
+			"| --- | --- | --- | --- | --- | --- |",
+			"| `APP_PORT` | `configtypes.OptIntGreaterThanZero` | integer greater than zero | yes |  | HTTP port |",
+			"| `APP_TIMEOUT` | `configtypes.OptDuration` | duration like 1m30s | no | `1m30s` |  |",
+			"",
+		}, "\n"), string(out))
+	})
+
+	t.Run("text without defaults", func(t *testing.T) {
+		out, err := generate(params{dir: dir, typeName: "Config", format: "text"})
+		require.NoError(t, err)
+		assert.Contains(t, string(out), "\nTIMEOUT   configtypes.OptDuration            duration like 1m30s        no\n")
+	})
+
+	t.Run("JSON schema", func(t *testing.T) {
+		out, err := generate(params{dir: dir, typeName: "Config", format: "jsonschema"})
+		require.NoError(t, err)
+		assert.Contains(t, string(out), `"required": [
+    "Port"
+  ]`)
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		_, err := generate(params{dir: dir, typeName: "Other", format: "text"})
+		assert.Error(t, err)
+	})
+
+	t.Run("temporary program is removed", func(t *testing.T) {
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Len(t, entries, 1)
+	})
+}
+
+func TestGenerateErrorsBeforeBuilding(t *testing.T) {
+	_, err := generate(params{dir: ".", typeName: "Config", format: "html"})
+	assert.EqualError(t, err, `unknown format "html"`)
+
+	_, err = generate(params{dir: ".", typeName: "Params", format: "text"})
+	assert.EqualError(t, err, "cannot document a type in a main package")
+
+	for _, name := range []string{"", "config", "pkg.Config", "Config{}", "Config; os.Exit(1)", "Config[int]"} {
+		_, err = generate(params{dir: ".", typeName: name, format: "text"})
+		assert.EqualError(t, err, fmt.Sprintf("invalid type name %q: must be the name of an exported type", name))
+	}
+
+	for _, name := range []string{"defaults", "Defaults()", "Defaults(); os.Exit(1)"} {
+		_, err = generate(params{dir: ".", typeName: "Config", defaultsFunc: name, format: "text"})
+		assert.EqualError(t, err,
+			fmt.Sprintf("invalid defaults function name %q: must be the name of an exported function", name))
+	}
+}
+
+func TestReplaceMarkedSection(t *testing.T) {
+	doc := "# Config\n\n" + beginMarker + "\nold table\n" + endMarker + "\n\nMore text\n"
+	updated, ok := replaceMarkedSection(doc, "new table\n")
+	assert.True(t, ok)
+	assert.Equal(t, "# Config\n\n"+beginMarker+"\nnew table\n"+endMarker+"\n\nMore text\n", updated)
+
+	_, ok = replaceMarkedSection("no markers", "table")
+	assert.False(t, ok)
+	_, ok = replaceMarkedSection(endMarker+beginMarker, "table")
+	assert.False(t, ok)
+}
+
+func TestWriteOutput(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "README.md")
+
+	require.NoError(t, writeOutput(path, []byte("table\n")))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "table\n", string(data))
+
+	require.NoError(t, os.WriteFile(path, []byte("intro\n"+beginMarker+endMarker+"\n"), 0o600))
+	require.NoError(t, writeOutput(path, []byte("table\n")))
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "intro\n"+beginMarker+"\ntable\n"+endMarker+"\n", string(data))
+}
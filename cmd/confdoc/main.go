@@ -0,0 +1,66 @@
+// Command confdoc generates documentation for the configuration variables that are read from a
+// struct by VarReader.ReadStruct, as a Markdown or plain-text table. It can also generate a JSON
+// Schema for the struct's JSON representation.
+//
+//	confdoc -type Config [-defaults DefaultConfig] [-prefix APP_] [-format text] [-output README.md] [dir]
+//
+// The table has a row for every variable, with the variable name, Go type, accepted format, whether it
+// is required, its default value, and the description from the "desc=" field tag option if any. See
+// configtypes.DescribeVars for details.
+//
+// With -format jsonschema, the output is instead a JSON Schema as described by configtypes.JSONSchema,
+// and -prefix, -suffix, and -recursive are ignored.
+//
+// If -defaults is specified, it is the name of a function in the same package that returns the
+// struct (or a pointer to it) populated with default values; otherwise, a zero value is used. The
+// -type and -defaults values must each be a single exported name, such as Config.
+//
+// The output is written to standard output, unless -output is specified. If the output file already
+// exists and contains these two lines, only the text between them is replaced, so the table can be
+// kept up to date within a larger document:
+//
+//	<!-- confdoc:begin -->
+//	<!-- confdoc:end -->
+//
+// Since the struct type can only be examined at runtime, confdoc works by building and running a small
+// program that imports the package in dir. That package must not be a main package.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+func main() {
+	var p params
+	flag.StringVar(&p.typeName, "type", "", "name of the struct type (required)")
+	flag.StringVar(&p.defaultsFunc, "defaults", "", "name of a function that returns the struct with default values")
+	flag.StringVar(&p.prefix, "prefix", "", "prefix to add to all variable names")
+	flag.StringVar(&p.suffix, "suffix", "", "suffix to add to all variable names")
+	flag.StringVar(&p.format, "format", "markdown", `output format: "markdown", "text", or "jsonschema"`)
+	flag.BoolVar(&p.recursive, "recursive", true, "include variables in nested structs")
+	output := flag.String("output", "", "output file (default: standard output)")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: confdoc -type T [options] [directory]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if p.typeName == "" || flag.NArg() > 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	p.dir = "."
+	if flag.NArg() == 1 {
+		p.dir = flag.Arg(0)
+	}
+
+	table, err := generate(p)
+	if err == nil {
+		err = writeOutput(*output, table)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "confdoc:", err)
+		os.Exit(1)
+	}
+}
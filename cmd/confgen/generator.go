@@ -0,0 +1,713 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/launchdarkly/go-configtypes/v2/internal/conftag"
+)
+
+const configtypesPath = "github.com/launchdarkly/go-configtypes/v2"
+
+// localType is a named type declared in the package being processed.
+type localType struct {
+	name string
+	spec *ast.TypeSpec
+	file *ast.File
+}
+
+type typeKind int
+
+const (
+	// kindUnknown is a type from another package, which may or may not be a struct.
+	kindUnknown typeKind = iota
+	kindBool
+	kindNumber
+	kindString
+	// kindNilable is a func or chan type, which has nil as its zero value.
+	kindNilable
+	kindInterface
+	// kindOpt is any type from the configtypes package.
+	kindOpt
+	// kindStruct is a struct type declared in this package.
+	kindStruct
+	kindPointer
+	kindSlice
+	kindArray
+	kindMap
+)
+
+// typeInfo describes a type expression as far as the generator needs to know about it.
+type typeInfo struct {
+	kind  typeKind
+	expr  string     // Go source for the type, as it should appear in generated code
+	elem  *typeInfo  // for pointers, slices, arrays, and maps
+	key   *typeInfo  // for maps
+	local *localType // for kindStruct
+}
+
+// isOrdered returns true if values of the type can be compared with the < operator.
+func (t *typeInfo) isOrdered() bool {
+	return t.kind == kindString || (t.kind == kindNumber && !strings.HasPrefix(t.expr, "complex"))
+}
+
+func (t *typeInfo) isStructOrStructPointer() bool {
+	return t.kind == kindStruct || (t.kind == kindPointer && t.elem.kind == kindStruct)
+}
+
+// mayBeStructOrStructPointer returns true if the type is, or might be, something that the
+// reflection-based functions would treat as a nested struct or struct pointer.
+func (t *typeInfo) mayBeStructOrStructPointer() bool {
+	if t.kind == kindPointer {
+		return t.elem.kind == kindStruct || t.elem.kind == kindUnknown
+	}
+	return t.kind == kindStruct || t.kind == kindUnknown
+}
+
+type generator struct {
+	fset      *token.FileSet
+	pkgName   string
+	types     map[string]*localType
+	recursive bool
+	imports   map[string]string // package name -> import path, for imports used by the output
+	generated map[string]bool
+	queue     []*localType
+	needsSort bool
+	buf       bytes.Buffer
+}
+
+// generate parses the Go package in dir, and returns the formatted source of a file containing
+// methods for the named types.
+func generate(dir string, typeNames []string, recursive bool, outputFileName string) ([]byte, error) {
+	g := &generator{
+		fset:      token.NewFileSet(),
+		types:     make(map[string]*localType),
+		recursive: recursive,
+		imports:   map[string]string{"configtypes": configtypesPath},
+		generated: make(map[string]bool),
+	}
+	if err := g.parsePackage(dir, outputFileName); err != nil {
+		return nil, err
+	}
+
+	var body bytes.Buffer
+	for _, name := range typeNames {
+		t, err := g.structType(strings.TrimSpace(name))
+		if err != nil {
+			return nil, err
+		}
+		g.buf.Reset()
+		g.writeExportedMethods(t)
+		body.Write(g.buf.Bytes())
+		g.enqueue(t)
+	}
+	for len(g.queue) > 0 {
+		t := g.queue[0]
+		g.queue = g.queue[1:]
+		g.buf.Reset()
+		if err := g.writeHelperMethods(t); err != nil {
+			return nil, err
+		}
+		body.Write(g.buf.Bytes())
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by confgen -type %s", strings.Join(typeNames, ","))
+	if !recursive {
+		out.WriteString(" -recursive=false")
+	}
+	out.WriteString("; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&out, "package %s\n\nimport (\n", g.pkgName)
+	if g.needsSort {
+		g.imports["sort"] = "sort"
+	}
+	var stdImports, otherImports []string
+	for name, path := range g.imports {
+		spec := fmt.Sprintf("%q", path)
+		if name != defaultImportName(path) {
+			spec = name + " " + spec
+		}
+		if strings.Contains(strings.Split(path, "/")[0], ".") {
+			otherImports = append(otherImports, spec)
+		} else {
+			stdImports = append(stdImports, spec)
+		}
+	}
+	writeImportGroup(&out, stdImports)
+	if len(stdImports) != 0 && len(otherImports) != 0 {
+		out.WriteString("\n")
+	}
+	writeImportGroup(&out, otherImports)
+	out.WriteString(")\n")
+	out.Write(body.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("internal error formatting generated code: %w", err)
+	}
+	return src, nil
+}
+
+// writeImportGroup writes import specs sorted by path, the same way goimports would.
+func writeImportGroup(out *bytes.Buffer, specs []string) {
+	sort.Slice(specs, func(i, j int) bool {
+		return importSpecPath(specs[i]) < importSpecPath(specs[j])
+	})
+	for _, spec := range specs {
+		fmt.Fprintf(out, "\t%s\n", spec)
+	}
+}
+
+func importSpecPath(spec string) string {
+	return spec[strings.Index(spec, `"`):]
+}
+
+func (g *generator) parsePackage(dir, outputFileName string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") ||
+			name == outputFileName {
+			continue
+		}
+		if match, err := build.Default.MatchFile(dir, name); err != nil || !match {
+			continue
+		}
+		file, err := parser.ParseFile(g.fset, filepath.Join(dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return err
+		}
+		if g.pkgName == "" {
+			g.pkgName = file.Name.Name
+		} else if file.Name.Name != g.pkgName {
+			return fmt.Errorf("found packages %s and %s in %s", g.pkgName, file.Name.Name, dir)
+		}
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				g.types[typeSpec.Name.Name] = &localType{name: typeSpec.Name.Name, spec: typeSpec, file: file}
+			}
+		}
+	}
+	if g.pkgName == "" {
+		return fmt.Errorf("no Go source files in %s", dir)
+	}
+	return nil
+}
+
+func (g *generator) structType(name string) (*localType, error) {
+	t := g.types[name]
+	if t == nil {
+		return nil, fmt.Errorf("type %s not found", name)
+	}
+	if t.spec.TypeParams != nil {
+		return nil, fmt.Errorf("type %s: generic types are not supported", name)
+	}
+	if _, ok := t.spec.Type.(*ast.StructType); !ok || t.spec.Assign.IsValid() {
+		return nil, fmt.Errorf("type %s is not a struct type", name)
+	}
+	return t, nil
+}
+
+func (g *generator) enqueue(t *localType) {
+	if !g.generated[t.name] {
+		g.generated[t.name] = true
+		g.queue = append(g.queue, t)
+	}
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+func (g *generator) writeExportedMethods(t *localType) {
+	kind := "recursive"
+	if !g.recursive {
+		kind = "non-recursive"
+	}
+	g.printf("\n// ReadFrom reads configuration variables into the fields of %s. It behaves the same as\n", t.name)
+	g.printf("// calling the %s version of r.ReadStruct(s), but does not use reflection.\n", kind)
+	g.printf("func (s *%s) ReadFrom(r *configtypes.VarReader) {\n", t.name)
+	g.printf("\ts.confgenReadFrom(r, make(map[interface{}]bool))\n}\n")
+	g.printf("\n// Validate checks the fields of %s according to their field tags. It behaves the same as\n", t.name)
+	g.printf("// calling the %s version of configtypes.ValidateStruct(s), but does not use reflection.\n", kind)
+	g.printf("func (s *%s) Validate() configtypes.ValidationResult {\n", t.name)
+	g.printf("\treturn s.confgenValidate(make(map[interface{}]bool))\n}\n")
+}
+
+// field is an exported field of a struct type.
+type field struct {
+	name    string
+	typ     *typeInfo
+	tagInfo conftag.Info
+}
+
+func (g *generator) fields(t *localType) ([]field, error) {
+	var ret []field
+	for _, f := range t.spec.Type.(*ast.StructType).Fields.List {
+		names := make([]string, 0, len(f.Names))
+		for _, n := range f.Names {
+			names = append(names, n.Name)
+		}
+		if len(f.Names) == 0 { // embedded field
+			names = append(names, embeddedFieldName(f.Type))
+		}
+		var tag string
+		if f.Tag != nil {
+			tag, _ = strconv.Unquote(f.Tag.Value)
+		}
+		for _, name := range names {
+			if !ast.IsExported(name) {
+				continue
+			}
+			tagInfo, err := conftag.Parse(reflect.StructTag(tag).Get(conftag.Name))
+			if err != nil {
+				return nil, fmt.Errorf("%s.%s: %w", t.name, name, err)
+			}
+			typ, err := g.analyzeType(f.Type, t.file)
+			if err != nil {
+				return nil, fmt.Errorf("%s.%s: %w", t.name, name, err)
+			}
+			// The reflection-based functions also treat these types as required or secret regardless of
+			// the field tag; see configtypes.RequiredValue and configtypes.SecretValue.
+			optType := typ
+			if optType.kind == kindPointer {
+				optType = optType.elem
+			}
+			if optType.kind == kindOpt {
+				tagInfo.Required = tagInfo.Required || requiredOptTypes[optType.expr]
+				tagInfo.Secret = tagInfo.Secret || secretOptTypes[optType.expr]
+			}
+			ret = append(ret, field{name: name, typ: typ, tagInfo: tagInfo})
+		}
+	}
+	return ret, nil
+}
+
+func embeddedFieldName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return embeddedFieldName(e.X)
+	case *ast.SelectorExpr:
+		return e.Sel.Name
+	case *ast.Ident:
+		return e.Name
+	case *ast.IndexExpr:
+		return embeddedFieldName(e.X)
+	case *ast.IndexListExpr:
+		return embeddedFieldName(e.X)
+	}
+	return ""
+}
+
+func (g *generator) writeHelperMethods(t *localType) error {
+	fields, err := g.fields(t)
+	if err != nil {
+		return err
+	}
+
+	g.printf("\nfunc (s *%s) confgenReadFrom(r *configtypes.VarReader, visited map[interface{}]bool) {\n", t.name)
+	for _, f := range fields {
+		if err := g.writeReadField(t, f); err != nil {
+			return err
+		}
+	}
+	g.printf("}\n")
+
+	g.printf("\nfunc (s *%s) confgenValidate(visited map[interface{}]bool) configtypes.ValidationResult {\n", t.name)
+	g.printf("\tvar result configtypes.ValidationResult\n")
+	for _, f := range fields {
+		if err := g.writeValidateField(t, f); err != nil {
+			return err
+		}
+	}
+	g.printf("\treturn result\n}\n")
+	return nil
+}
+
+func unsupportedError(t *localType, f field) error {
+	return fmt.Errorf("%s.%s: cannot determine whether type %s is a struct without reflection",
+		t.name, f.name, f.typ.expr)
+}
+
+func (g *generator) writeReadField(t *localType, f field) error {
+	readMethod := "Read"
+	if f.tagInfo.Required {
+		readMethod = "ReadRequired"
+	}
+	if f.tagInfo.Secret {
+		readMethod = "WithSecretValues()." + readMethod
+	}
+	switch {
+	case f.tagInfo.VarName != "" && f.typ.kind == kindPointer:
+		g.printf("\tif s.%s != nil {\n", f.name)
+		g.printf("\t\tr.%s(%q, s.%s)\n", readMethod, f.tagInfo.VarName, f.name)
+		g.printf("\t} else if v := new(%s); r.%s(%q, v) {\n", f.typ.elem.expr, readMethod, f.tagInfo.VarName)
+		g.printf("\t\ts.%s = v\n\t}\n", f.name)
+	case f.tagInfo.VarName != "":
+		g.printf("\tr.%s(%q, &s.%s)\n", readMethod, f.tagInfo.VarName, f.name)
+	case !g.recursive:
+		// untagged fields are only used for recursion
+	case f.typ.kind == kindStruct:
+		g.enqueue(f.typ.local)
+		g.printf("\ts.%s.confgenReadFrom(r, visited)\n", f.name)
+	case f.typ.kind == kindPointer && f.typ.elem.kind == kindStruct:
+		g.enqueue(f.typ.elem.local)
+		g.printf("\tif s.%s != nil && !visited[s.%s] {\n", f.name, f.name)
+		g.printf("\t\tvisited[s.%s] = true\n", f.name)
+		g.printf("\t\ts.%s.confgenReadFrom(r, visited)\n", f.name)
+		g.printf("\t\tdelete(visited, s.%s)\n\t}\n", f.name)
+	case f.typ.mayBeStructOrStructPointer():
+		return unsupportedError(t, f)
+	}
+	return nil
+}
+
+func (g *generator) writeValidateField(t *localType, f field) error {
+	typ := f.typ
+	switch {
+	case typ.kind == kindStruct:
+		if g.recursive {
+			g.enqueue(typ.local)
+			g.printf("\tif sub := s.%s.confgenValidate(visited); !sub.OK() {\n", f.name)
+			g.printf("\t\tresult.AddAll(configtypes.NewValidationPath(%q), sub)\n\t}\n", f.name)
+		}
+		return nil
+	case typ.kind == kindUnknown:
+		if f.tagInfo.Required || g.recursive {
+			return unsupportedError(t, f)
+		}
+		return nil
+	case typ.kind == kindPointer && (typ.elem.kind == kindUnknown || typ.elem.kind == kindPointer),
+		typ.kind == kindInterface:
+		if g.recursive {
+			return unsupportedError(t, f)
+		}
+	}
+
+	if typ.kind == kindPointer && typ.elem.kind == kindStruct {
+		g.writeValidateStructPointerField(f)
+		return nil
+	}
+	if f.tagInfo.Required {
+		g.printf("\tif %s {\n", zeroCheck("s."+f.name, typ))
+		g.writeRequiredError(f)
+		g.printf("\t}\n")
+	}
+	if !g.recursive || (typ.kind != kindSlice && typ.kind != kindArray && typ.kind != kindMap) {
+		return nil
+	}
+	elem := typ.elem
+	if !elem.isStructOrStructPointer() {
+		if elem.mayBeStructOrStructPointer() {
+			return unsupportedError(t, field{name: f.name, typ: elem})
+		}
+		return nil
+	}
+	if elem.kind == kindStruct {
+		g.enqueue(elem.local)
+	} else {
+		g.enqueue(elem.elem.local)
+	}
+	if typ.kind == kindMap {
+		g.needsSort = true
+		g.printf("\t{\n")
+		g.printf("\t\tkeys := make([]%s, 0, len(s.%s))\n", typ.key.expr, f.name)
+		g.printf("\t\tfor k := range s.%s {\n\t\t\tkeys = append(keys, k)\n\t\t}\n", f.name)
+		g.printf("\t\tsort.Slice(keys, func(i, j int) bool {\n")
+		if typ.key.isOrdered() {
+			g.printf("\t\t\treturn keys[i] < keys[j]\n\t\t})\n")
+		} else {
+			g.printf("\t\t\treturn configtypes.PathKey(keys[i]).String() < " +
+				"configtypes.PathKey(keys[j]).String()\n\t\t})\n")
+		}
+		g.printf("\t\tfor _, k := range keys {\n")
+		g.writeValidateElement(fmt.Sprintf("s.%s[k]", f.name), elem,
+			fmt.Sprintf("configtypes.ValidationPath{configtypes.PathField(%q), configtypes.PathKey(k)}", f.name))
+		g.printf("\t\t}\n\t}\n")
+		return nil
+	}
+	g.printf("\tfor i := range s.%s {\n", f.name)
+	g.writeValidateElement(fmt.Sprintf("s.%s[i]", f.name), elem,
+		fmt.Sprintf("configtypes.ValidationPath{configtypes.PathField(%q), configtypes.PathIndex(i)}", f.name))
+	g.printf("\t}\n")
+	return nil
+}
+
+func (g *generator) writeRequiredError(f field) {
+	g.printf("\t\tresult.Add(configtypes.ValidationError{Path: configtypes.NewValidationPath(%q), ", f.name)
+	if f.tagInfo.VarName == "" {
+		g.printf("Err: configtypes.ErrRequired()})\n")
+		return
+	}
+	g.printf("Err: configtypes.ErrRequired(), VarName: %q})\n", f.tagInfo.VarName)
+}
+
+func (g *generator) writeValidateStructPointerField(f field) {
+	path := fmt.Sprintf("configtypes.NewValidationPath(%q)", f.name)
+	switch {
+	case f.tagInfo.Required && g.recursive:
+		g.enqueue(f.typ.elem.local)
+		g.printf("\tif s.%s == nil {\n", f.name)
+		g.writeRequiredError(f)
+		g.printf("\t} else if !visited[s.%s] {\n", f.name)
+		g.writeValidatePointer("s."+f.name, path)
+		g.printf("\t}\n")
+	case f.tagInfo.Required:
+		g.printf("\tif s.%s == nil {\n", f.name)
+		g.writeRequiredError(f)
+		g.printf("\t}\n")
+	case g.recursive:
+		g.enqueue(f.typ.elem.local)
+		g.printf("\tif s.%s != nil && !visited[s.%s] {\n", f.name, f.name)
+		g.writeValidatePointer("s."+f.name, path)
+		g.printf("\t}\n")
+	}
+}
+
+func (g *generator) writeValidateElement(elemExpr string, elem *typeInfo, path string) {
+	g.printf("\t\telem := %s\n", elemExpr)
+	if elem.kind == kindStruct {
+		g.printf("\t\tif sub := elem.confgenValidate(visited); !sub.OK() {\n")
+		g.printf("\t\t\tresult.AddAll(%s, sub)\n\t\t}\n", path)
+		return
+	}
+	g.printf("\t\tif elem != nil && !visited[elem] {\n")
+	g.writeValidatePointer("elem", path)
+	g.printf("\t\t}\n")
+}
+
+func (g *generator) writeValidatePointer(ptrExpr, path string) {
+	g.printf("\t\tvisited[%s] = true\n", ptrExpr)
+	g.printf("\t\tsub := %s.confgenValidate(visited)\n", ptrExpr)
+	g.printf("\t\tdelete(visited, %s)\n", ptrExpr)
+	g.printf("\t\tif !sub.OK() {\n\t\t\tresult.AddAll(%s, sub)\n\t\t}\n", path)
+}
+
+// zeroCheck returns a boolean expression that is true if the value is the zero value for its type,
+// equivalent to reflect.Value.IsZero.
+func zeroCheck(valueExpr string, typ *typeInfo) string {
+	switch typ.kind {
+	case kindBool:
+		return "!" + valueExpr
+	case kindNumber:
+		return valueExpr + " == 0"
+	case kindString:
+		return valueExpr + ` == ""`
+	case kindOpt:
+		return "!" + valueExpr + ".IsDefined()"
+	case kindPointer, kindSlice, kindMap, kindNilable, kindInterface:
+		return valueExpr + " == nil"
+	default:
+		return valueExpr + " == *new(" + typ.expr + ")"
+	}
+}
+
+var requiredOptTypes = map[string]bool{ //nolint:gochecknoglobals
+	"configtypes.ReqSecret": true,
+}
+
+var secretOptTypes = map[string]bool{ //nolint:gochecknoglobals
+	"configtypes.OptSecret": true, "configtypes.ReqSecret": true,
+}
+
+var builtinKinds = map[string]typeKind{ //nolint:gochecknoglobals
+	"bool": kindBool, "string": kindString, "error": kindInterface, "any": kindInterface,
+	"int": kindNumber, "int8": kindNumber, "int16": kindNumber, "int32": kindNumber, "int64": kindNumber,
+	"uint": kindNumber, "uint8": kindNumber, "uint16": kindNumber, "uint32": kindNumber, "uint64": kindNumber,
+	"uintptr": kindNumber, "byte": kindNumber, "rune": kindNumber, "float32": kindNumber, "float64": kindNumber,
+	"complex64": kindNumber, "complex128": kindNumber,
+}
+
+func (g *generator) analyzeType(expr ast.Expr, file *ast.File) (*typeInfo, error) {
+	return g.analyzeTypeInternal(expr, file, nil)
+}
+
+func (g *generator) analyzeTypeInternal(expr ast.Expr, file *ast.File, seen map[string]bool) (*typeInfo, error) {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if t := g.types[e.Name]; t != nil {
+			return g.analyzeLocalType(t, seen)
+		}
+		if kind, ok := builtinKinds[e.Name]; ok {
+			return &typeInfo{kind: kind, expr: e.Name}, nil
+		}
+		return nil, fmt.Errorf("unknown type %s", e.Name)
+	case *ast.SelectorExpr:
+		pkgIdent, ok := e.X.(*ast.Ident)
+		if !ok {
+			break
+		}
+		path, err := g.importPath(file, pkgIdent.Name)
+		if err != nil {
+			return nil, err
+		}
+		if path == configtypesPath {
+			return &typeInfo{kind: kindOpt, expr: "configtypes." + e.Sel.Name}, nil
+		}
+		return &typeInfo{kind: kindUnknown, expr: pkgIdent.Name + "." + e.Sel.Name}, nil
+	case *ast.IndexExpr:
+		// a generic type from configtypes, such as configtypes.OptIntBetween[PortBounds]
+		base, err := g.analyzeTypeInternal(e.X, file, seen)
+		if err != nil || base.kind != kindOpt {
+			break
+		}
+		index, err := g.exprString(e.Index)
+		if err != nil {
+			return nil, err
+		}
+		return &typeInfo{kind: kindOpt, expr: base.expr + "[" + index + "]"}, nil
+	case *ast.StarExpr:
+		elem, err := g.analyzeTypeInternal(e.X, file, seen)
+		if err != nil {
+			return nil, err
+		}
+		return &typeInfo{kind: kindPointer, expr: "*" + elem.expr, elem: elem}, nil
+	case *ast.ArrayType:
+		elem, err := g.analyzeTypeInternal(e.Elt, file, seen)
+		if err != nil {
+			return nil, err
+		}
+		if e.Len == nil {
+			return &typeInfo{kind: kindSlice, expr: "[]" + elem.expr, elem: elem}, nil
+		}
+		length, err := g.exprString(e.Len)
+		if err != nil {
+			return nil, err
+		}
+		return &typeInfo{kind: kindArray, expr: "[" + length + "]" + elem.expr, elem: elem}, nil
+	case *ast.MapType:
+		key, err := g.analyzeTypeInternal(e.Key, file, seen)
+		if err != nil {
+			return nil, err
+		}
+		elem, err := g.analyzeTypeInternal(e.Value, file, seen)
+		if err != nil {
+			return nil, err
+		}
+		return &typeInfo{kind: kindMap, expr: "map[" + key.expr + "]" + elem.expr, key: key, elem: elem}, nil
+	case *ast.InterfaceType:
+		s, err := g.exprString(e)
+		return &typeInfo{kind: kindInterface, expr: s}, err
+	case *ast.FuncType, *ast.ChanType:
+		s, err := g.exprString(e)
+		return &typeInfo{kind: kindNilable, expr: s}, err
+	}
+	s, _ := g.exprString(expr)
+	return nil, fmt.Errorf("unsupported type %s", s)
+}
+
+func (g *generator) analyzeLocalType(t *localType, seen map[string]bool) (*typeInfo, error) {
+	if t.spec.TypeParams != nil {
+		return nil, fmt.Errorf("generic type %s is not supported", t.name)
+	}
+	if _, ok := t.spec.Type.(*ast.StructType); ok {
+		if t.spec.Assign.IsValid() {
+			return nil, fmt.Errorf("alias %s for an anonymous struct type is not supported", t.name)
+		}
+		return &typeInfo{kind: kindStruct, expr: t.name, local: t}, nil
+	}
+	if seen[t.name] {
+		return nil, fmt.Errorf("invalid recursive type %s", t.name)
+	}
+	if seen == nil {
+		seen = make(map[string]bool)
+	}
+	seen[t.name] = true
+	underlying, err := g.analyzeTypeInternal(t.spec.Type, t.file, seen)
+	if err != nil {
+		return nil, err
+	}
+	if t.spec.Assign.IsValid() { // an alias is exactly equivalent to the aliased type
+		return underlying, nil
+	}
+	if underlying.kind == kindPointer {
+		return nil, fmt.Errorf("named pointer type %s is not supported", t.name)
+	}
+	ret := *underlying
+	ret.expr = t.name
+	return &ret, nil
+}
+
+// importPath finds the import path for a package name in a source file, and records the import as
+// one that the generated file will need.
+func (g *generator) importPath(file *ast.File, name string) (string, error) {
+	for _, imp := range file.Imports {
+		path, _ := strconv.Unquote(imp.Path.Value)
+		importName := defaultImportName(path)
+		if imp.Name != nil {
+			importName = imp.Name.Name
+		}
+		if importName != name {
+			continue
+		}
+		if path != configtypesPath {
+			if existing, ok := g.imports[name]; ok && existing != path {
+				return "", fmt.Errorf("package name %s refers to both %s and %s", name, existing, path)
+			}
+			g.imports[name] = path
+		}
+		return path, nil
+	}
+	return "", fmt.Errorf("unknown package %s in %s", name, g.fset.Position(file.Pos()).Filename)
+}
+
+// defaultImportName guesses the package name for an import path that has no explicit name, using
+// the same conventions as goimports: a trailing major version element such as "v2", or a ".v2"
+// suffix as used by gopkg.in, is not part of the name.
+func defaultImportName(path string) string {
+	if path == configtypesPath {
+		return "configtypes"
+	}
+	parts := strings.Split(path, "/")
+	name := parts[len(parts)-1]
+	if len(parts) > 1 && isMajorVersion(name) {
+		name = parts[len(parts)-2]
+	}
+	if i := strings.Index(name, ".v"); i > 0 && isMajorVersion(name[i+1:]) {
+		name = name[:i]
+	}
+	name = strings.TrimPrefix(name, "go-")
+	if i := strings.IndexFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	}); i >= 0 {
+		name = name[:i]
+	}
+	return name
+}
+
+func isMajorVersion(s string) bool {
+	if len(s) < 2 || s[0] != 'v' {
+		return false
+	}
+	_, err := strconv.Atoi(s[1:])
+	return err == nil
+}
+
+func (g *generator) exprString(expr ast.Expr) (string, error) {
+	var b bytes.Buffer
+	if err := printer.Fprint(&b, g.fset, expr); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
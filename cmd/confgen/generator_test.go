@@ -0,0 +1,89 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDataDir = "../../internal/confgentest"
+
+func TestGeneratedFilesAreUpToDate(t *testing.T) {
+	for _, p := range []struct {
+		types     []string
+		recursive bool
+		output    string
+	}{
+		{[]string{"Config"}, true, "config_confgen.go"},
+		{[]string{"NonRecursiveConfig"}, false, "nonrecursive_confgen.go"},
+	} {
+		t.Run(p.output, func(t *testing.T) {
+			expected, err := os.ReadFile(filepath.Join(testDataDir, p.output))
+			require.NoError(t, err)
+			actual, err := generate(testDataDir, p.types, p.recursive, p.output)
+			require.NoError(t, err)
+			assert.Equal(t, string(expected), string(actual), "run go generate ./... to update")
+		})
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	for _, p := range []struct {
+		name, typeName, source, message string
+	}{
+		{"type not found", "Config", "type Other struct{}", "type Config not found"},
+		{"not a struct", "Config", "type Config int", "type Config is not a struct type"},
+		{"generic type", "Config", "type Config[T any] struct{ A T }", "type Config: generic types are not supported"},
+		{
+			"invalid tag", "Config", "type Config struct{ A string `conf:\"A,optional\"` }",
+			`Config.A: unrecognized field tag option "optional"`,
+		},
+		{
+			"imported type that might be a struct", "Config",
+			"import \"net/url\"\n\ntype Config struct{ U url.URL }",
+			"Config.U: cannot determine whether type url.URL is a struct without reflection",
+		},
+		{
+			"unknown package", "Config", "type Config struct{ U url.URL }",
+			"unknown package url in",
+		},
+		{
+			"named pointer type", "Config", "type P *int\n\ntype Config struct{ A P `conf:\"A\"` }",
+			"named pointer type P is not supported",
+		},
+	} {
+		t.Run(p.name, func(t *testing.T) {
+			dir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "config.go"),
+				[]byte("package test\n\n"+p.source+"\n"), 0o600))
+			_, err := generate(dir, []string{p.typeName}, true, "config_confgen.go")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), p.message)
+		})
+	}
+}
+
+func TestGenerateSkipsOutputFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.go"),
+		[]byte("package test\n\ntype Config struct{ A int `conf:\"A\"` }\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config_confgen.go"),
+		[]byte("package test\n\nthis is not valid Go\n"), 0o600))
+	src, err := generate(dir, []string{"Config"}, true, "config_confgen.go")
+	require.NoError(t, err)
+	assert.Contains(t, string(src), `r.Read("A", &s.A)`)
+}
+
+func TestDefaultImportName(t *testing.T) {
+	for path, name := range map[string]string{
+		"net/url":                                  "url",
+		"github.com/launchdarkly/go-configtypes":   "configtypes",
+		"github.com/launchdarkly/go-sdk-common/v3": "sdk",
+		"gopkg.in/yaml.v3":                         "yaml",
+	} {
+		assert.Equal(t, name, defaultImportName(path), path)
+	}
+}
@@ -0,0 +1,69 @@
+// Command confgen generates reflection-free methods for reading and validating configuration structs
+// that use configtypes field tags.
+//
+// It is meant to be used with go generate:
+//
+//	//go:generate go run github.com/launchdarkly/go-configtypes/v2/cmd/confgen -type Config
+//
+// For each type T named with -type, confgen generates these methods:
+//
+//	func (s *T) ReadFrom(r *configtypes.VarReader)
+//	func (s *T) Validate() configtypes.ValidationResult
+//
+// ReadFrom behaves the same as r.ReadStruct(s, true), and Validate behaves the same as
+// configtypes.ValidateStruct(s, true), except that they do not use reflection. If -recursive=false
+// is specified, they behave the same as the non-recursive versions of those functions instead.
+//
+// Any other struct types in the same package that the named types refer to, such as the type of a
+// nested struct field, also get unexported helper methods. Therefore, if several types refer to the
+// same nested type, they must all be named in a single confgen command.
+//
+// Some field types cannot be handled without reflection, because confgen only looks at the source
+// code of the current package and cannot tell whether a type from some other package is a struct.
+// In that case, or if a field tag is invalid, confgen reports an error and generates nothing.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	typeNames := flag.String("type", "", "comma-delimited list of struct type names (required)")
+	output := flag.String("output", "", "output file name (default: <first type name in lowercase>_confgen.go)")
+	recursive := flag.Bool("recursive", true, "generate recursive versions of ReadFrom and Validate")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: confgen -type T[,T...] [-output file] [-recursive=false] [directory]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if *typeNames == "" || flag.NArg() > 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	dir := "."
+	if flag.NArg() == 1 {
+		dir = flag.Arg(0)
+	}
+	names := strings.Split(*typeNames, ",")
+	outputPath := *output
+	if outputPath == "" {
+		outputPath = strings.ToLower(names[0]) + "_confgen.go"
+	}
+	if !filepath.IsAbs(outputPath) {
+		outputPath = filepath.Join(dir, outputPath)
+	}
+
+	src, err := generate(dir, names, *recursive, filepath.Base(outputPath))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "confgen:", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(outputPath, src, 0o644); err != nil { //nolint:gosec
+		fmt.Fprintln(os.Stderr, "confgen:", err)
+		os.Exit(1)
+	}
+}
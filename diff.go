@@ -0,0 +1,118 @@
+package configtypes
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ConfigChange describes a field whose value is different in two configuration structs, as returned
+// by Diff.
+type ConfigChange struct {
+	// Path is the path of the field within the struct, as described for DumpEntry.
+	Path ValidationPath
+	// VarName is the variable name from the field's "conf:" tag, or "" if there is none.
+	VarName string
+	// OldValue is the old value in text form, as described for DumpEntry.Value.
+	OldValue string
+	// NewValue is the new value in text form, as described for DumpEntry.Value.
+	NewValue string
+	// OldUnset is true if the old value was not set, as described for DumpEntry.Unset.
+	OldUnset bool
+	// NewUnset is true if the new value is not set, as described for DumpEntry.Unset. If OldUnset is
+	// false and NewUnset is true, the value has changed from defined to empty.
+	NewUnset bool
+	// Secret is true if the field is a secret, in which case OldValue and NewValue are redacted.
+	Secret bool
+}
+
+// String returns a description of the change, such as "Server.Port (PORT): 8080 -> <unset>".
+func (c ConfigChange) String() string {
+	name := c.Path.String()
+	if c.VarName != "" {
+		name += " (" + c.VarName + ")"
+	}
+	return fmt.Sprintf("%s: %s -> %s", name, c.OldValue, c.NewValue)
+}
+
+// Diff compares two values of the same struct type, and returns a description of each field whose
+// value is different. This can be used to log what changed when a configuration is reloaded. Both
+// parameters can be structs or struct pointers, but they must refer to the same struct type.
+//
+// The fields are found and described in the same way as for Dump. A field that exists in only one of
+// the structs, such as an element of a slice that has a different length, is treated as unset in the
+// other one. Values are compared by their text and, if the type has a MarshalJSON method, their JSON
+// representation, so that a change is reported for values with the same text such as the
+// OptStringList values ["a,b"] and ["a", "b"]. Secrets are compared using their actual values, so a
+// change is reported even if the redacted forms are the same; but the OldValue and NewValue of the
+// change are redacted.
+//
+// An error is returned if either parameter is not a struct, if they are not of the same type, or if
+// any field tag is invalid.
+func Diff(oldValue, newValue interface{}, recursive bool) ([]ConfigChange, error) {
+	oldStruct, ok1 := getReflectValueForStruct(oldValue)
+	newStruct, ok2 := getReflectValueForStruct(newValue)
+	if !ok1 || !ok2 {
+		return nil, errors.New( //nolint:staticcheck
+			"Diff was called on something other than a struct or struct pointer")
+	}
+	if oldStruct.Type() != newStruct.Type() {
+		return nil, fmt.Errorf("Diff was called with different types %s and %s", //nolint:staticcheck
+			oldStruct.Type(), newStruct.Type())
+	}
+	oldDumper := dumper{recursive: recursive, keepRaw: true}
+	if err := oldDumper.dumpFields(oldStruct, nil); err != nil {
+		return nil, err
+	}
+	newDumper := dumper{recursive: recursive, keepRaw: true}
+	if err := newDumper.dumpFields(newStruct, nil); err != nil {
+		return nil, err
+	}
+
+	oldIndexes := make(map[string]int, len(oldDumper.entries))
+	for i, e := range oldDumper.entries {
+		oldIndexes[e.Path.String()] = i
+	}
+	var changes []ConfigChange
+	matched := make(map[int]bool, len(oldDumper.entries))
+	for i, e := range newDumper.entries {
+		oldIndex, found := oldIndexes[e.Path.String()]
+		if !found {
+			changes = append(changes, makeConfigChange(nil, &e))
+			continue
+		}
+		matched[oldIndex] = true
+		old := oldDumper.entries[oldIndex]
+		if old.Unset != e.Unset || oldDumper.rawValues[oldIndex] != newDumper.rawValues[i] {
+			changes = append(changes, makeConfigChange(&old, &e))
+		}
+	}
+	for i, e := range oldDumper.entries {
+		if !matched[i] {
+			changes = append(changes, makeConfigChange(&e, nil))
+		}
+	}
+	return changes, nil
+}
+
+func makeConfigChange(oldEntry, newEntry *DumpEntry) ConfigChange {
+	field := newEntry
+	if field == nil {
+		field = oldEntry
+	}
+	unset := DumpEntry{Value: DumpUnsetValue, Unset: true}
+	if oldEntry == nil {
+		oldEntry = &unset
+	}
+	if newEntry == nil {
+		newEntry = &unset
+	}
+	return ConfigChange{
+		Path:     field.Path,
+		VarName:  field.VarName,
+		OldValue: oldEntry.Value,
+		NewValue: newEntry.Value,
+		OldUnset: oldEntry.Unset,
+		NewUnset: newEntry.Unset,
+		Secret:   oldEntry.Secret || newEntry.Secret,
+	}
+}
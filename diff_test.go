@@ -0,0 +1,104 @@
+package configtypes
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	t.Run("no changes", func(t *testing.T) {
+		s := makeTestStructForDump()
+		changes, err := Diff(s, &s, true)
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+	})
+
+	t.Run("changes", func(t *testing.T) {
+		oldValue := makeTestStructForDump()
+		newValue := makeTestStructForDump()
+		limit := 3
+		newValue.Port = OptIntGreaterThanZero{}
+		newValue.Limit = &limit
+		newValue.Server.Host = NewOptString("z")
+		newValue.Replicas = newValue.Replicas[:1]
+		newValue.Named = map[string]testStructForDumpServer{"x": newValue.Named["x"], "w": {}}
+
+		changes, err := Diff(&oldValue, &newValue, true)
+		require.NoError(t, err)
+		assert.Equal(t, []ConfigChange{
+			{
+				Path: NewValidationPath("Port"), VarName: "PORT",
+				OldValue: "8080", NewValue: DumpUnsetValue, NewUnset: true,
+			},
+			{
+				Path: NewValidationPath("Limit"), VarName: "LIMIT",
+				OldValue: DumpUnsetValue, NewValue: "3", OldUnset: true,
+			},
+			{Path: NewValidationPath("Server", "Host"), VarName: "HOST", OldValue: "a", NewValue: "z"},
+			{
+				Path: ValidationPath{PathField("Named"), PathKey("w"), PathField("Host")}, VarName: "HOST",
+				OldValue: DumpUnsetValue, NewValue: DumpUnsetValue, OldUnset: true, NewUnset: true,
+			},
+			{
+				Path: ValidationPath{PathField("Replicas"), PathIndex(1), PathField("Host")}, VarName: "HOST",
+				OldValue: "b", NewValue: DumpUnsetValue, NewUnset: true,
+			},
+			{
+				Path: ValidationPath{PathField("Named"), PathKey("y"), PathField("Host")}, VarName: "HOST",
+				OldValue: DumpUnsetValue, NewValue: DumpUnsetValue, OldUnset: true, NewUnset: true,
+			},
+		}, changes)
+		assert.Equal(t, "Port (PORT): 8080 -> <unset>", changes[0].String())
+		assert.Equal(t, "Server.Host (HOST): a -> z", changes[2].String())
+	})
+
+	t.Run("secrets are compared by value but redacted", func(t *testing.T) {
+		oldValue := testStructForDump{Key: NewOptSecret("first-secret-abcd"), Token: "short1"}
+		newValue := testStructForDump{Key: NewOptSecret("other-secret-abcd"), Token: "short2"}
+		changes, err := Diff(oldValue, newValue, false)
+		require.NoError(t, err)
+		assert.Equal(t, []ConfigChange{
+			{Path: NewValidationPath("Key"), VarName: "KEY", OldValue: "****abcd", NewValue: "****abcd", Secret: true},
+			{Path: NewValidationPath("Token"), VarName: "TOKEN", OldValue: "****", NewValue: "****", Secret: true},
+		}, changes)
+	})
+
+	t.Run("values with the same text are compared by their JSON form", func(t *testing.T) {
+		type config struct {
+			Hosts OptStringList `conf:"HOSTS"`
+		}
+		oldValue := config{Hosts: NewOptStringList([]string{"a,b"})}
+		newValue := config{Hosts: NewOptStringList([]string{"a", "b"})}
+		changes, err := Diff(oldValue, newValue, false)
+		require.NoError(t, err)
+		assert.Equal(t, []ConfigChange{
+			{Path: NewValidationPath("Hosts"), VarName: "HOSTS", OldValue: "a,b", NewValue: "a,b"},
+		}, changes)
+
+		changes, err = Diff(oldValue, config{Hosts: NewOptStringList([]string{"a,b"})}, false)
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+	})
+
+	t.Run("different types", func(t *testing.T) {
+		_, err := Diff(testStructForDump{}, testStructForDumpServer{}, true)
+		assert.EqualError(t, err, "Diff was called with different types "+
+			"configtypes.testStructForDump and configtypes.testStructForDumpServer")
+	})
+
+	t.Run("bad tag", func(t *testing.T) {
+		_, err := Diff(testStructWithBadTag{}, testStructWithBadTag{}, true)
+		assert.Equal(t, ValidationError{
+			Path: NewValidationPath("F1"),
+			Err:  errors.New(`unrecognized field tag option "whatever"`),
+		}, err)
+	})
+
+	t.Run("not a struct", func(t *testing.T) {
+		_, err := Diff(3, 3, true)
+		assert.Error(t, err)
+	})
+}
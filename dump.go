@@ -0,0 +1,294 @@
+package configtypes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"reflect"
+	"strings"
+)
+
+// DumpUnsetValue is the value that Dump reports for an empty Opt value, a nil pointer, or a nil
+// interface.
+const DumpUnsetValue = "<unset>"
+
+// DumpEntry describes the value of one field, as returned by Dump.
+type DumpEntry struct {
+	// Path is the path of the field within the struct, such as {"Server", "Port"}.
+	Path ValidationPath
+	// VarName is the variable name from the field's "conf:" tag, or "" if there is none.
+	VarName string
+	// Value is the field's value in text form, or DumpUnsetValue if it is not set. If the field is a
+	// secret, this is the redacted form of the value.
+	Value string
+	// Unset is true if the field is an empty Opt value, a nil pointer, or a nil interface.
+	Unset bool
+	// Secret is true if the field's type implements SecretValue or the field tag has the "secret"
+	// option.
+	Secret bool
+}
+
+// ConfigDump describes the effective values of all fields of a struct, as returned by Dump. It can
+// be written as a text table with WriteText, as JSON with json.Marshal, or as structured log
+// attributes with LogAttrs; it also implements slog.LogValuer.
+type ConfigDump []DumpEntry
+
+// Dump uses reflection to describe the current values of all exported fields of a struct, so that the
+// effective configuration can be logged. The target can be a struct or a struct pointer.
+//
+// Each value is rendered with its String method if it has one, or else in the default format of the
+// fmt package. An empty Opt value, a nil pointer, or a nil interface is reported as DumpUnsetValue.
+// Secrets are always redacted: that includes types that implement SecretValue, such as OptSecret,
+// and fields whose tag has the "secret" option.
+//
+//	dump, err := configtypes.Dump(&config, true)
+//	if err == nil {
+//	    logger.LogAttrs(ctx, slog.LevelInfo, "effective configuration", dump.LogAttrs()...)
+//	}
+//
+// If the recursive parameter is true, Dump describes the fields of embedded structs and non-nil
+// struct pointers, and of structs or non-nil struct pointers that are elements of slices, arrays, or
+// maps, in the same way as ValidateStruct; the paths of elements include a subscript, such as
+// "Upstreams[2].URL". If it is false, fields that refer to a nested struct, and slices, arrays, or
+// maps of structs, are skipped unless they have a variable name in their field tag; other pointer and
+// interface fields, such as a *int, are described like any other field.
+//
+// An error is returned if the target is not a struct or if any field tag is invalid.
+func Dump(target interface{}, recursive bool) (ConfigDump, error) {
+	refStruct, ok := getReflectValueForStruct(target)
+	if !ok {
+		return nil, errors.New( //nolint:staticcheck
+			"Dump was called on something other than a struct or struct pointer")
+	}
+	d := dumper{recursive: recursive}
+	if err := d.dumpFields(refStruct, nil); err != nil {
+		return nil, err
+	}
+	return d.entries, nil
+}
+
+type dumper struct {
+	recursive bool
+	visited   visitSet
+	entries   ConfigDump
+	// If keepRaw is true, rawValues has the unredacted value for each entry; see Diff.
+	keepRaw   bool
+	rawValues []dumpRawValue
+}
+
+// dumpRawValue is what Diff compares. Some different values have the same text, such as the
+// OptStringList values ["a,b"] and ["a", "b"], so if the type has a MarshalJSON method, its JSON
+// representation is compared as well, in the same way as VarWriter does.
+type dumpRawValue struct {
+	text string
+	json string
+}
+
+func (d *dumper) dumpFields(refStruct reflect.Value, path ValidationPath) error {
+	for _, field := range getStructPlan(refStruct.Type()).fields {
+		fieldPath := path.child(PathField(field.name))
+		if field.tagErr != nil {
+			return ValidationError{Path: fieldPath, Err: field.tagErr}
+		}
+		fieldInInstance := refStruct.Field(field.index)
+		if field.tagInfo.varName == "" && field.kind != fieldKindValue {
+			if !d.recursive {
+				// A pointer or interface that does not refer to a struct is described like the
+				// value it refers to, the same as an untagged field of that value's type.
+				if field.kind == fieldKindCollection || refersToStruct(fieldInInstance) {
+					continue
+				}
+				d.addEntry(fieldPath, "", fieldInInstance, field.tagInfo.secret)
+				continue
+			}
+			if field.kind == fieldKindCollection {
+				if err := d.dumpElements(fieldInInstance, fieldPath); err != nil {
+					return err
+				}
+				continue
+			}
+			isStruct, err := d.dumpNested(fieldInInstance, fieldPath)
+			if err != nil {
+				return err
+			}
+			if isStruct {
+				continue
+			}
+		}
+		d.addEntry(fieldPath, field.tagInfo.varName, fieldInInstance, field.tagInfo.secret)
+	}
+	return nil
+}
+
+// refersToStruct returns true if a value is a struct, a pointer whose element type is a struct or
+// struct pointer, or a non-nil interface holding a struct or struct pointer.
+func refersToStruct(refValue reflect.Value) bool {
+	switch refValue.Kind() {
+	case reflect.Struct:
+		return isStructType(refValue.Type())
+	case reflect.Ptr:
+		return isStructOrStructPtrType(refValue.Type().Elem())
+	case reflect.Interface:
+		return !refValue.IsNil() && isStructOrStructPtrType(refValue.Elem().Type())
+	}
+	return false
+}
+
+// dumpNested checks whether a value is a struct, or a non-nil pointer or interface referring to a
+// struct. If so, it describes the struct's fields and returns true. If a pointer refers to a struct
+// that is already being described further up in the tree, the struct is skipped.
+func (d *dumper) dumpNested(refValue reflect.Value, path ValidationPath) (bool, error) {
+	switch refValue.Kind() {
+	case reflect.Struct:
+		if !isStructType(refValue.Type()) {
+			return false, nil
+		}
+		return true, d.dumpFields(refValue, path)
+	case reflect.Interface:
+		if refValue.IsNil() {
+			return false, nil
+		}
+		return d.dumpNested(refValue.Elem(), path)
+	case reflect.Ptr:
+		if refValue.IsNil() {
+			return false, nil
+		}
+		if !d.visited.enter(refValue) {
+			return isStructOrStructPtrType(refValue.Type().Elem()), nil
+		}
+		defer d.visited.leave(refValue)
+		return d.dumpNested(refValue.Elem(), path)
+	}
+	return false, nil
+}
+
+func (d *dumper) dumpElements(refValue reflect.Value, path ValidationPath) error {
+	dumpElement := func(elem reflect.Value, elemPath ValidationPath) error {
+		if isStruct, err := d.dumpNested(elem, elemPath); isStruct || err != nil {
+			return err
+		}
+		d.addEntry(elemPath, "", elem, false)
+		return nil
+	}
+	switch refValue.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < refValue.Len(); i++ {
+			if err := dumpElement(refValue.Index(i), path.child(PathIndex(i))); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		keys, keySegments := sortedMapKeys(refValue)
+		for i, key := range keys {
+			if err := dumpElement(refValue.MapIndex(key), path.child(keySegments[i])); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *dumper) addEntry(path ValidationPath, varName string, value reflect.Value, secret bool) {
+	entry := DumpEntry{Path: path, VarName: varName, Secret: secret}
+	text, ok := dumpValue(value)
+	if d.keepRaw {
+		raw := dumpRawValue{text: text}
+		if ok {
+			switch v := dereferenceValue(value).Interface().(type) {
+			case SecretValue:
+				raw.text = v.Reveal()
+			case json.Marshaler:
+				if data, err := v.MarshalJSON(); err == nil {
+					raw.json = string(data)
+				}
+			}
+		}
+		d.rawValues = append(d.rawValues, raw)
+	}
+	switch {
+	case !ok:
+		entry.Value, entry.Unset = DumpUnsetValue, true
+	case secret && !isSecretValueType(value.Type()): // SecretValue is already redacted
+		entry.Value = redactSecret(text)
+	default:
+		entry.Value = text
+	}
+	d.entries = append(d.entries, entry)
+}
+
+// dumpValue returns the text form of a value, or false if it is not set.
+func dumpValue(value reflect.Value) (string, bool) {
+	value = dereferenceValue(value)
+	if !value.IsValid() {
+		return "", false
+	}
+	if sv, ok := value.Interface().(SingleValue); ok {
+		if !sv.IsDefined() {
+			return "", false
+		}
+		return sv.String(), true
+	}
+	return fmt.Sprint(value.Interface()), true
+}
+
+// dereferenceValue follows pointers and interfaces, returning an invalid Value if any of them is nil.
+func dereferenceValue(value reflect.Value) reflect.Value {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return reflect.Value{}
+		}
+		value = value.Elem()
+	}
+	return value
+}
+
+// WriteText writes the dump as a table with columns for the field path, the variable name, and the
+// value.
+func (d ConfigDump) WriteText(w io.Writer) error {
+	rows := [][]string{{"Field", "Variable", "Value"}}
+	for _, e := range d {
+		rows = append(rows, []string{e.Path.String(), e.VarName, e.Value})
+	}
+	_, err := io.WriteString(w, strings.Join(textTableRows(rows), "\n")+"\n")
+	return err
+}
+
+// MarshalJSON returns the dump as a JSON array, with an object for each field that has the properties
+// "path", "var" (omitted if there is no variable name), "value" (null if the field is unset), and
+// "secret" (omitted if false).
+func (d ConfigDump) MarshalJSON() ([]byte, error) {
+	type jsonEntry struct {
+		Path   string  `json:"path"`
+		Var    string  `json:"var,omitempty"`
+		Value  *string `json:"value"`
+		Secret bool    `json:"secret,omitempty"`
+	}
+	entries := make([]jsonEntry, 0, len(d))
+	for _, e := range d {
+		je := jsonEntry{Path: e.Path.String(), Var: e.VarName, Secret: e.Secret}
+		if !e.Unset {
+			value := e.Value
+			je.Value = &value
+		}
+		entries = append(entries, je)
+	}
+	return json.Marshal(entries)
+}
+
+// LogAttrs returns the dump as structured log attributes, with one string attribute for each field
+// whose key is the field path.
+func (d ConfigDump) LogAttrs() []slog.Attr {
+	attrs := make([]slog.Attr, 0, len(d))
+	for _, e := range d {
+		attrs = append(attrs, slog.String(e.Path.String(), e.Value))
+	}
+	return attrs
+}
+
+// LogValue implements slog.LogValuer, so that the dump is logged as a group of the attributes
+// returned by LogAttrs.
+func (d ConfigDump) LogValue() slog.Value {
+	return slog.GroupValue(d.LogAttrs()...)
+}
@@ -0,0 +1,168 @@
+package configtypes
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testStructForDump struct {
+	Port     OptIntGreaterThanZero `conf:"PORT"`
+	Timeout  OptDuration           `conf:"TIMEOUT"`
+	Key      OptSecret             `conf:"KEY"`
+	Token    string                `conf:"TOKEN,secret"`
+	Limit    *int                  `conf:"LIMIT"`
+	Untagged string
+	Retries  *int
+	Server   testStructForDumpServer
+	Backup   *testStructForDumpServer
+	Replicas []*testStructForDumpServer
+	Named    map[string]testStructForDumpServer
+}
+
+type testStructForDumpServer struct {
+	Host OptString `conf:"HOST"`
+}
+
+func makeTestStructForDump() testStructForDump {
+	retries := 3
+	return testStructForDump{
+		Port:     mustOptIntGreaterThanZero(8080),
+		Key:      NewOptSecret(testLongSecret),
+		Token:    testShortSecret,
+		Untagged: "u",
+		Retries:  &retries,
+		Server:   testStructForDumpServer{Host: NewOptString("a")},
+		Replicas: []*testStructForDumpServer{nil, {Host: NewOptString("b")}},
+		Named:    map[string]testStructForDumpServer{"y": {}, "x": {Host: NewOptString("c")}},
+	}
+}
+
+func TestDump(t *testing.T) {
+	t.Run("recursive", func(t *testing.T) {
+		s := makeTestStructForDump()
+		dump, err := Dump(&s, true)
+		require.NoError(t, err)
+		assert.Equal(t, ConfigDump{
+			{Path: NewValidationPath("Port"), VarName: "PORT", Value: "8080"},
+			{Path: NewValidationPath("Timeout"), VarName: "TIMEOUT", Value: DumpUnsetValue, Unset: true},
+			{Path: NewValidationPath("Key"), VarName: "KEY", Value: "****abcd", Secret: true},
+			{Path: NewValidationPath("Token"), VarName: "TOKEN", Value: "****", Secret: true},
+			{Path: NewValidationPath("Limit"), VarName: "LIMIT", Value: DumpUnsetValue, Unset: true},
+			{Path: NewValidationPath("Untagged"), Value: "u"},
+			{Path: NewValidationPath("Retries"), Value: "3"},
+			{Path: NewValidationPath("Server", "Host"), VarName: "HOST", Value: "a"},
+			{Path: NewValidationPath("Backup"), Value: DumpUnsetValue, Unset: true},
+			{Path: ValidationPath{PathField("Replicas"), PathIndex(0)}, Value: DumpUnsetValue, Unset: true},
+			{Path: ValidationPath{PathField("Replicas"), PathIndex(1), PathField("Host")}, VarName: "HOST", Value: "b"},
+			{Path: ValidationPath{PathField("Named"), PathKey("x"), PathField("Host")}, VarName: "HOST", Value: "c"},
+			{
+				Path:    ValidationPath{PathField("Named"), PathKey("y"), PathField("Host")},
+				VarName: "HOST", Value: DumpUnsetValue, Unset: true,
+			},
+		}, dump)
+	})
+
+	t.Run("non-recursive", func(t *testing.T) {
+		dump, err := Dump(makeTestStructForDump(), false)
+		require.NoError(t, err)
+		var paths []string
+		for _, e := range dump {
+			paths = append(paths, e.Path.String())
+		}
+		assert.Equal(t, []string{"Port", "Timeout", "Key", "Token", "Limit", "Untagged", "Retries"}, paths)
+	})
+
+	t.Run("self-reference", func(t *testing.T) {
+		s := testStructWithSelfReference{F1: "x"}
+		s.Next = &s
+		dump, err := Dump(&s, true)
+		require.NoError(t, err)
+		require.Len(t, dump, 2) // same as ValidateStruct, which visits s and s.Next once each
+		assert.Equal(t, NewValidationPath("Next", "F1"), dump[1].Path)
+	})
+
+	t.Run("bad tag", func(t *testing.T) {
+		_, err := Dump(testStructWithBadTag{}, true)
+		assert.Equal(t, ValidationError{
+			Path: NewValidationPath("F1"),
+			Err:  errors.New(`unrecognized field tag option "whatever"`),
+		}, err)
+	})
+
+	t.Run("not a struct", func(t *testing.T) {
+		_, err := Dump(3, true)
+		assert.Error(t, err)
+	})
+}
+
+func TestConfigDumpOutputFormats(t *testing.T) {
+	dump := ConfigDump{
+		{Path: NewValidationPath("Timeout"), VarName: "TIMEOUT", Value: "1m30s"},
+		{Path: NewValidationPath("Key"), VarName: "KEY", Value: "****abcd", Secret: true},
+		{Path: NewValidationPath("Server", "Host"), Value: DumpUnsetValue, Unset: true},
+	}
+
+	t.Run("text", func(t *testing.T) {
+		var b strings.Builder
+		require.NoError(t, dump.WriteText(&b))
+		assert.Equal(t, strings.Join([]string{
+			"Field        Variable  Value",
+			"Timeout      TIMEOUT   1m30s",
+			"Key          KEY       ****abcd",
+			"Server.Host            <unset>",
+			"",
+		}, "\n"), b.String())
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		data, err := json.Marshal(dump)
+		require.NoError(t, err)
+		assert.JSONEq(t, `[
+			{"path": "Timeout", "var": "TIMEOUT", "value": "1m30s"},
+			{"path": "Key", "var": "KEY", "value": "****abcd", "secret": true},
+			{"path": "Server.Host", "value": null}
+		]`, string(data))
+	})
+
+	t.Run("slog", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+			ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+				if a.Key == slog.TimeKey {
+					return slog.Attr{}
+				}
+				return a
+			},
+		}))
+		logger.Info("config", "config", dump)
+		assert.Equal(t,
+			`level=INFO msg=config config.Timeout=1m30s config.Key=****abcd config.Server.Host=<unset>`+"\n",
+			buf.String())
+	})
+
+	t.Run("secrets are redacted in every format", func(t *testing.T) {
+		s := testStructForDump{
+			Key: NewOptSecret(testLongSecret), Token: testLongSecret, Timeout: NewOptDuration(time.Second),
+		}
+		dump, err := Dump(&s, true)
+		require.NoError(t, err)
+		var text strings.Builder
+		require.NoError(t, dump.WriteText(&text))
+		data, err := json.Marshal(dump)
+		require.NoError(t, err)
+		var logged bytes.Buffer
+		slog.New(slog.NewJSONHandler(&logged, nil)).Info("config", "config", dump)
+		for _, out := range []string{text.String(), string(data), logged.String()} {
+			assert.NotContains(t, out, testLongSecret)
+			assert.Contains(t, out, "****abcd")
+		}
+	})
+}
@@ -2,6 +2,8 @@ package configtypes
 
 import (
 	"errors"
+	"fmt"
+	"reflect"
 )
 
 // Error is a type tag for all errors returned by this package.
@@ -17,14 +19,28 @@ func errDurationFormat() Error {
 	)
 }
 
+func errTimeFormat() Error {
+	return errors.New(
+		`not a valid date/time (must use RFC 3339 format like "2006-01-02T15:04:05Z", or "2006-01-02")`,
+	)
+}
+
 func errIntFormat() Error {
 	return errors.New("not a valid integer")
 }
 
+func errIntOutOfRange(minValue int64, maxValue uint64) Error {
+	return fmt.Errorf("integer is out of range (must be between %d and %d)", minValue, maxValue)
+}
+
 func errFloatFormat() Error {
 	return errors.New("not a valid number")
 }
 
+func errStringFormat() Error {
+	return errors.New("not a string")
+}
+
 func errMustBeGreaterThanZero() Error {
 	return errors.New("value must be greater than zero")
 }
@@ -41,6 +57,112 @@ func errRequired() Error {
 	return errors.New("value is required")
 }
 
+// ErrRequired returns the error that ValidateStruct and VarReader report for a required value that
+// was not set. It is exported for the use of generated code; see cmd/confgen.
+func ErrRequired() Error {
+	return errRequired()
+}
+
+func errUnknownVar() Error {
+	return errors.New("not a recognized variable name")
+}
+
+func errUnknownKey() Error {
+	return errors.New("does not correspond to any field")
+}
+
+func errLoadNonStruct() Error {
+	return errors.New("configuration file was loaded into something other than a struct pointer")
+}
+
+func errJSONTopLevelNotObject() Error {
+	return errors.New("JSON configuration must be an object")
+}
+
+func errJSONTrailingData() Error {
+	return errors.New("unexpected data after the end of the JSON value")
+}
+
+func errJSONUnexpectedEnd() Error {
+	return errors.New("unexpected end of JSON input")
+}
+
+func errYAMLTopLevelNotMapping() Error {
+	return errors.New("YAML configuration must be a mapping")
+}
+
+func errINIInvalidLine() Error {
+	return errors.New(`expected a section header such as [section] or a variable such as "name = value"`)
+}
+
+func errINIInvalidSectionHeader() Error {
+	return errors.New(`not a valid section header (must be [section] or [section "subsection"])`)
+}
+
+func errINIInvalidEscape(ch byte) Error {
+	return fmt.Errorf(`invalid escape sequence "\%c"`, ch)
+}
+
+func errINIUnterminatedQuote() Error {
+	return errors.New("quoted string was not terminated")
+}
+
+func errINIMissingValue() Error {
+	return errors.New(`a value is required (use "name = value")`)
+}
+
+func errININotASection() Error {
+	return errors.New("corresponds to a field that is not a struct, so it cannot be a section")
+}
+
+func errINISubsectionRequired() Error {
+	return errors.New(`requires a subsection name, as in [section "name"]`)
+}
+
+func errINISubsectionNotAllowed() Error {
+	return errors.New("cannot have subsections")
+}
+
+func errTOMLExpected(what string) Error {
+	return fmt.Errorf("expected %s", what)
+}
+
+func errTOMLInvalidValue(text string) Error {
+	return fmt.Errorf("%q is not a valid TOML value", text)
+}
+
+func errTOMLInvalidEscape(sequence string) Error {
+	return fmt.Errorf("invalid escape sequence %q", sequence)
+}
+
+func errTOMLUnterminatedString() Error {
+	return errors.New("string was not terminated")
+}
+
+func errTOMLInvalidCharacter(c rune) Error {
+	return fmt.Errorf("character %U is not allowed in TOML", c)
+}
+
+func errTOMLInvalidUTF8() Error {
+	return errors.New("TOML must be valid UTF-8")
+}
+
+func errTOMLAlreadyDefined(key string) Error {
+	return fmt.Errorf("%q is already defined", key)
+}
+
+func errTOMLWrongType(tomlType string, t reflect.Type) Error {
+	return fmt.Errorf("TOML %s is not valid for a value of type %s", tomlType, t)
+}
+
+func errTOMLOutOfRange(value int64, t reflect.Type) Error {
+	return fmt.Errorf("TOML integer %d is out of range for a value of type %s", value, t)
+}
+
+func errJSONWrongType(jsonValue string, t reflect.Type) Error {
+	return fmt.Errorf("JSON %s is not valid for a value of type %s", jsonValue, t)
+}
+
 func errStringListJSONFormat() Error {
 	return errors.New("string list value must be a string, an array of strings, or null")
 }
@@ -57,6 +179,38 @@ func errURLNotAbsolute() Error {
 	return errors.New("must be an absolute URL/URI")
 }
 
+func errVarWriterCannotRepresent() Error {
+	return errors.New("value cannot be written as a variable in a form that would be read back as the same value")
+}
+
+func errVarWriterConflict() Error {
+	return errors.New("a different value was already written for the same variable")
+}
+
+func errVarWriterSecretNotRevealed() Error {
+	return errors.New("secret value was not written because VarWriter.WithSecretsRevealed was not used")
+}
+
 func errValidateNonStruct() Error {
 	return errors.New("Validate was called with a parameter that was not a struct pointer") //nolint:staticcheck
 }
+
+func errSQLScanType(src, target interface{}) Error {
+	return fmt.Errorf("cannot read a database value of type %T into %T", src, target)
+}
+
+func errBinaryFormat() Error {
+	return errors.New("not a valid binary encoding")
+}
+
+func errOutOfRange(rangeDescription string) Error {
+	return fmt.Errorf("value must be %s", rangeDescription)
+}
+
+func errLDFlagValueNotRepresentable() Error {
+	return errors.New("flag value cannot be represented as text that would be parsed as the same value")
+}
+
+func errSecretNotValid(redactedValue string) Error {
+	return fmt.Errorf("not a valid value (%s); details are not shown because the value is secret", redactedValue)
+}
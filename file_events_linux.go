@@ -0,0 +1,67 @@
+//go:build linux
+
+package configtypes
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// On Linux, we watch the directory that contains each file, rather than the file itself, so that we
+// will also see a file being replaced with a rename or a symlink change. That is how Kubernetes
+// updates the files for a mounted config map or secret.
+const inotifyEventMask = syscall.IN_CLOSE_WRITE | syscall.IN_CREATE | syscall.IN_DELETE |
+	syscall.IN_MOVED_FROM | syscall.IN_MOVED_TO | syscall.IN_ATTRIB
+
+type inotifyNotifier struct {
+	file *os.File
+	ch   chan struct{}
+}
+
+func newFileEventNotifier(paths []string) (fileEventNotifier, error) {
+	if len(paths) == 0 {
+		return nil, nil //nolint:nilnil
+	}
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range paths {
+		dir := path
+		if info, err := os.Stat(path); err != nil || !info.IsDir() {
+			dir = filepath.Dir(path)
+		}
+		if _, err := syscall.InotifyAddWatch(fd, dir, inotifyEventMask); err != nil {
+			_ = syscall.Close(fd)
+			return nil, err
+		}
+	}
+	// Since the descriptor is non-blocking, os.File uses the runtime poller for it, so closing the
+	// file will interrupt a pending Read.
+	n := &inotifyNotifier{file: os.NewFile(uintptr(fd), "inotify"), ch: make(chan struct{}, 1)}
+	go n.readEvents()
+	return n, nil
+}
+
+func (n *inotifyNotifier) readEvents() {
+	buf := make([]byte, 4096)
+	for {
+		if _, err := n.file.Read(buf); err != nil {
+			return
+		}
+		// We don't need the details of the events, since any change causes all sources to be re-read.
+		select {
+		case n.ch <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (n *inotifyNotifier) events() <-chan struct{} {
+	return n.ch
+}
+
+func (n *inotifyNotifier) close() {
+	_ = n.file.Close()
+}
@@ -0,0 +1,8 @@
+//go:build !linux
+
+package configtypes
+
+// On platforms other than Linux, Watcher only re-reads its sources at a regular interval.
+func newFileEventNotifier(paths []string) (fileEventNotifier, error) {
+	return nil, nil //nolint:nilnil
+}
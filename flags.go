@@ -0,0 +1,189 @@
+package configtypes
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// BindFlags registers a command-line flag on a flag.FlagSet for every field of a struct that
+// VarReader.ReadStruct would read, so that the same struct can be populated from flags as well as from
+// variables, using the same parsing rules. The target must be a struct pointer; the flags set the
+// struct's fields when fs.Parse is called.
+//
+// Fields are found with the same field tag logic as ReadStruct; if recursive is true, nested struct
+// fields and non-nil struct pointer fields are included. The flag name is derived from the variable
+// name by changing it to lowercase and replacing underscores with hyphens, so a field with the tag
+// `conf:"HTTP_READ_TIMEOUT"` becomes the flag "-http-read-timeout" (which the flag package also
+// accepts as "--http-read-timeout"). The usage string is the text of the field tag's "desc=" option,
+// or a description of the format of the value if there is none, followed by the variable name. The
+// default value shown by fs.PrintDefaults is the field's current value, redacted if it is a secret.
+//
+// Since the struct's current values are treated as defaults, you can read variables into the struct
+// first and then parse flags, so that flags take precedence:
+//
+//	var config Config
+//	r := configtypes.NewVarReaderFromEnvironment()
+//	r.ReadStruct(&config, true)
+//	if err := configtypes.BindFlags(flag.CommandLine, &config, true); err != nil { ... }
+//	flag.Parse()
+//
+// If a flag for an OptStringList field is repeated, the values are added to the list, but the first
+// occurrence of the flag replaces the default value rather than adding to it. Fields of type OptBool or
+// bool can be given without a value to mean true, as in "-debug".
+//
+// An error is returned, and no flags are registered, if the target is not a struct pointer, if any
+// field tag is invalid, if a field has a type that cannot be parsed from text, or if a flag with the
+// same name already exists.
+func BindFlags(fs *flag.FlagSet, target interface{}, recursive bool) error {
+	refStruct, ok := getReflectValueForStructPtr(target)
+	if !ok {
+		return errors.New("BindFlags was called on something other than a struct pointer")
+	}
+	b := flagBinder{recursive: recursive, names: make(map[string]bool)}
+	if err := b.bindFields(fs, refStruct, nil); err != nil {
+		return err
+	}
+	for _, f := range b.flags {
+		fs.Var(f, f.name, f.usage)
+	}
+	return nil
+}
+
+type flagBinder struct {
+	recursive bool
+	visited   visitSet
+	names     map[string]bool
+	flags     []*structFieldFlag
+}
+
+func (b *flagBinder) bindFields(fs *flag.FlagSet, refStruct reflect.Value, path ValidationPath) error {
+	for _, field := range getStructPlan(refStruct.Type()).fields {
+		fieldPath := path.child(PathField(field.name))
+		if field.tagErr != nil {
+			return ValidationError{Path: fieldPath, Err: field.tagErr}
+		}
+		fieldInInstance := refStruct.Field(field.index)
+		if field.tagInfo.varName == "" {
+			if b.recursive && field.kind == fieldKindStruct {
+				err := walkNestedStruct(fieldInInstance, &b.visited, func(refStruct reflect.Value) error {
+					return b.bindFields(fs, refStruct, fieldPath)
+				})
+				if err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		f := &structFieldFlag{
+			name:   flagNameForVar(field.tagInfo.varName),
+			field:  fieldInInstance,
+			secret: field.tagInfo.secret,
+		}
+		if !f.canSet() {
+			err := varReaderBadTargetTypeError(fieldInInstance.Addr().Interface())
+			return ValidationError{Path: fieldPath, Err: err}
+		}
+		if b.names[f.name] || fs.Lookup(f.name) != nil {
+			return ValidationError{Path: fieldPath, Err: fmt.Errorf("flag %q is already defined", f.name)}
+		}
+		b.names[f.name] = true
+		f.usage = field.tagInfo.description
+		if f.usage == "" {
+			f.usage = describeVarFormat(fieldInInstance.Type())
+		}
+		f.usage = strings.TrimSpace(f.usage + " (" + field.tagInfo.varName + ")")
+		b.flags = append(b.flags, f)
+	}
+	return nil
+}
+
+func flagNameForVar(varName string) string {
+	return strings.ReplaceAll(strings.ToLower(varName), "_", "-")
+}
+
+// varNameForFlag is the reverse of flagNameForVar, for CommandLineVarSource.
+func varNameForFlag(flagName string) string {
+	return strings.ReplaceAll(strings.ToUpper(flagName), "-", "_")
+}
+
+// structFieldFlag is the flag.Getter that BindFlags registers for a struct field.
+type structFieldFlag struct {
+	name   string
+	usage  string
+	field  reflect.Value
+	secret bool
+	isSet  bool
+}
+
+func (f *structFieldFlag) canSet() bool {
+	return setterForTarget(reflect.New(f.valueType()).Interface()) != nil
+}
+
+// valueType is the type of the field, or the type it points to if it is a pointer.
+func (f *structFieldFlag) valueType() reflect.Type {
+	if t := f.field.Type(); t.Kind() == reflect.Ptr {
+		return t.Elem()
+	}
+	return f.field.Type()
+}
+
+// Set parses a value into the field. The first time it is called, the field is reset to its zero value
+// first, so that an OptStringList flag replaces the default value rather than adding to it. The field is
+// not changed if the value is invalid.
+func (f *structFieldFlag) Set(s string) error {
+	var target reflect.Value
+	switch {
+	case !f.isSet:
+		target = reflect.New(f.valueType())
+	case f.field.Kind() == reflect.Ptr:
+		target = f.field
+	default:
+		target = f.field.Addr()
+	}
+	if err := setterForTarget(target.Interface())([]byte(s)); err != nil {
+		if f.secret {
+			return redactSecretInError(err, s)
+		}
+		return err
+	}
+	if f.field.Kind() == reflect.Ptr {
+		f.field.Set(target)
+	} else {
+		f.field.Set(target.Elem())
+	}
+	f.isSet = true
+	return nil
+}
+
+// String returns the current value in the same form as VarDoc.Default. The flag package may call this
+// on a zero structFieldFlag.
+func (f *structFieldFlag) String() string {
+	if f == nil || !f.field.IsValid() {
+		return ""
+	}
+	value := describeVarDefault(f.field)
+	if f.secret && !isSecretValueType(f.field.Type()) { // SecretValue is already redacted
+		value = redactSecret(value)
+	}
+	return value
+}
+
+// Get returns the current value of the field.
+func (f *structFieldFlag) Get() interface{} {
+	return f.field.Interface()
+}
+
+// IsBoolFlag tells the flag package that a boolean flag can be given without a value.
+func (f *structFieldFlag) IsBoolFlag() bool {
+	if f == nil || !f.field.IsValid() {
+		return false
+	}
+	switch reflect.Zero(f.valueType()).Interface().(type) {
+	case OptBool, bool:
+		return true
+	}
+	return false
+}
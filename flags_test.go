@@ -0,0 +1,206 @@
+package configtypes
+
+import (
+	"flag"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testStructForFlags struct {
+	ReadTimeout OptDuration   `conf:"HTTP_READ_TIMEOUT,desc=timeout for reading requests"`
+	Port        int           `conf:"PORT"`
+	Debug       OptBool       `conf:"DEBUG"`
+	Verbose     bool          `conf:"VERBOSE"`
+	Hosts       OptStringList `conf:"HOSTS"`
+	Key         OptSecret     `conf:"KEY"`
+	Token       string        `conf:"TOKEN,secret"`
+	Limit       *int          `conf:"LIMIT"`
+	Untagged    string
+	Server      testStructForDumpServer
+}
+
+func newTestFlagSet() *flag.FlagSet {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	return fs
+}
+
+func TestOptTypesAsFlags(t *testing.T) {
+	t.Run("zero values", func(t *testing.T) {
+		for _, g := range []flag.Getter{
+			&OptBool{}, &OptDuration{}, &OptDurationNonNegative{}, &OptFloat64{}, &OptInt{},
+			&OptIntGreaterThanZero{}, &OptSecret{}, &OptString{}, &OptStringList{}, &OptStringNonEmpty{},
+			&OptTime{}, &ReqSecret{}, &OptIntBetween[testIntBounds]{}, &OptInt64Between[testInt64Bounds]{},
+			&OptFloat64Between[testFloat64Bounds]{}, &OptDurationBetween[testDurationBounds]{}, &OptInt64{},
+			&OptInt64GreaterThanZero{}, &OptUint{}, &OptUintGreaterThanZero{}, &OptUint64{},
+			&OptUint64GreaterThanZero{},
+		} {
+			assert.Equal(t, "", g.String())
+		}
+		// These are not flag.Getters, because their Get methods return a specific type
+		for _, v := range []flag.Value{&OptBase2Bytes{}, &OptURL{}, &OptURLAbsolute{}} {
+			assert.Equal(t, "", v.String())
+		}
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		for _, p := range []struct {
+			value    interface{ Get() interface{} }
+			expected interface{}
+		}{
+			{OptBool{}, nil},
+			{NewOptBool(false), false},
+			{NewOptDuration(time.Second), time.Second},
+			{mustOptDurationNonNegative(time.Second), time.Second},
+			{NewOptFloat64(1.5), 1.5},
+			{NewOptInt(0), 0},
+			{mustOptIntGreaterThanZero(2), 2},
+			{NewOptString(""), ""},
+			{NewOptStringNonEmpty("x"), "x"},
+			{NewOptStringList([]string{"a", "b"}), []string{"a", "b"}},
+			{NewOptTime(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)), time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)},
+			{NewOptSecret(testLongSecret), NewOptSecret(testLongSecret)},
+			{mustReqSecret(testLongSecret), mustReqSecret(testLongSecret)},
+			{mustOptIntBetween(3), 3},
+			{mustOptInt64Between(3), int64(3)},
+			{mustOptFloat64Between(0.5), 0.5},
+			{mustOptDurationBetween(time.Second), time.Second},
+			{NewOptInt64(-3), int64(-3)},
+			{mustOptInt64GreaterThanZero(3), int64(3)},
+			{NewOptUint(3), uint(3)},
+			{mustOptUintGreaterThanZero(3), uint(3)},
+			{NewOptUint64(3), uint64(3)},
+			{mustOptUint64GreaterThanZero(3), uint64(3)},
+		} {
+			assert.Equal(t, p.expected, p.value.Get())
+		}
+	})
+
+	t.Run("parse", func(t *testing.T) {
+		var (
+			debug OptBool
+			hosts = NewOptStringList([]string{"default"})
+			size  OptBase2Bytes
+			key   ReqSecret
+		)
+		fs := newTestFlagSet()
+		fs.Var(&debug, "debug", "")
+		fs.Var(&hosts, "host", "")
+		fs.Var(&size, "size", "")
+		fs.Var(&key, "key", "")
+		require.NoError(t, fs.Parse([]string{"-debug", "--host", "a", "-host=b,c", "-size", "1KB", "-key", "k"}))
+		assert.Equal(t, NewOptBool(true), debug)
+		assert.Equal(t, []string{"default", "a", "b", "c"}, hosts.Values())
+		assert.Equal(t, "1KiB", size.String())
+		assert.Equal(t, "k", key.Reveal())
+
+		assert.Error(t, newTestFlagSetWith(&debug, "debug").Parse([]string{"-debug=x"}))
+	})
+}
+
+func newTestFlagSetWith(value flag.Value, name string) *flag.FlagSet {
+	fs := newTestFlagSet()
+	fs.Var(value, name, "")
+	return fs
+}
+
+func TestBindFlags(t *testing.T) {
+	t.Run("parse", func(t *testing.T) {
+		s := testStructForFlags{Port: 80, Hosts: NewOptStringList([]string{"default"})}
+		fs := newTestFlagSet()
+		require.NoError(t, BindFlags(fs, &s, true))
+		require.NoError(t, fs.Parse([]string{
+			"--http-read-timeout", "5s", "-port=8080", "-debug", "-verbose",
+			"-hosts", "a", "-hosts", "b,c", "-key", testLongSecret, "-token", testShortSecret,
+			"-limit", "3", "-host", "h",
+		}))
+		limit := 3
+		assert.Equal(t, testStructForFlags{
+			ReadTimeout: NewOptDuration(5 * time.Second),
+			Port:        8080,
+			Debug:       NewOptBool(true),
+			Verbose:     true,
+			Hosts:       NewOptStringList([]string{"a", "b", "c"}),
+			Key:         NewOptSecret(testLongSecret),
+			Token:       testShortSecret,
+			Limit:       &limit,
+			Server:      testStructForDumpServer{Host: NewOptString("h")},
+		}, s)
+		assert.Equal(t, NewOptDuration(5*time.Second), fs.Lookup("http-read-timeout").Value.(flag.Getter).Get())
+	})
+
+	t.Run("types that are not flag.Getters", func(t *testing.T) {
+		var s struct {
+			Size     OptBase2Bytes  `conf:"SIZE"`
+			URL      OptURL         `conf:"URL"`
+			Absolute OptURLAbsolute `conf:"ABSOLUTE"`
+		}
+		fs := newTestFlagSet()
+		require.NoError(t, BindFlags(fs, &s, false))
+		require.NoError(t, fs.Parse([]string{"-size", "1KB", "-url", "/a", "-absolute", "http://b"}))
+		assert.Equal(t, s.Size, fs.Lookup("size").Value.(flag.Getter).Get())
+		assert.Equal(t, s.URL, fs.Lookup("url").Value.(flag.Getter).Get())
+		assert.Equal(t, s.Absolute, fs.Lookup("absolute").Value.(flag.Getter).Get())
+		assert.Equal(t, "1KiB", s.Size.String())
+	})
+
+	t.Run("unset flags keep their defaults", func(t *testing.T) {
+		s := testStructForFlags{Port: 80, Hosts: NewOptStringList([]string{"default"})}
+		fs := newTestFlagSet()
+		require.NoError(t, BindFlags(fs, &s, false))
+		require.NoError(t, fs.Parse(nil))
+		assert.Equal(t, testStructForFlags{Port: 80, Hosts: NewOptStringList([]string{"default"})}, s)
+		assert.Nil(t, fs.Lookup("host"))
+	})
+
+	t.Run("usage", func(t *testing.T) {
+		s := testStructForFlags{Key: NewOptSecret(testLongSecret), Token: testShortSecret}
+		fs := newTestFlagSet()
+		require.NoError(t, BindFlags(fs, &s, false))
+		assert.Equal(t, "timeout for reading requests (HTTP_READ_TIMEOUT)", fs.Lookup("http-read-timeout").Usage)
+		assert.Equal(t, "integer (PORT)", fs.Lookup("port").Usage)
+		assert.Equal(t, "****abcd", fs.Lookup("key").Value.String())
+		assert.Equal(t, "****", fs.Lookup("token").Value.String())
+
+		var b strings.Builder
+		fs.SetOutput(&b)
+		fs.PrintDefaults()
+		assert.Contains(t, b.String(), "-debug\n")
+		assert.Contains(t, b.String(), "(default ****abcd)")
+		assert.NotContains(t, b.String(), testLongSecret)
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		s := testStructForFlags{Port: 80}
+		fs := newTestFlagSet()
+		require.NoError(t, BindFlags(fs, &s, false))
+		assert.Error(t, fs.Parse([]string{"-port", "x"}))
+		assert.Equal(t, 80, s.Port)
+		assert.Error(t, fs.Parse([]string{"-limit", "x"}))
+		assert.Nil(t, s.Limit)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		var s testStructForFlags
+		assert.Error(t, BindFlags(newTestFlagSet(), s, false))
+
+		fs := newTestFlagSet()
+		fs.String("port", "", "")
+		assert.EqualError(t, BindFlags(fs, &s, false), `Port: flag "port" is already defined`)
+		assert.Nil(t, fs.Lookup("debug")) // no flags were registered
+
+		err := BindFlags(newTestFlagSet(), &testStructWithBadTag{}, false)
+		assert.EqualError(t, err, `F1: unrecognized field tag option "whatever"`)
+
+		var unsupported struct {
+			F []int `conf:"F"`
+		}
+		assert.EqualError(t, BindFlags(newTestFlagSet(), &unsupported, false),
+			"F: could not read into value of type *[]int")
+	})
+}
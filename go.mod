@@ -1,4 +1,4 @@
-module github.com/launchdarkly/go-configtypes
+module github.com/launchdarkly/go-configtypes/v2
 
 go 1.25
 
@@ -6,6 +6,7 @@ require (
 	github.com/alecthomas/units v0.0.0-20231202071711-9a357b53e9c9
 	github.com/launchdarkly/go-sdk-common/v3 v3.1.0
 	github.com/stretchr/testify v1.7.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -16,5 +17,4 @@ require (
 	github.com/mailru/easyjson v0.7.6 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	golang.org/x/exp v0.0.0-20220823124025-807a23277127 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
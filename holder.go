@@ -0,0 +1,138 @@
+package configtypes
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// Holder stores the current value of a configuration struct so that it can be safely read and updated
+// from any number of goroutines. Every value that it stores must pass ValidateStruct.
+//
+// Load is lock-free, so it is cheap enough to call every time a configuration value is needed. Each
+// call returns a snapshot: a copy of the struct that will not be affected by later updates. However,
+// since the struct is copied with an ordinary assignment, any pointers, slices, or maps in it are
+// shared by every snapshot, and must not be modified.
+//
+//	holder, err := configtypes.NewHolder(defaultConfig, true)
+//	if err != nil { ... }
+//	result := holder.Reload(configtypes.NewVarReaderFromEnvironment(), defaultConfig)
+//	...
+//	limit := holder.Load().RateLimit.GetOrElse(100)
+type Holder[T any] struct {
+	current     atomic.Pointer[T]
+	recursive   bool
+	updateLock  sync.Mutex
+	subsLock    sync.Mutex
+	subscribers []chan T
+}
+
+// NewHolder creates a Holder with an initial value. The value must be a struct that passes
+// ValidateStruct; the recursive parameter is passed to ValidateStruct for this and all later updates,
+// and to VarReader.ReadStruct for Reload. If the initial value is not valid, NewHolder returns the
+// error from the ValidationResult.
+func NewHolder[T any](initial T, recursive bool) (*Holder[T], error) {
+	h := &Holder[T]{recursive: recursive}
+	if result := ValidateStruct(&initial, recursive); !result.OK() {
+		return nil, result.GetError()
+	}
+	h.current.Store(&initial)
+	return h, nil
+}
+
+// Load returns a snapshot of the current value.
+func (h *Holder[T]) Load() T {
+	return *h.current.Load()
+}
+
+// Store replaces the current value, if the new value passes ValidateStruct. The returned
+// ValidationResult is not OK if the value was rejected, in which case the current value does not
+// change.
+func (h *Holder[T]) Store(value T) ValidationResult {
+	return h.Update(func(T) T { return value })
+}
+
+// Update computes a new value from a snapshot of the current one, and replaces the current value if
+// the new value passes ValidateStruct. The returned ValidationResult is not OK if the value was
+// rejected, in which case the current value does not change.
+//
+// Updates are serialized, so that fn always sees the result of the previous update. The fn parameter
+// must not call Update, Store, or Reload on the same Holder.
+func (h *Holder[T]) Update(fn func(T) T) ValidationResult {
+	h.updateLock.Lock()
+	defer h.updateLock.Unlock()
+	return h.storeIfValid(fn(h.Load()), ValidationResult{})
+}
+
+// Reload reads a new value from a VarReader and replaces the current value if there were no errors.
+// The new value starts as a copy of the base value, and then VarReader.ReadStruct is called on it;
+// if that succeeds, it is checked with ValidateStruct. The copy has its own storage for everything
+// that the base value's exported pointer, slice, map, and interface fields refer to, so Reload
+// never modifies the base value or a snapshot that has already been returned by Load. The returned
+// ValidationResult contains the errors from both steps, and is not OK if the value was rejected, in
+// which case the current value does not change.
+//
+// To start over from default values, so that variables that have been removed no longer have an
+// effect, pass the defaults as the base. To apply variables on top of the current value, pass the
+// result of Load.
+func (h *Holder[T]) Reload(r *VarReader, base T) ValidationResult {
+	h.updateLock.Lock()
+	defer h.updateLock.Unlock()
+	value := deepCopy(base)
+	r.ReadStruct(&value, h.recursive)
+	return h.storeIfValid(value, r.Result())
+}
+
+func (h *Holder[T]) storeIfValid(value T, result ValidationResult) ValidationResult {
+	if result.OK() {
+		result = ValidateStruct(&value, h.recursive)
+	}
+	if !result.OK() {
+		return result
+	}
+	old := h.current.Swap(&value)
+	if !reflect.DeepEqual(*old, value) {
+		h.notify(value)
+	}
+	return result
+}
+
+// Subscribe returns a channel that receives the new value whenever the value changes. The channel
+// has a buffer of one value: if a new value is stored before the subscriber has received the
+// previous one, the previous one is discarded, so a slow subscriber always sees the latest value
+// and never blocks an update.
+//
+// The returned function removes the subscription and closes the channel.
+func (h *Holder[T]) Subscribe() (<-chan T, func()) {
+	ch := make(chan T, 1)
+	h.subsLock.Lock()
+	h.subscribers = append(h.subscribers, ch)
+	h.subsLock.Unlock()
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			h.subsLock.Lock()
+			defer h.subsLock.Unlock()
+			for i, s := range h.subscribers {
+				if s == ch {
+					h.subscribers = append(h.subscribers[:i:i], h.subscribers[i+1:]...)
+					break
+				}
+			}
+			close(ch)
+		})
+	}
+}
+
+func (h *Holder[T]) notify(value T) {
+	h.subsLock.Lock()
+	defer h.subsLock.Unlock()
+	for _, ch := range h.subscribers {
+		// Since we're the only sender, after discarding an unreceived value there is room for the new one.
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- value
+	}
+}
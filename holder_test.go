@@ -0,0 +1,194 @@
+package configtypes
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testStructForHolder struct {
+	Limit OptIntGreaterThanZero `conf:"LIMIT"`
+	Level OptString             `conf:",required"`
+	Count int                   `conf:"COUNT"`
+}
+
+func TestHolder(t *testing.T) {
+	initial := testStructForHolder{Level: NewOptString("info")}
+
+	t.Run("initial value", func(t *testing.T) {
+		h, err := NewHolder(initial, false)
+		require.NoError(t, err)
+		assert.Equal(t, initial, h.Load())
+	})
+
+	t.Run("invalid initial value", func(t *testing.T) {
+		_, err := NewHolder(testStructForHolder{}, false)
+		assert.Equal(t, ValidationError{Path: NewValidationPath("Level"), Err: errRequired()}, err)
+	})
+
+	t.Run("Store", func(t *testing.T) {
+		h, err := NewHolder(initial, false)
+		require.NoError(t, err)
+		updated := testStructForHolder{Level: NewOptString("debug")}
+		assert.True(t, h.Store(updated).OK())
+		assert.Equal(t, updated, h.Load())
+
+		result := h.Store(testStructForHolder{})
+		assert.False(t, result.OK())
+		assert.Equal(t, updated, h.Load())
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		h, err := NewHolder(initial, false)
+		require.NoError(t, err)
+		assert.True(t, h.Update(func(c testStructForHolder) testStructForHolder {
+			c.Count++
+			return c
+		}).OK())
+		assert.Equal(t, 1, h.Load().Count)
+
+		assert.False(t, h.Update(func(c testStructForHolder) testStructForHolder {
+			c.Count++
+			c.Level = OptString{}
+			return c
+		}).OK())
+		assert.Equal(t, 1, h.Load().Count)
+	})
+
+	t.Run("snapshots are not affected by updates", func(t *testing.T) {
+		h, err := NewHolder(initial, false)
+		require.NoError(t, err)
+		snapshot := h.Load()
+		h.Store(testStructForHolder{Level: NewOptString("debug")})
+		assert.Equal(t, initial, snapshot)
+	})
+
+	t.Run("Reload", func(t *testing.T) {
+		defaults := testStructForHolder{Limit: mustOptIntGreaterThanZero(10), Level: NewOptString("info")}
+		h, err := NewHolder(defaults, false)
+		require.NoError(t, err)
+
+		result := h.Reload(NewVarReaderFromValues(map[string]string{"LIMIT": "5", "COUNT": "2"}), defaults)
+		assert.True(t, result.OK())
+		assert.Equal(t, testStructForHolder{
+			Limit: mustOptIntGreaterThanZero(5), Level: defaults.Level, Count: 2,
+		}, h.Load())
+
+		result = h.Reload(NewVarReaderFromValues(map[string]string{"COUNT": "3"}), h.Load())
+		assert.True(t, result.OK())
+		assert.Equal(t, testStructForHolder{
+			Limit: mustOptIntGreaterThanZero(5), Level: defaults.Level, Count: 3,
+		}, h.Load())
+
+		result = h.Reload(NewVarReaderFromValues(map[string]string{"LIMIT": "0", "COUNT": "4"}), defaults)
+		assert.Equal(t, []ValidationError{
+			{Path: NewValidationPath("LIMIT"), Err: errMustBeGreaterThanZero()},
+		}, result.Errors())
+		assert.Equal(t, 3, h.Load().Count)
+
+		result = h.Reload(NewVarReaderFromValues(map[string]string{"COUNT": "4"}), testStructForHolder{})
+		assert.Equal(t, []ValidationError{{Path: NewValidationPath("Level"), Err: errRequired()}}, result.Errors())
+		assert.Equal(t, 3, h.Load().Count)
+	})
+
+	t.Run("Reload does not modify the base value or snapshots", func(t *testing.T) {
+		type config struct {
+			Server *testStructForHolder
+		}
+		defaults := config{Server: &testStructForHolder{Level: NewOptString("info")}}
+		h, err := NewHolder(defaults, true)
+		require.NoError(t, err)
+		snapshot := h.Load()
+
+		result := h.Reload(NewVarReaderFromValues(map[string]string{"LIMIT": "0", "COUNT": "4"}), defaults)
+		assert.False(t, result.OK())
+		assert.Equal(t, 0, defaults.Server.Count)
+		assert.Equal(t, 0, snapshot.Server.Count)
+		assert.Equal(t, 0, h.Load().Server.Count)
+
+		result = h.Reload(NewVarReaderFromValues(map[string]string{"COUNT": "5"}), defaults)
+		assert.True(t, result.OK())
+		assert.Equal(t, 5, h.Load().Server.Count)
+		assert.Equal(t, 0, defaults.Server.Count)
+		assert.Equal(t, 0, snapshot.Server.Count)
+	})
+
+	t.Run("subscriptions", func(t *testing.T) {
+		h, err := NewHolder(initial, false)
+		require.NoError(t, err)
+		ch1, unsubscribe1 := h.Subscribe()
+		ch2, unsubscribe2 := h.Subscribe()
+		defer unsubscribe2()
+
+		h.Store(initial) // unchanged, so no notification
+		assert.Len(t, ch1, 0)
+
+		for i := 1; i <= 3; i++ {
+			h.Store(testStructForHolder{Level: initial.Level, Count: i})
+		}
+		assert.Equal(t, 3, (<-ch1).Count) // only the latest value is kept
+		assert.Equal(t, 3, (<-ch2).Count)
+
+		unsubscribe1()
+		unsubscribe1() // no effect
+		_, open := <-ch1
+		assert.False(t, open)
+		h.Store(testStructForHolder{Level: initial.Level, Count: 4})
+		assert.Equal(t, 4, (<-ch2).Count)
+	})
+
+	t.Run("concurrent reads and updates", func(t *testing.T) {
+		h, err := NewHolder(initial, false)
+		require.NoError(t, err)
+		ch, unsubscribe := h.Subscribe()
+
+		const updaters, updatesEach, readers = 4, 200, 8
+		var wg sync.WaitGroup
+		done := make(chan struct{})
+		for i := 0; i < readers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				last := 0
+				for {
+					select {
+					case <-done:
+						return
+					default:
+					}
+					c := h.Load()
+					assert.GreaterOrEqual(t, c.Count, last) // updates are never lost or reordered
+					last = c.Count
+				}
+			}()
+		}
+		var received sync.WaitGroup
+		received.Add(1)
+		go func() {
+			defer received.Done()
+			for range ch {
+			}
+		}()
+		var updatersDone sync.WaitGroup
+		for i := 0; i < updaters; i++ {
+			updatersDone.Add(1)
+			go func() {
+				defer updatersDone.Done()
+				for j := 0; j < updatesEach; j++ {
+					h.Update(func(c testStructForHolder) testStructForHolder {
+						c.Count++
+						return c
+					})
+				}
+			}()
+		}
+		updatersDone.Wait()
+		close(done)
+		wg.Wait()
+		unsubscribe()
+		received.Wait()
+		assert.Equal(t, updaters*updatesEach, h.Load().Count)
+	})
+}
@@ -0,0 +1,349 @@
+package configtypes
+
+import (
+	"io"
+	"reflect"
+	"strings"
+)
+
+// LoadINI reads an INI configuration file, in the format used by gcfg and git-config, into a struct,
+// and then checks the struct with ValidateStruct. The target must be a struct pointer.
+//
+//	; comment
+//	port = 8080
+//
+//	[server]
+//	host = https://example.com
+//	hosts = a
+//	hosts = b          # adds to the list
+//	name = "  quoted; with \"escapes\"\n"
+//	verbose            ; a bool with no value is true
+//
+//	[replica "east"]
+//	host = https://east.example.com
+//
+// Each section corresponds to a field of the target struct whose type is a struct or a struct
+// pointer; a section with a subsection name, as in [replica "east"], corresponds to a field of type
+// map[string]*T, with one map entry for each subsection name. Variables before the first section
+// header set fields of the target struct itself. Section and variable names are matched to fields
+// either by the field name or by the variable name in its "conf:" tag, ignoring case and treating "-"
+// and "_" as the same, so both "http-port" and "HttpPort" match a field with `conf:"HTTP_PORT"`.
+//
+// Values are set with the field type's UnmarshalText method if it has one, as all of the Opt types
+// do, or otherwise in the same way as VarReader for bool, int, float64, and string fields. If a
+// variable appears more than once in a section, UnmarshalText is called for each occurrence, so an
+// OptStringList accumulates all of the values, while most types just use the last one; a slice of any
+// of these types also gets one element per occurrence. The first occurrence always replaces any value
+// that the field had before, so you can set default values in the struct before calling LoadINI.
+//
+// LoadINI reports each value that could not be parsed, and each unknown section or variable if
+// options.DisallowUnknownKeys is set, as a separate ValidationError whose Path is the section,
+// subsection, and variable names, and whose Line is its line in the file. Errors from ValidateStruct,
+// which is called with recursive set to true, are added to the same result; their paths use Go field
+// names. If the file is not valid INI syntax, the result contains only one error for the first invalid
+// line and the struct is not validated.
+func LoadINI(r io.Reader, target interface{}, options LoadOptions) ValidationResult {
+	return loadFile(r, target, options, func(data []byte, refStruct reflect.Value, result *ValidationResult) bool {
+		entries, line, err := parseINI(data)
+		if err != nil {
+			result.Add(ValidationError{Err: err, Source: options.Source, Line: line})
+			return false
+		}
+		l := iniLoader{options: options, result: result, seen: make(map[visitKey]bool)}
+		section, sectionPath, inSection := refStruct, ValidationPath(nil), true
+		for _, e := range entries {
+			if e.isSection {
+				section, sectionPath, inSection = l.findSection(refStruct, e)
+			} else if inSection {
+				l.setVariable(section, sectionPath, e)
+			}
+		}
+		return true
+	})
+}
+
+// iniEntry is either a section header or a variable.
+type iniEntry struct {
+	line          int
+	isSection     bool
+	name          string
+	subsection    string
+	hasSubsection bool
+	value         string
+	hasValue      bool
+}
+
+// parseINI parses the file into entries. If it fails, it returns the line number of the error.
+func parseINI(data []byte) ([]iniEntry, int, error) {
+	var entries []iniEntry
+	lines := strings.Split(strings.TrimPrefix(string(data), "\ufeff"), "\n")
+	for i := 0; i < len(lines); i++ {
+		e := iniEntry{line: i + 1}
+		s := strings.TrimLeft(strings.TrimSuffix(lines[i], "\r"), " \t")
+		switch {
+		case isINICommentOrEnd(s):
+			continue
+		case s[0] == '[':
+			var ok bool
+			if e, ok = parseINISectionHeader(s[1:], e); !ok {
+				return nil, e.line, errINIInvalidSectionHeader()
+			}
+		default:
+			n := iniNameLength(s)
+			rest := strings.TrimLeft(s[n:], " \t")
+			switch {
+			case n == 0 || !(isINICommentOrEnd(rest) || rest[0] == '='):
+				return nil, e.line, errINIInvalidLine()
+			case rest != "" && rest[0] == '=':
+				value, continuations, err := parseINIValue(rest[1:], lines[i+1:])
+				if err != nil {
+					return nil, e.line, err
+				}
+				e.value, e.hasValue = value, true
+				i += continuations
+			}
+			e.name = s[:n]
+		}
+		entries = append(entries, e)
+	}
+	return entries, 0, nil
+}
+
+func isINICommentOrEnd(s string) bool {
+	return s == "" || s[0] == ';' || s[0] == '#'
+}
+
+// iniNameLength returns the length of the section or variable name at the start of s, or zero if
+// there is none. A name is a letter followed by letters, digits, "-", or "_".
+func iniNameLength(s string) int {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		isLetter := (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+		if !isLetter && (i == 0 || !((c >= '0' && c <= '9') || c == '-' || c == '_')) {
+			return i
+		}
+	}
+	return len(s)
+}
+
+// parseINISectionHeader parses the rest of a section header after the "[".
+func parseINISectionHeader(s string, e iniEntry) (iniEntry, bool) {
+	e.isSection = true
+	s = strings.TrimLeft(s, " \t")
+	n := iniNameLength(s)
+	if n == 0 {
+		return e, false
+	}
+	e.name, s = s[:n], strings.TrimLeft(s[n:], " \t")
+	if s != "" && s[0] == '"' {
+		var sub []byte
+		i := 1
+		for ; i < len(s) && s[i] != '"'; i++ {
+			if s[i] == '\\' && i+1 < len(s) {
+				i++
+			}
+			sub = append(sub, s[i])
+		}
+		if i == len(s) {
+			return e, false
+		}
+		e.subsection, e.hasSubsection = string(sub), true
+		s = strings.TrimLeft(s[i+1:], " \t")
+	}
+	if s == "" || s[0] != ']' {
+		return e, false
+	}
+	return e, isINICommentOrEnd(strings.TrimLeft(s[1:], " \t"))
+}
+
+// parseINIValue parses the value after the "=". Whitespace around the value is ignored, as is a
+// comment; double quotes can be used to preserve whitespace or include ";" or "#", and a backslash
+// at the end of a line continues the value on the next line. It returns the number of additional
+// lines that were used.
+func parseINIValue(s string, more []string) (string, int, error) {
+	var out []byte
+	keep, quoted, continuations := 0, false, 0
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\\' && i == len(s)-1 && continuations < len(more):
+			s = strings.TrimSuffix(more[continuations], "\r")
+			continuations++
+			i = -1
+		case c == '\\' && i == len(s)-1: // at the end of the file, so there is nothing to continue
+		case c == '\\':
+			i++
+			switch s[i] {
+			case 'n':
+				out = append(out, '\n')
+			case 't':
+				out = append(out, '\t')
+			case '"', '\\':
+				out = append(out, s[i])
+			default:
+				return "", 0, errINIInvalidEscape(s[i])
+			}
+			keep = len(out)
+		case c == '"':
+			quoted = !quoted
+			keep = len(out)
+		case quoted:
+			out = append(out, c)
+			keep = len(out)
+		case c == ';' || c == '#':
+			i = len(s) // the rest of the line is a comment
+		case c == ' ' || c == '\t':
+			if len(out) > 0 {
+				out = append(out, c)
+			}
+		default:
+			out = append(out, c)
+			keep = len(out)
+		}
+	}
+	if quoted {
+		return "", 0, errINIUnterminatedQuote()
+	}
+	return string(out[:keep]), continuations, nil
+}
+
+type iniLoader struct {
+	options LoadOptions
+	result  *ValidationResult
+	seen    map[visitKey]bool // fields that have already been set from this file
+}
+
+func (l *iniLoader) addError(path ValidationPath, line int, err error) {
+	l.result.Add(ValidationError{
+		Path:   append(ValidationPath(nil), path...),
+		Err:    err,
+		Source: l.options.Source,
+		Line:   line,
+	})
+}
+
+// findSection returns the struct that a section header refers to, and its path. It returns false if
+// the section's variables should be skipped.
+func (l *iniLoader) findSection(root reflect.Value, e iniEntry) (reflect.Value, ValidationPath, bool) {
+	path := NewValidationPath(e.name)
+	field, ok := findFieldForKey(root.Type(), e.name)
+	if !ok {
+		if l.options.DisallowUnknownKeys {
+			l.addError(path, e.line, errUnknownKey())
+		}
+		return reflect.Value{}, nil, false
+	}
+	fieldInInstance := root.Field(field.index)
+	t := fieldInInstance.Type()
+	switch {
+	case t.Kind() == reflect.Map && t.Key().Kind() == reflect.String && t.Elem().Kind() == reflect.Ptr &&
+		isStructType(t.Elem().Elem()):
+		if !e.hasSubsection {
+			l.addError(path, e.line, errINISubsectionRequired())
+			return reflect.Value{}, nil, false
+		}
+		if fieldInInstance.IsNil() {
+			fieldInInstance.Set(reflect.MakeMap(t))
+		}
+		key := reflect.ValueOf(e.subsection).Convert(t.Key())
+		ptr := fieldInInstance.MapIndex(key)
+		if !ptr.IsValid() || ptr.IsNil() {
+			ptr = reflect.New(t.Elem().Elem())
+			fieldInInstance.SetMapIndex(key, ptr)
+		}
+		return ptr.Elem(), append(path, PathKey(e.subsection)), true
+	case isStructType(t) || (t.Kind() == reflect.Ptr && isStructType(t.Elem())):
+		if e.hasSubsection {
+			l.addError(path, e.line, errINISubsectionNotAllowed())
+			return reflect.Value{}, nil, false
+		}
+		if t.Kind() == reflect.Ptr {
+			if fieldInInstance.IsNil() {
+				fieldInInstance.Set(reflect.New(t.Elem()))
+			}
+			return fieldInInstance.Elem(), path, true
+		}
+		return fieldInInstance, path, true
+	}
+	l.addError(path, e.line, errININotASection())
+	return reflect.Value{}, nil, false
+}
+
+func (l *iniLoader) setVariable(section reflect.Value, sectionPath ValidationPath, e iniEntry) {
+	path := sectionPath.child(PathField(e.name))
+	field, ok := findFieldForKey(section.Type(), e.name)
+	if !ok {
+		if l.options.DisallowUnknownKeys {
+			l.addError(path, e.line, errUnknownKey())
+		}
+		return
+	}
+	fieldInInstance := section.Field(field.index)
+	value := e.value
+	if !e.hasValue {
+		if !isINIBoolType(fieldInInstance.Type()) {
+			l.addError(path, e.line, errINIMissingValue())
+			return
+		}
+		value = "true"
+	}
+	key := visitKey{fieldInInstance.Addr().Pointer(), fieldInInstance.Type()}
+	first := !l.seen[key]
+	l.seen[key] = true
+	if err := setINIValue(fieldInInstance, value, first); err != nil {
+		if field.tagInfo.secret {
+			err = redactSecretInError(err, value)
+		}
+		l.addError(path, e.line, err)
+	}
+}
+
+// setINIValue sets an addressable value from a string. If first is true, the new value replaces the
+// old one; otherwise, it is added to the old one if the type supports multiple values.
+func setINIValue(target reflect.Value, value string, first bool) error {
+	t := target.Type()
+	switch {
+	case t.Kind() == reflect.Ptr:
+		if first || target.IsNil() {
+			newValue := reflect.New(t.Elem())
+			if err := setINIValue(newValue.Elem(), value, true); err != nil {
+				return err
+			}
+			target.Set(newValue)
+			return nil
+		}
+		return setINIValue(target.Elem(), value, false)
+	case t.Kind() == reflect.Slice && setterForTarget(target.Addr().Interface()) == nil:
+		elem := reflect.New(t.Elem()).Elem()
+		if err := setINIValue(elem, value, true); err != nil {
+			return err
+		}
+		if first {
+			target.Set(reflect.Zero(t))
+		}
+		target.Set(reflect.Append(target, elem))
+		return nil
+	}
+	if !first {
+		if setter := setterForTarget(target.Addr().Interface()); setter != nil {
+			return setter([]byte(value))
+		}
+	}
+	newValue := reflect.New(t) // so that the old value is kept if the new one is invalid
+	setter := setterForTarget(newValue.Interface())
+	if setter == nil {
+		return varReaderBadTargetTypeError(target.Addr().Interface())
+	}
+	if err := setter([]byte(value)); err != nil {
+		return err
+	}
+	target.Set(newValue.Elem())
+	return nil
+}
+
+func isINIBoolType(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Bool || t == reflect.TypeOf(OptBool{})
+}
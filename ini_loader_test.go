@@ -0,0 +1,149 @@
+package configtypes
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testStructForINI struct {
+	Port    OptIntGreaterThanZero `conf:"PORT"`
+	Name    OptString             `conf:",required"`
+	Server  testStructForINIServer
+	Backup  *testStructForINIServer
+	Replica map[string]*testStructForINIServer
+}
+
+type testStructForINIServer struct {
+	Host        OptURLAbsolute `conf:"HOST"`
+	Hosts       OptStringList
+	Tags        []string
+	Verbose     bool
+	Debug       OptBool
+	Label       string
+	Timeout     *OptDuration
+	ReadTimeout OptDuration `conf:"READ_TIMEOUT"`
+}
+
+func TestLoadINI(t *testing.T) {
+	t.Run("valid file", func(t *testing.T) {
+		s := testStructForINI{
+			Port:   mustOptIntGreaterThanZero(1),
+			Server: testStructForINIServer{Hosts: NewOptStringList([]string{"default"}), Label: "default"},
+		}
+		result := LoadINI(strings.NewReader("\ufeff"+`; comment
+# another comment
+port = 8080
+name = n ; trailing comment
+
+[server]
+host = http://a
+hosts = a
+  hosts=b,c   # comma-delimited values are also allowed
+tags = x
+tags = y
+verbose
+Debug = true
+label = "  quoted; \"text\"\t" with more \
+continued
+timeout = 5s
+read-timeout = 3s
+
+[ backup ]
+[replica "east"]
+host = http://b
+[Replica "we\"st"]
+label = w
+[replica "east"]
+label = e`+"\r\n"), &s, LoadOptions{})
+		assert.True(t, result.OK(), result.Errors())
+		hostA, _ := NewOptURLAbsoluteFromString("http://a")
+		hostB, _ := NewOptURLAbsoluteFromString("http://b")
+		timeout := NewOptDuration(5 * time.Second)
+		assert.Equal(t, testStructForINI{
+			Port: mustOptIntGreaterThanZero(8080),
+			Name: NewOptString("n"),
+			Server: testStructForINIServer{
+				Host:        hostA,
+				Hosts:       NewOptStringList([]string{"a", "b", "c"}),
+				Tags:        []string{"x", "y"},
+				Verbose:     true,
+				Debug:       NewOptBool(true),
+				Label:       "  quoted; \"text\"\t with more continued",
+				Timeout:     &timeout,
+				ReadTimeout: NewOptDuration(3 * time.Second),
+			},
+			Backup: &testStructForINIServer{},
+			Replica: map[string]*testStructForINIServer{
+				"east":  {Host: hostB, Label: "e"},
+				`we"st`: {Label: "w"},
+			},
+		}, s)
+	})
+
+	t.Run("field errors", func(t *testing.T) {
+		s := testStructForINI{Port: mustOptIntGreaterThanZero(1)}
+		result := LoadINI(strings.NewReader(strings.Join([]string{
+			"port = 0",
+			"name = n",
+			"other = 1",
+			"[server]",
+			"host = not/absolute",
+			"label",
+			"[unknown]",
+			"x = 1",
+			"[port]",
+			`[server "x"]`,
+			"[replica]",
+			`[replica "a"]`,
+			"timeout = x",
+		}, "\n")), &s, LoadOptions{Source: "config.ini", DisallowUnknownKeys: true})
+		assert.Equal(t, []ValidationError{
+			{Path: NewValidationPath("port"), Err: errMustBeGreaterThanZero(), Source: "config.ini", Line: 1},
+			{Path: NewValidationPath("other"), Err: errUnknownKey(), Source: "config.ini", Line: 3},
+			{Path: NewValidationPath("server", "host"), Err: errURLNotAbsolute(), Source: "config.ini", Line: 5},
+			{Path: NewValidationPath("server", "label"), Err: errINIMissingValue(), Source: "config.ini", Line: 6},
+			{Path: NewValidationPath("unknown"), Err: errUnknownKey(), Source: "config.ini", Line: 7},
+			{Path: NewValidationPath("port"), Err: errININotASection(), Source: "config.ini", Line: 9},
+			{Path: NewValidationPath("server"), Err: errINISubsectionNotAllowed(), Source: "config.ini", Line: 10},
+			{Path: NewValidationPath("replica"), Err: errINISubsectionRequired(), Source: "config.ini", Line: 11},
+			{
+				Path:   ValidationPath{PathField("replica"), PathKey("a"), PathField("timeout")},
+				Err:    errDurationFormat(),
+				Source: "config.ini", Line: 13,
+			},
+		}, result.Errors())
+		assert.Equal(t, mustOptIntGreaterThanZero(1), s.Port) // an invalid value does not replace the old one
+		assert.Equal(t, &testStructForINIServer{}, s.Replica["a"])
+	})
+
+	t.Run("ValidateStruct errors are added", func(t *testing.T) {
+		var s testStructForINI
+		result := LoadINI(strings.NewReader("port = 1"), &s, LoadOptions{})
+		assert.Equal(t, []ValidationError{{Path: NewValidationPath("Name"), Err: errRequired()}}, result.Errors())
+	})
+
+	t.Run("syntax errors", func(t *testing.T) {
+		for input, expected := range map[string]ValidationError{
+			"a = 1\n[server":             {Err: errINIInvalidSectionHeader(), Line: 2},
+			`[server "x]`:                {Err: errINIInvalidSectionHeader(), Line: 1},
+			"[server] x":                 {Err: errINIInvalidSectionHeader(), Line: 1},
+			"[]":                         {Err: errINIInvalidSectionHeader(), Line: 1},
+			"\n\n= 1":                    {Err: errINIInvalidLine(), Line: 3},
+			"a b":                        {Err: errINIInvalidLine(), Line: 1},
+			"1a = b":                     {Err: errINIInvalidLine(), Line: 1},
+			`a = "b`:                     {Err: errINIUnterminatedQuote(), Line: 1},
+			"a = \"b \\\nc\nd = \"e":     {Err: errINIUnterminatedQuote(), Line: 1},
+			`a = \q`:                     {Err: errINIInvalidEscape('q'), Line: 1},
+			"a = 1\nb = 2 \\\nc = \\q\n": {Err: errINIInvalidEscape('q'), Line: 2},
+		} {
+			t.Run(input, func(t *testing.T) {
+				var s testStructForINI
+				result := LoadINI(strings.NewReader(input), &s, LoadOptions{})
+				assert.Equal(t, []ValidationError{expected}, result.Errors())
+			})
+		}
+	})
+}
@@ -0,0 +1,122 @@
+package configtypes
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+)
+
+// These helpers implement the parsing rules for OptInt64, OptUint, and OptUint64. Text is parsed with
+// strconv, and a value that is too large for the type is reported as out of range rather than as a
+// format error. A JSON value is first converted to decimal text, so JSON numbers are never converted
+// to float64.
+
+// maxJSONIntegerBits is larger than the number of bits in any of our integer types. A JSON number with
+// a fraction or exponent is parsed with this much precision, which is enough to tell whether it is
+// exactly equal to an integer in the range of those types.
+const maxJSONIntegerBits = 128
+
+// parseInt64 parses a decimal integer with an optional sign, such as "-5" or "+5".
+func parseInt64(s string) (int64, error) {
+	n, err := strconv.ParseInt(s, 10, 64)
+	switch {
+	case errors.Is(err, strconv.ErrRange):
+		return 0, errIntOutOfRange(math.MinInt64, math.MaxInt64)
+	case err != nil:
+		return 0, errIntFormat()
+	}
+	return n, nil
+}
+
+// parseUint64 parses a decimal integer with an optional sign, such as "5" or "+5", that must be no
+// greater than maxValue. A negative integer is out of range rather than a format error.
+func parseUint64(s string, maxValue uint64) (uint64, error) {
+	if strings.HasPrefix(s, "-") {
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil && !errors.Is(err, strconv.ErrRange) {
+			return 0, errIntFormat()
+		}
+		return int64ToUint64(n, maxValue) // ParseInt returns math.MinInt64 if out of range
+	}
+	n, err := strconv.ParseUint(strings.TrimPrefix(s, "+"), 10, 64)
+	switch {
+	case errors.Is(err, strconv.ErrRange) || (err == nil && n > maxValue):
+		return 0, errIntOutOfRange(0, maxValue)
+	case err != nil:
+		return 0, errIntFormat()
+	}
+	return n, nil
+}
+
+func int64ToUint64(n int64, maxValue uint64) (uint64, error) {
+	if n < 0 || uint64(n) > maxValue {
+		return 0, errIntOutOfRange(0, maxValue)
+	}
+	return uint64(n), nil
+}
+
+// parseJSONInteger parses a JSON number that is an integer, or a JSON string containing a decimal
+// integer, or a JSON null, and returns the integer as text for parseInt64 or parseUint64. It returns
+// false for a null. A number with a fraction or exponent, such as 1e3, is allowed if its value is
+// exactly an integer.
+func parseJSONInteger(data []byte) (string, bool, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return "", false, err
+	}
+	switch n := v.(type) {
+	case nil:
+		return "", false, nil
+	case string:
+		return n, true, nil
+	case json.Number:
+		if !strings.ContainsAny(n.String(), ".eE") {
+			return n.String(), true, nil
+		}
+		f, _, err := big.ParseFloat(n.String(), 10, maxJSONIntegerBits, big.ToNearestEven)
+		if err != nil {
+			return "", false, errIntFormat()
+		}
+		return bigFloatToIntegerText(f)
+	}
+	return "", false, errIntFormat()
+}
+
+// ldValueInteger converts an ldvalue.Value with the same rules as parseJSONInteger. Since ldvalue.Value
+// stores numbers as float64, a number beyond 2^53 may not be exact; a string is always exact.
+func ldValueInteger(v ldvalue.Value) (string, bool, error) {
+	switch {
+	case v.IsNull():
+		return "", false, nil
+	case v.IsString():
+		return v.StringValue(), true, nil
+	case v.IsNumber():
+		f := v.Float64Value()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return "", false, errIntFormat()
+		}
+		return bigFloatToIntegerText(new(big.Float).SetFloat64(f))
+	}
+	return "", false, errIntFormat()
+}
+
+func bigFloatToIntegerText(f *big.Float) (string, bool, error) {
+	if f.IsInf() || f.MantExp(nil) > maxJSONIntegerBits {
+		// Too large for any of our types, so there is no need to compute the exact value, which could
+		// be very expensive for a number like 1e1000000000.
+		return new(big.Int).Lsh(big.NewInt(int64(f.Sign())), maxJSONIntegerBits).String(), true, nil
+	}
+	if !f.IsInt() || f.Acc() != big.Exact {
+		return "", false, errIntFormat()
+	}
+	n, _ := f.Int(nil)
+	return n.String(), true, nil
+}
@@ -0,0 +1,122 @@
+package configtypes
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseInt64(t *testing.T) {
+	for input, expected := range map[string]int64{
+		"0": 0, "-0": 0, "+5": 5, "-5": -5, "007": 7,
+		"9223372036854775807": math.MaxInt64, "-9223372036854775808": math.MinInt64,
+	} {
+		n, err := parseInt64(input)
+		if assert.NoError(t, err, input) {
+			assert.Equal(t, expected, n, input)
+		}
+	}
+
+	for _, input := range []string{"9223372036854775808", "-9223372036854775809", "1" + strings.Repeat("0", 100)} {
+		_, err := parseInt64(input)
+		assert.Equal(t, errIntOutOfRange(math.MinInt64, math.MaxInt64), err, input)
+	}
+
+	for _, input := range []string{"", " 1", "1.0", "1e3", "0x10", "1_000", "+-1", "++1", "x"} {
+		_, err := parseInt64(input)
+		assert.Equal(t, errIntFormat(), err, input)
+	}
+}
+
+func TestParseUint64(t *testing.T) {
+	for input, expected := range map[string]uint64{
+		"0": 0, "-0": 0, "+5": 5, "007": 7, "255": 255,
+	} {
+		n, err := parseUint64(input, math.MaxUint8)
+		if assert.NoError(t, err, input) {
+			assert.Equal(t, expected, n, input)
+		}
+	}
+
+	for _, input := range []string{"256", "-1", "-9223372036854775809", "18446744073709551616"} {
+		_, err := parseUint64(input, math.MaxUint8)
+		assert.Equal(t, errIntOutOfRange(0, math.MaxUint8), err, input)
+	}
+
+	for _, input := range []string{"", " 1", "-", "+", "1.0", "+-1", "-+1", "--1", "x"} {
+		_, err := parseUint64(input, math.MaxUint8)
+		assert.Equal(t, errIntFormat(), err, input)
+	}
+}
+
+func TestParseJSONInteger(t *testing.T) {
+	t.Run("valid values", func(t *testing.T) {
+		for input, expected := range map[string]string{
+			`0`:                        "0",
+			`-0`:                       "-0",
+			`"-5"`:                     "-5",
+			`1.5e1`:                    "15",
+			`100E-2`:                   "1",
+			`18446744073709551616`:     "18446744073709551616",
+			`1.8446744073709551616e19`: "18446744073709551616",
+		} {
+			text, defined, err := parseJSONInteger([]byte(input))
+			if assert.NoError(t, err, input) {
+				assert.True(t, defined, input)
+				assert.Equal(t, expected, text, input)
+			}
+		}
+	})
+
+	t.Run("null", func(t *testing.T) {
+		_, defined, err := parseJSONInteger([]byte(`null`))
+		assert.NoError(t, err)
+		assert.False(t, defined)
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		for _, input := range []string{
+			`1.5`, `1e-1`, `1.0000000000000000000000000000000000000001`, `true`, `[1]`,
+		} {
+			_, _, err := parseJSONInteger([]byte(input))
+			assert.Equal(t, errIntFormat(), err, input)
+		}
+	})
+
+	t.Run("very large exponent is out of range without being computed", func(t *testing.T) {
+		for _, input := range []string{`1e1000000000`, `-1e1000000000`, `1e300`} {
+			text, _, err := parseJSONInteger([]byte(input))
+			if assert.NoError(t, err, input) {
+				_, err = parseInt64(text)
+				assert.Equal(t, errIntOutOfRange(math.MinInt64, math.MaxInt64), err, input)
+				_, err = parseUint64(text, math.MaxUint64)
+				assert.Equal(t, errIntOutOfRange(0, math.MaxUint64), err, input)
+			}
+		}
+	})
+}
+
+func TestLDValueInteger(t *testing.T) {
+	text, defined, err := ldValueInteger(ldvalue.Float64(1e3))
+	assert.NoError(t, err)
+	assert.True(t, defined)
+	assert.Equal(t, "1000", text)
+
+	text, _, err = ldValueInteger(ldvalue.String("18446744073709551615"))
+	assert.NoError(t, err)
+	assert.Equal(t, "18446744073709551615", text)
+
+	_, defined, err = ldValueInteger(ldvalue.Null())
+	assert.NoError(t, err)
+	assert.False(t, defined)
+
+	for _, v := range []ldvalue.Value{
+		ldvalue.Float64(0.5), ldvalue.Float64(math.NaN()), ldvalue.Float64(math.Inf(1)), ldvalue.Bool(true),
+	} {
+		_, _, err := ldValueInteger(v)
+		assert.Equal(t, errIntFormat(), err, v.JSONString())
+	}
+}
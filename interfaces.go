@@ -25,3 +25,24 @@ type Validation interface {
 	// Validate is called by the validation logic to check the validity of this value.
 	Validate() ValidationResult
 }
+
+// SecretValue is implemented by types whose values are sensitive, such as OptSecret and ReqSecret.
+//
+// VarReader, DescribeVars, and other functions in this package that might otherwise show the value of
+// a variable will redact the value of such a type, the same as for a field with the "secret" field tag
+// option. VarWriter uses Reveal to get the value, but only if secrets have been revealed with
+// VarWriter.WithSecretsRevealed.
+type SecretValue interface {
+	// Reveal returns the actual value in text form.
+	Reveal() string
+}
+
+// RequiredValue is an optional interface for types that always require a value, such as ReqSecret.
+//
+// If IsRequired returns true for the zero value of a type, then ValidateStruct and VarReader.ReadStruct
+// treat a field of that type, or of a pointer to that type, as if its field tag had the "required"
+// option.
+type RequiredValue interface {
+	// IsRequired returns true if a value is required.
+	IsRequired() bool
+}
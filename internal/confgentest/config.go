@@ -0,0 +1,70 @@
+// Package confgentest contains configuration structs for verifying that the code generated by
+// cmd/confgen behaves the same as the reflection-based functions in configtypes.
+package confgentest
+
+import (
+	ct "github.com/launchdarkly/go-configtypes/v2"
+)
+
+//go:generate go run ../../cmd/confgen -type Config
+//go:generate go run ../../cmd/confgen -type NonRecursiveConfig -recursive=false -output nonrecursive_confgen.go
+
+// Config uses every kind of field that the generator supports.
+type Config struct {
+	Common
+	Name     ct.OptString              `conf:"NAME,required"`
+	Port     ct.OptIntGreaterThanZero  `conf:"PORT"`
+	Debug    bool                      `conf:"DEBUG"`
+	Count    int                       `conf:"COUNT,required"`
+	Ratio    float64                   `conf:"RATIO"`
+	Label    string                    `conf:"LABEL,required"`
+	Timeout  *ct.OptDuration           `conf:"TIMEOUT"`
+	Limit    *int                      `conf:"LIMIT,required"`
+	Hosts    ct.OptStringList          `conf:"HOSTS"`
+	Level    Level                     `conf:"LEVEL,required"`
+	Tags     []string                  `conf:",required"`
+	APIKey   ct.ReqSecret              `conf:"API_KEY"`
+	Password ct.OptSecret              `conf:"PASSWORD"`
+	Token    string                    `conf:"TOKEN,secret"`
+	Retries  ct.OptIntBetween[Retries] `conf:"RETRIES"`
+	Untagged string
+	internal string `conf:"INTERNAL"` //nolint:unused
+
+	Server    ServerConfig
+	Backup    *ServerConfig
+	Primary   *ServerConfig `conf:",required"`
+	Upstreams []ServerConfig
+	Replicas  []*ServerConfig
+	Named     map[string]ServerConfig
+	ByID      map[int]*ServerConfig
+	Fixed     [2]ServerConfig
+	Next      *Config
+}
+
+// Common is embedded in Config.
+type Common struct {
+	Env ct.OptString `conf:"ENV,required"`
+}
+
+// ServerConfig is used in nested fields of Config.
+type ServerConfig struct {
+	Host ct.OptString      `conf:"HOST,required"`
+	URL  ct.OptURLAbsolute `conf:"URL"`
+}
+
+// Retries is the range of Config.Retries.
+type Retries struct{}
+
+// Range implements ct.Bounds.
+func (Retries) Range() ct.NumericRange[int] { return ct.RangeBetween(0, 10) }
+
+// Level is a named type that is not a struct.
+type Level int
+
+// NonRecursiveConfig is used to test the generator's non-recursive mode.
+type NonRecursiveConfig struct {
+	Name    ct.OptString  `conf:"NAME,required"`
+	Server  ServerConfig  `conf:",required"`
+	Backup  *ServerConfig `conf:",required"`
+	Servers []ServerConfig
+}
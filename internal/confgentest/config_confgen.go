@@ -0,0 +1,204 @@
+// Code generated by confgen -type Config; DO NOT EDIT.
+
+package confgentest
+
+import (
+	"sort"
+
+	"github.com/launchdarkly/go-configtypes/v2"
+)
+
+// ReadFrom reads configuration variables into the fields of Config. It behaves the same as
+// calling the recursive version of r.ReadStruct(s), but does not use reflection.
+func (s *Config) ReadFrom(r *configtypes.VarReader) {
+	s.confgenReadFrom(r, make(map[interface{}]bool))
+}
+
+// Validate checks the fields of Config according to their field tags. It behaves the same as
+// calling the recursive version of configtypes.ValidateStruct(s), but does not use reflection.
+func (s *Config) Validate() configtypes.ValidationResult {
+	return s.confgenValidate(make(map[interface{}]bool))
+}
+
+func (s *Config) confgenReadFrom(r *configtypes.VarReader, visited map[interface{}]bool) {
+	s.Common.confgenReadFrom(r, visited)
+	r.ReadRequired("NAME", &s.Name)
+	r.Read("PORT", &s.Port)
+	r.Read("DEBUG", &s.Debug)
+	r.ReadRequired("COUNT", &s.Count)
+	r.Read("RATIO", &s.Ratio)
+	r.ReadRequired("LABEL", &s.Label)
+	if s.Timeout != nil {
+		r.Read("TIMEOUT", s.Timeout)
+	} else if v := new(configtypes.OptDuration); r.Read("TIMEOUT", v) {
+		s.Timeout = v
+	}
+	if s.Limit != nil {
+		r.ReadRequired("LIMIT", s.Limit)
+	} else if v := new(int); r.ReadRequired("LIMIT", v) {
+		s.Limit = v
+	}
+	r.Read("HOSTS", &s.Hosts)
+	r.ReadRequired("LEVEL", &s.Level)
+	r.WithSecretValues().ReadRequired("API_KEY", &s.APIKey)
+	r.WithSecretValues().Read("PASSWORD", &s.Password)
+	r.WithSecretValues().Read("TOKEN", &s.Token)
+	r.Read("RETRIES", &s.Retries)
+	s.Server.confgenReadFrom(r, visited)
+	if s.Backup != nil && !visited[s.Backup] {
+		visited[s.Backup] = true
+		s.Backup.confgenReadFrom(r, visited)
+		delete(visited, s.Backup)
+	}
+	if s.Primary != nil && !visited[s.Primary] {
+		visited[s.Primary] = true
+		s.Primary.confgenReadFrom(r, visited)
+		delete(visited, s.Primary)
+	}
+	if s.Next != nil && !visited[s.Next] {
+		visited[s.Next] = true
+		s.Next.confgenReadFrom(r, visited)
+		delete(visited, s.Next)
+	}
+}
+
+func (s *Config) confgenValidate(visited map[interface{}]bool) configtypes.ValidationResult {
+	var result configtypes.ValidationResult
+	if sub := s.Common.confgenValidate(visited); !sub.OK() {
+		result.AddAll(configtypes.NewValidationPath("Common"), sub)
+	}
+	if !s.Name.IsDefined() {
+		result.Add(configtypes.ValidationError{Path: configtypes.NewValidationPath("Name"), Err: configtypes.ErrRequired(), VarName: "NAME"})
+	}
+	if s.Count == 0 {
+		result.Add(configtypes.ValidationError{Path: configtypes.NewValidationPath("Count"), Err: configtypes.ErrRequired(), VarName: "COUNT"})
+	}
+	if s.Label == "" {
+		result.Add(configtypes.ValidationError{Path: configtypes.NewValidationPath("Label"), Err: configtypes.ErrRequired(), VarName: "LABEL"})
+	}
+	if s.Limit == nil {
+		result.Add(configtypes.ValidationError{Path: configtypes.NewValidationPath("Limit"), Err: configtypes.ErrRequired(), VarName: "LIMIT"})
+	}
+	if s.Level == 0 {
+		result.Add(configtypes.ValidationError{Path: configtypes.NewValidationPath("Level"), Err: configtypes.ErrRequired(), VarName: "LEVEL"})
+	}
+	if s.Tags == nil {
+		result.Add(configtypes.ValidationError{Path: configtypes.NewValidationPath("Tags"), Err: configtypes.ErrRequired()})
+	}
+	if !s.APIKey.IsDefined() {
+		result.Add(configtypes.ValidationError{Path: configtypes.NewValidationPath("APIKey"), Err: configtypes.ErrRequired(), VarName: "API_KEY"})
+	}
+	if sub := s.Server.confgenValidate(visited); !sub.OK() {
+		result.AddAll(configtypes.NewValidationPath("Server"), sub)
+	}
+	if s.Backup != nil && !visited[s.Backup] {
+		visited[s.Backup] = true
+		sub := s.Backup.confgenValidate(visited)
+		delete(visited, s.Backup)
+		if !sub.OK() {
+			result.AddAll(configtypes.NewValidationPath("Backup"), sub)
+		}
+	}
+	if s.Primary == nil {
+		result.Add(configtypes.ValidationError{Path: configtypes.NewValidationPath("Primary"), Err: configtypes.ErrRequired()})
+	} else if !visited[s.Primary] {
+		visited[s.Primary] = true
+		sub := s.Primary.confgenValidate(visited)
+		delete(visited, s.Primary)
+		if !sub.OK() {
+			result.AddAll(configtypes.NewValidationPath("Primary"), sub)
+		}
+	}
+	for i := range s.Upstreams {
+		elem := s.Upstreams[i]
+		if sub := elem.confgenValidate(visited); !sub.OK() {
+			result.AddAll(configtypes.ValidationPath{configtypes.PathField("Upstreams"), configtypes.PathIndex(i)}, sub)
+		}
+	}
+	for i := range s.Replicas {
+		elem := s.Replicas[i]
+		if elem != nil && !visited[elem] {
+			visited[elem] = true
+			sub := elem.confgenValidate(visited)
+			delete(visited, elem)
+			if !sub.OK() {
+				result.AddAll(configtypes.ValidationPath{configtypes.PathField("Replicas"), configtypes.PathIndex(i)}, sub)
+			}
+		}
+	}
+	{
+		keys := make([]string, 0, len(s.Named))
+		for k := range s.Named {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			return keys[i] < keys[j]
+		})
+		for _, k := range keys {
+			elem := s.Named[k]
+			if sub := elem.confgenValidate(visited); !sub.OK() {
+				result.AddAll(configtypes.ValidationPath{configtypes.PathField("Named"), configtypes.PathKey(k)}, sub)
+			}
+		}
+	}
+	{
+		keys := make([]int, 0, len(s.ByID))
+		for k := range s.ByID {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			return keys[i] < keys[j]
+		})
+		for _, k := range keys {
+			elem := s.ByID[k]
+			if elem != nil && !visited[elem] {
+				visited[elem] = true
+				sub := elem.confgenValidate(visited)
+				delete(visited, elem)
+				if !sub.OK() {
+					result.AddAll(configtypes.ValidationPath{configtypes.PathField("ByID"), configtypes.PathKey(k)}, sub)
+				}
+			}
+		}
+	}
+	for i := range s.Fixed {
+		elem := s.Fixed[i]
+		if sub := elem.confgenValidate(visited); !sub.OK() {
+			result.AddAll(configtypes.ValidationPath{configtypes.PathField("Fixed"), configtypes.PathIndex(i)}, sub)
+		}
+	}
+	if s.Next != nil && !visited[s.Next] {
+		visited[s.Next] = true
+		sub := s.Next.confgenValidate(visited)
+		delete(visited, s.Next)
+		if !sub.OK() {
+			result.AddAll(configtypes.NewValidationPath("Next"), sub)
+		}
+	}
+	return result
+}
+
+func (s *Common) confgenReadFrom(r *configtypes.VarReader, visited map[interface{}]bool) {
+	r.ReadRequired("ENV", &s.Env)
+}
+
+func (s *Common) confgenValidate(visited map[interface{}]bool) configtypes.ValidationResult {
+	var result configtypes.ValidationResult
+	if !s.Env.IsDefined() {
+		result.Add(configtypes.ValidationError{Path: configtypes.NewValidationPath("Env"), Err: configtypes.ErrRequired(), VarName: "ENV"})
+	}
+	return result
+}
+
+func (s *ServerConfig) confgenReadFrom(r *configtypes.VarReader, visited map[interface{}]bool) {
+	r.ReadRequired("HOST", &s.Host)
+	r.Read("URL", &s.URL)
+}
+
+func (s *ServerConfig) confgenValidate(visited map[interface{}]bool) configtypes.ValidationResult {
+	var result configtypes.ValidationResult
+	if !s.Host.IsDefined() {
+		result.Add(configtypes.ValidationError{Path: configtypes.NewValidationPath("Host"), Err: configtypes.ErrRequired(), VarName: "HOST"})
+	}
+	return result
+}
@@ -0,0 +1,124 @@
+package confgentest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	ct "github.com/launchdarkly/go-configtypes/v2"
+)
+
+// Each test case builds a fresh struct twice, so that the generated methods and the reflection-based
+// functions can each modify their own copy.
+
+type configCase struct {
+	name string
+	vars map[string]string
+	make func() *Config
+}
+
+func makeServer(host string) ServerConfig {
+	return ServerConfig{Host: ct.NewOptString(host)}
+}
+
+func makeCompleteConfig() *Config {
+	limit := 1
+	primary := makeServer("primary")
+	return &Config{
+		Common:  Common{Env: ct.NewOptString("test")},
+		Name:    ct.NewOptString("name"),
+		Count:   1,
+		Label:   "label",
+		Limit:   &limit,
+		Level:   1,
+		Tags:    []string{},
+		Server:  makeServer("server"),
+		Primary: &primary,
+		Fixed:   [2]ServerConfig{makeServer("a"), makeServer("b")},
+	}
+}
+
+func configCases() []configCase {
+	return []configCase{
+		{name: "empty", make: func() *Config { return &Config{} }},
+		{name: "complete", make: makeCompleteConfig},
+		{
+			name: "all variables set",
+			vars: map[string]string{
+				"ENV": "prod", "NAME": "n", "PORT": "8080", "DEBUG": "true", "COUNT": "3", "RATIO": "0.5",
+				"LABEL": "x", "TIMEOUT": "5s", "LIMIT": "10", "HOSTS": "a,b", "LEVEL": "2", "HOST": "h",
+				"URL": "http://localhost", "RETRIES": "3", "INTERNAL": "ignored",
+			},
+			make: func() *Config {
+				backup := ServerConfig{}
+				return &Config{Backup: &backup}
+			},
+		},
+		{
+			name: "bad variables",
+			vars: map[string]string{
+				"PORT": "0", "DEBUG": "maybe", "TIMEOUT": "x", "LIMIT": "y", "URL": "/relative", "RETRIES": "11",
+			},
+			make: makeCompleteConfig,
+		},
+		{
+			name: "collections",
+			make: func() *Config {
+				c := makeCompleteConfig()
+				good, bad := makeServer("good"), ServerConfig{}
+				c.Upstreams = []ServerConfig{good, bad, bad}
+				c.Replicas = []*ServerConfig{nil, &bad, &good, &bad}
+				c.Named = map[string]ServerConfig{"b": bad, "a": bad, "c": good}
+				c.ByID = map[int]*ServerConfig{10: &bad, 9: &bad, 1: nil}
+				c.Fixed[1] = bad
+				return c
+			},
+		},
+		{
+			name: "cycle",
+			vars: map[string]string{"NAME": "n"},
+			make: func() *Config {
+				c := makeCompleteConfig()
+				other := &Config{Next: c}
+				c.Next = other
+				return c
+			},
+		},
+	}
+}
+
+func TestGeneratedReadFromMatchesReadStruct(t *testing.T) {
+	for _, tc := range configCases() {
+		t.Run(tc.name, func(t *testing.T) {
+			expected, actual := tc.make(), tc.make()
+			r1, r2 := ct.NewVarReaderFromValues(tc.vars), ct.NewVarReaderFromValues(tc.vars)
+			r1.ReadStruct(expected, true)
+			actual.ReadFrom(r2)
+			assert.Equal(t, expected, actual)
+			assert.Equal(t, r1.Result(), r2.Result())
+		})
+	}
+}
+
+func TestGeneratedValidateMatchesValidateStruct(t *testing.T) {
+	for _, tc := range configCases() {
+		t.Run(tc.name, func(t *testing.T) {
+			c := tc.make()
+			assert.Equal(t, ct.ValidateStruct(c, true), c.Validate())
+		})
+	}
+}
+
+func TestGeneratedNonRecursiveMethodsMatchReflection(t *testing.T) {
+	vars := map[string]string{"NAME": "n", "HOST": "h"}
+	expected, actual := &NonRecursiveConfig{}, &NonRecursiveConfig{}
+	r1, r2 := ct.NewVarReaderFromValues(vars), ct.NewVarReaderFromValues(vars)
+	r1.ReadStruct(expected, false)
+	actual.ReadFrom(r2)
+	assert.Equal(t, expected, actual)
+	assert.Equal(t, r1.Result(), r2.Result())
+
+	c := &NonRecursiveConfig{Servers: []ServerConfig{{}}}
+	assert.Equal(t, ct.ValidateStruct(c, false), c.Validate())
+	assert.Len(t, c.Validate().Errors(), 2) // Name and Backup
+}
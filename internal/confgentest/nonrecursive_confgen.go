@@ -0,0 +1,34 @@
+// Code generated by confgen -type NonRecursiveConfig -recursive=false; DO NOT EDIT.
+
+package confgentest
+
+import (
+	"github.com/launchdarkly/go-configtypes/v2"
+)
+
+// ReadFrom reads configuration variables into the fields of NonRecursiveConfig. It behaves the same as
+// calling the non-recursive version of r.ReadStruct(s), but does not use reflection.
+func (s *NonRecursiveConfig) ReadFrom(r *configtypes.VarReader) {
+	s.confgenReadFrom(r, make(map[interface{}]bool))
+}
+
+// Validate checks the fields of NonRecursiveConfig according to their field tags. It behaves the same as
+// calling the non-recursive version of configtypes.ValidateStruct(s), but does not use reflection.
+func (s *NonRecursiveConfig) Validate() configtypes.ValidationResult {
+	return s.confgenValidate(make(map[interface{}]bool))
+}
+
+func (s *NonRecursiveConfig) confgenReadFrom(r *configtypes.VarReader, visited map[interface{}]bool) {
+	r.ReadRequired("NAME", &s.Name)
+}
+
+func (s *NonRecursiveConfig) confgenValidate(visited map[interface{}]bool) configtypes.ValidationResult {
+	var result configtypes.ValidationResult
+	if !s.Name.IsDefined() {
+		result.Add(configtypes.ValidationError{Path: configtypes.NewValidationPath("Name"), Err: configtypes.ErrRequired(), VarName: "NAME"})
+	}
+	if s.Backup == nil {
+		result.Add(configtypes.ValidationError{Path: configtypes.NewValidationPath("Backup"), Err: configtypes.ErrRequired()})
+	}
+	return result
+}
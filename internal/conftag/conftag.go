@@ -0,0 +1,54 @@
+// Package conftag parses the "conf" struct field tags that are used by configtypes. It is shared by
+// the reflection-based functions in configtypes and by the code generator in cmd/confgen, so that
+// both interpret tags identically.
+package conftag
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Name is the struct tag key.
+const Name = "conf"
+
+// Info is the parsed form of a field tag such as `conf:"VAR_NAME,required,secret,desc=Some text"`.
+type Info struct {
+	// VarName is the variable name, or "" if none was specified.
+	VarName string
+	// Required is true if the tag included the "required" option.
+	Required bool
+	// Secret is true if the tag included the "secret" option.
+	Secret bool
+	// Description is the text of the "desc=" option, or "" if there was none.
+	Description string
+}
+
+// Parse parses the value of a "conf" field tag. An empty string is valid and returns a zero Info.
+//
+// The "desc=" option must be the last one, since everything after "desc=" is the description, which
+// may contain commas.
+func Parse(tag string) (Info, error) {
+	ret := Info{}
+	tagStr := strings.TrimSpace(tag)
+	if tagStr == "" {
+		return ret, nil
+	}
+	parts := strings.Split(tagStr, ",")
+	ret.VarName = strings.TrimSpace(parts[0])
+	for i := 1; i < len(parts); i++ {
+		p := strings.TrimSpace(parts[i])
+		switch {
+		case p == "required":
+			ret.Required = true
+		case p == "secret":
+			ret.Secret = true
+		case strings.HasPrefix(p, "desc="):
+			desc := strings.TrimSpace(strings.Join(parts[i:], ","))
+			ret.Description = strings.TrimSpace(strings.TrimPrefix(desc, "desc="))
+			return ret, nil
+		default:
+			return ret, fmt.Errorf("unrecognized field tag option %q", p)
+		}
+	}
+	return ret, nil
+}
@@ -0,0 +1,35 @@
+package conftag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	for tag, expected := range map[string]Info{
+		"":                      {},
+		"  ":                    {},
+		"VAR":                   {VarName: "VAR"},
+		" VAR ":                 {VarName: "VAR"},
+		"VAR,required":          {VarName: "VAR", Required: true},
+		"VAR, required ":        {VarName: "VAR", Required: true},
+		",required":             {Required: true},
+		"VAR,required,required": {VarName: "VAR", Required: true},
+		"VAR,desc=text":         {VarName: "VAR", Description: "text"},
+		"VAR,secret,required":   {VarName: "VAR", Required: true, Secret: true},
+		"VAR,required, desc= a, b=c,required ": {
+			VarName: "VAR", Required: true, Description: "a, b=c,required",
+		},
+		",desc=": {},
+	} {
+		t.Run(tag, func(t *testing.T) {
+			info, err := Parse(tag)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, info)
+		})
+	}
+
+	_, err := Parse("VAR,whatever")
+	assert.EqualError(t, err, `unrecognized field tag option "whatever"`)
+}
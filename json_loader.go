@@ -0,0 +1,271 @@
+package configtypes
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// LoadJSON reads a JSON configuration file into a struct, and then checks the struct with
+// ValidateStruct. The target must be a struct pointer.
+//
+// JSON properties are matched to struct fields in the same way as encoding/json: by the name in the
+// field's "json:" tag if any, or otherwise by the field name, preferring an exact match but accepting a
+// case-insensitive one. Values are decoded with the field type's UnmarshalJSON method if it has one,
+// as all of the Opt types do, so the JSON formats are the same as for encoding/json. However, unlike
+// encoding/json, LoadJSON does not stop at the first invalid value: it reports every field that could
+// not be decoded, and every unknown property if options.DisallowUnknownKeys is set, as a ValidationError
+// whose Path is the path of JSON property names and array indexes, and whose Line and Column are the
+// location of the value in the file. Errors from ValidateStruct, which is called with recursive set to
+// true, are added to the same result; their paths use Go field names.
+//
+// If the data is not valid JSON, or the top-level value is not an object, the result contains only one
+// error and the struct is not validated. Fields that do not appear in the file are not modified, so
+// you can set default values in the struct before calling LoadJSON.
+//
+//	var config Config
+//	file, err := os.Open("config.json")
+//	if err != nil { ... }
+//	defer file.Close()
+//	result := configtypes.LoadJSON(file, &config, configtypes.LoadOptions{Source: "config.json"})
+func LoadJSON(r io.Reader, target interface{}, options LoadOptions) ValidationResult {
+	return loadFile(r, target, options, func(data []byte, refStruct reflect.Value, result *ValidationResult) bool {
+		l := jsonLoader{data: data, options: options, result: result}
+		root, offset, err := parseJSONDocument(data)
+		if err != nil {
+			l.addError(nil, offset, err)
+			return false
+		}
+		if root.kind != jsonNodeObject {
+			l.addError(nil, root.start, errJSONTopLevelNotObject())
+			return false
+		}
+		l.decode(root, refStruct, nil)
+		return true
+	})
+}
+
+type jsonNodeKind int
+
+const (
+	jsonNodeScalar jsonNodeKind = iota
+	jsonNodeObject
+	jsonNodeArray
+)
+
+// jsonNode is a parsed JSON value, with its location in the data.
+type jsonNode struct {
+	kind     jsonNodeKind
+	start    int
+	end      int
+	members  []jsonMember // for an object
+	elements []*jsonNode  // for an array
+}
+
+type jsonMember struct {
+	key      string
+	keyStart int
+	value    *jsonNode
+}
+
+// parseJSONDocument parses a JSON value. If it fails, it returns the offset of the error.
+func parseJSONDocument(data []byte) (*jsonNode, int, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	root, err := parseJSONNode(dec, data)
+	if err == nil {
+		if _, err = dec.Token(); err == io.EOF {
+			return root, 0, nil
+		}
+		if err == nil {
+			return nil, int(dec.InputOffset()) - 1, errJSONTrailingData()
+		}
+	}
+	var se *json.SyntaxError
+	if errors.As(err, &se) {
+		return nil, int(se.Offset) - 1, err // the offset is just after the invalid character
+	}
+	return nil, len(data), errJSONUnexpectedEnd() // io.EOF or io.ErrUnexpectedEOF
+}
+
+func parseJSONNode(dec *json.Decoder, data []byte) (*jsonNode, error) {
+	node := &jsonNode{start: skipJSONSeparators(data, int(dec.InputOffset()))}
+	token, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch token {
+	case json.Delim('{'):
+		node.kind = jsonNodeObject
+		for dec.More() {
+			keyStart := skipJSONSeparators(data, int(dec.InputOffset()))
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := parseJSONNode(dec, data)
+			if err != nil {
+				return nil, err
+			}
+			node.members = append(node.members, jsonMember{key: key.(string), keyStart: keyStart, value: value})
+		}
+	case json.Delim('['):
+		node.kind = jsonNodeArray
+		for dec.More() {
+			value, err := parseJSONNode(dec, data)
+			if err != nil {
+				return nil, err
+			}
+			node.elements = append(node.elements, value)
+		}
+	}
+	if node.kind != jsonNodeScalar {
+		if _, err := dec.Token(); err != nil { // closing delimiter
+			return nil, err
+		}
+	}
+	node.end = int(dec.InputOffset())
+	return node, nil
+}
+
+// skipJSONSeparators finds the start of the next token, since json.Decoder.InputOffset is the end of
+// the previous token.
+func skipJSONSeparators(data []byte, offset int) int {
+	for offset < len(data) && strings.IndexByte(" \t\r\n:,", data[offset]) >= 0 {
+		offset++
+	}
+	return offset
+}
+
+type jsonLoader struct {
+	data    []byte
+	options LoadOptions
+	result  *ValidationResult
+}
+
+func (l *jsonLoader) addError(path ValidationPath, offset int, err error) {
+	line, column := lineAndColumn(l.data, offset)
+	l.result.Add(ValidationError{
+		Path:   append(ValidationPath(nil), path...),
+		Err:    err,
+		Source: l.options.Source,
+		Line:   line,
+		Column: column,
+	})
+}
+
+// decode sets an addressable value from a JSON value.
+func (l *jsonLoader) decode(node *jsonNode, target reflect.Value, path ValidationPath) {
+	raw := l.data[node.start:node.end]
+	if target.Kind() == reflect.Ptr {
+		if string(raw) == "null" {
+			target.Set(reflect.Zero(target.Type()))
+			return
+		}
+		if target.IsNil() {
+			target.Set(reflect.New(target.Type().Elem()))
+		}
+		l.decode(node, target.Elem(), path)
+		return
+	}
+	if u, ok := target.Addr().Interface().(json.Unmarshaler); ok {
+		if err := u.UnmarshalJSON(raw); err != nil {
+			l.addError(path, node.start, err)
+		}
+		return
+	}
+	_, isText := target.Addr().Interface().(encoding.TextUnmarshaler)
+	switch {
+	case isText:
+	case target.Kind() == reflect.Struct && node.kind == jsonNodeObject:
+		l.decodeStruct(node, target, path)
+		return
+	case target.Kind() == reflect.Slice && node.kind == jsonNodeArray:
+		slice := reflect.MakeSlice(target.Type(), len(node.elements), len(node.elements))
+		for i, elem := range node.elements {
+			l.decode(elem, slice.Index(i), append(path, PathIndex(i)))
+		}
+		target.Set(slice)
+		return
+	case target.Kind() == reflect.Map && target.Type().Key().Kind() == reflect.String &&
+		node.kind == jsonNodeObject:
+		if target.IsNil() {
+			target.Set(reflect.MakeMap(target.Type()))
+		}
+		for _, m := range node.members {
+			value := reflect.New(target.Type().Elem()).Elem()
+			l.decode(m.value, value, append(path, PathKey(m.key)))
+			target.SetMapIndex(reflect.ValueOf(m.key).Convert(target.Type().Key()), value)
+		}
+		return
+	}
+	if err := json.Unmarshal(raw, target.Addr().Interface()); err != nil {
+		var te *json.UnmarshalTypeError
+		if errors.As(err, &te) {
+			err = errJSONWrongType(te.Value, te.Type)
+		}
+		l.addError(path, node.start, err)
+	}
+}
+
+func (l *jsonLoader) decodeStruct(node *jsonNode, target reflect.Value, path ValidationPath) {
+	fields := jsonFieldsOfType(target.Type())
+	for _, m := range node.members {
+		memberPath := append(path, PathField(m.key))
+		index, ok := findJSONField(fields, m.key)
+		if !ok {
+			if l.options.DisallowUnknownKeys {
+				l.addError(memberPath, m.keyStart, errUnknownKey())
+			}
+			continue
+		}
+		l.decode(m.value, target.FieldByIndex(index), memberPath)
+	}
+}
+
+type jsonField struct {
+	name  string
+	index []int
+}
+
+// jsonFieldsOfType returns the fields that encoding/json would use for a struct type, including the
+// fields of embedded structs.
+func jsonFieldsOfType(t reflect.Type) []jsonField {
+	var fields, embedded []jsonField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		switch {
+		case name == "-":
+			continue
+		case f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct:
+			for _, ef := range jsonFieldsOfType(f.Type) {
+				embedded = append(embedded, jsonField{name: ef.name, index: append([]int{i}, ef.index...)})
+			}
+			continue
+		case !f.IsExported():
+			continue
+		case name == "":
+			name = f.Name
+		}
+		fields = append(fields, jsonField{name: name, index: []int{i}})
+	}
+	return append(fields, embedded...) // fields of the outer struct take precedence
+}
+
+func findJSONField(fields []jsonField, key string) ([]int, bool) {
+	for _, f := range fields {
+		if f.name == key {
+			return f.index, true
+		}
+	}
+	for _, f := range fields {
+		if strings.EqualFold(f.name, key) {
+			return f.index, true
+		}
+	}
+	return nil, false
+}
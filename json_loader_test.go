@@ -0,0 +1,161 @@
+package configtypes
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testStructForLoader struct {
+	Port     OptIntGreaterThanZero `conf:"PORT"`
+	Timeout  OptDuration           `json:"timeout"`
+	Hosts    OptStringList
+	Name     OptString `conf:",required"`
+	Count    int
+	Limit    *int
+	Server   testStructForLoaderServer `json:"server"`
+	Backup   *testStructForLoaderServer
+	Replicas []testStructForLoaderServer
+	Headers  map[string]string
+	Ignored  string `json:"-"`
+	testStructForLoaderEmbedded
+}
+
+type testStructForLoaderServer struct {
+	Host OptURLAbsolute
+}
+
+type testStructForLoaderEmbedded struct {
+	Region string
+}
+
+func TestLoadJSON(t *testing.T) {
+	t.Run("valid file", func(t *testing.T) {
+		s := testStructForLoader{Count: 1, Ignored: "x"}
+		result := LoadJSON(strings.NewReader(`{
+			"port": 8080,
+			"timeout": "5s",
+			"HOSTS": "a",
+			"Name": "n",
+			"Limit": 3,
+			"server": {"Host": "http://a"},
+			"Backup": {"Host": null},
+			"Replicas": [{"Host": "http://b"}, {}],
+			"Headers": {"X": "y"},
+			"Ignored": "y",
+			"Region": "r",
+			"Unknown": [1, 2]
+		}`), &s, LoadOptions{})
+		assert.True(t, result.OK())
+		limit := 3
+		hostA, _ := NewOptURLAbsoluteFromString("http://a")
+		hostB, _ := NewOptURLAbsoluteFromString("http://b")
+		assert.Equal(t, testStructForLoader{
+			Port:                        mustOptIntGreaterThanZero(8080),
+			Timeout:                     NewOptDuration(5 * time.Second),
+			Hosts:                       NewOptStringList([]string{"a"}),
+			Name:                        NewOptString("n"),
+			Count:                       1,
+			Limit:                       &limit,
+			Server:                      testStructForLoaderServer{Host: hostA},
+			Backup:                      &testStructForLoaderServer{},
+			Replicas:                    []testStructForLoaderServer{{Host: hostB}, {}},
+			Headers:                     map[string]string{"X": "y"},
+			Ignored:                     "x",
+			testStructForLoaderEmbedded: testStructForLoaderEmbedded{Region: "r"},
+		}, s)
+	})
+
+	t.Run("field errors", func(t *testing.T) {
+		var s testStructForLoader
+		result := LoadJSON(strings.NewReader(
+			"{\n"+
+				`  "Port": 0, "Count": "x",`+"\n"+
+				`  "server": {"Host": "not/absolute", "Other": 1},`+"\n"+
+				`  "Replicas": [{}, {"Host": true}],`+"\n"+
+				`  "Unknown": {"a": [1, 2]}, "Name": "é", "Limit": "x"`+"\n"+
+				"}"), &s, LoadOptions{Source: "config.json", DisallowUnknownKeys: true})
+		assert.Equal(t, []ValidationError{
+			{
+				Path: NewValidationPath("Port"), Err: errMustBeGreaterThanZero(),
+				Source: "config.json", Line: 2, Column: 11,
+			},
+			{
+				Path: NewValidationPath("Count"), Err: errJSONWrongType("string", reflect.TypeOf(0)),
+				Source: "config.json", Line: 2, Column: 23,
+			},
+			{
+				Path: NewValidationPath("server", "Host"), Err: errURLNotAbsolute(),
+				Source: "config.json", Line: 3, Column: 22,
+			},
+			{
+				Path: NewValidationPath("server", "Other"), Err: errUnknownKey(),
+				Source: "config.json", Line: 3, Column: 38,
+			},
+			{
+				Path: ValidationPath{PathField("Replicas"), PathIndex(1), PathField("Host")}, Err: errURLFormat(),
+				Source: "config.json", Line: 4, Column: 29,
+			},
+			{Path: NewValidationPath("Unknown"), Err: errUnknownKey(), Source: "config.json", Line: 5, Column: 3},
+			{
+				Path: NewValidationPath("Limit"), Err: errJSONWrongType("string", reflect.TypeOf(0)),
+				Source: "config.json", Line: 5, Column: 51,
+			},
+		}, result.Errors())
+	})
+
+	t.Run("ValidateStruct errors are added", func(t *testing.T) {
+		var s testStructForLoader
+		result := LoadJSON(strings.NewReader(`{"Count": "x"}`), &s, LoadOptions{})
+		assert.Equal(t, []ValidationError{
+			{
+				Path: NewValidationPath("Count"), Err: errJSONWrongType("string", reflect.TypeOf(0)),
+				Line: 1, Column: 11,
+			},
+			{Path: NewValidationPath("Name"), Err: errRequired()},
+		}, result.Errors())
+	})
+
+	t.Run("syntax errors", func(t *testing.T) {
+		for input, expected := range map[string][2]int{
+			"{\n  \"a\": 1,\n  x\n}": {3, 3},
+			`{"a": [1, 2}`:           {1, 12},
+			`{"a": 1`:                {1, 7},
+			`{} {}`:                  {1, 4},
+			``:                       {1, 1},
+		} {
+			t.Run(input, func(t *testing.T) {
+				var s testStructForLoader
+				errs := LoadJSON(strings.NewReader(input), &s, LoadOptions{}).Errors()
+				if assert.Len(t, errs, 1) {
+					assert.Equal(t, expected, [2]int{errs[0].Line, errs[0].Column})
+					assert.NotEmpty(t, errs[0].Err.Error())
+				}
+			})
+		}
+	})
+
+	t.Run("top-level value is not an object", func(t *testing.T) {
+		var s testStructForLoader
+		result := LoadJSON(strings.NewReader(` []`), &s, LoadOptions{})
+		assert.Equal(t, []ValidationError{{Err: errJSONTopLevelNotObject(), Line: 1, Column: 2}}, result.Errors())
+	})
+
+	t.Run("target is not a struct pointer", func(t *testing.T) {
+		var s testStructForLoader
+		result := LoadJSON(strings.NewReader(`{}`), s, LoadOptions{})
+		assert.Equal(t, []ValidationError{{Err: errLoadNonStruct()}}, result.Errors())
+	})
+
+	t.Run("read error", func(t *testing.T) {
+		var s testStructForLoader
+		readErr := errors.New("sorry")
+		result := LoadJSON(iotest.ErrReader(readErr), &s, LoadOptions{Source: "x"})
+		assert.Equal(t, []ValidationError{{Err: readErr, Source: "x"}}, result.Errors())
+	})
+}
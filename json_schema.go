@@ -0,0 +1,357 @@
+package configtypes
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// JSONSchemaDialect is the JSON Schema version used by JSONSchema.
+const JSONSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// These patterns match the strings that are accepted by OptDuration and OptDurationNonNegative,
+// including an empty string which means the value is empty. They are the same as the rules of
+// time.ParseDuration.
+const (
+	durationJSONPattern            = `^([-+]?(0|(([0-9]+(\.[0-9]*)?|\.[0-9]+)(ns|us|µs|μs|ms|s|m|h))+))?$`
+	durationNonNegativeJSONPattern = `^(\+?(0|(([0-9]+(\.[0-9]*)?|\.[0-9]+)(ns|us|µs|μs|ms|s|m|h))+)|-0)?$`
+)
+
+// JSONSchemaOptions specifies optional behavior for JSONSchema.
+type JSONSchemaOptions struct {
+	// ID is the "$id" of the schema, if any.
+	ID string
+	// Title is the "title" of the schema, if any.
+	Title string
+	// DisallowUnknownFields causes all objects in the schema to have "additionalProperties": false.
+	// Otherwise, properties that do not correspond to struct fields are allowed, since they are
+	// ignored by json.Unmarshal.
+	DisallowUnknownFields bool
+}
+
+// JSONSchema generates a JSON Schema (draft 2020-12) that describes the JSON representation of a
+// struct, and returns it as indented JSON. The target can be a struct or a struct pointer.
+//
+// Property names and embedded structs follow the same rules as json.Unmarshal. Each of the Opt types
+// in this package is described according to its documented JSON representation: for instance,
+// OptBool is a boolean or null, and OptIntGreaterThanZero is an integer with a minimum of 1 or null.
+// Pointers, slices, and maps also allow null. Nested struct types are described in "$defs".
+//
+// Field tags with the "required" option, as used by ValidateStruct, cause the property to be listed
+// in "required" and to not allow null. The "desc=" option becomes the property's "description".
+// Secrets, such as OptSecret fields or fields with the "secret" option, are marked as "writeOnly".
+func JSONSchema(target interface{}, options JSONSchemaOptions) ([]byte, error) {
+	refStruct, ok := getReflectValueForStruct(target)
+	if !ok {
+		return nil, errors.New("JSONSchema was called on something other than a struct or struct pointer")
+	}
+	g := jsonSchemaGenerator{
+		options:  options,
+		root:     refStruct.Type(),
+		defNames: make(map[reflect.Type]string),
+		defs:     make(map[string]interface{}),
+	}
+	schema, err := g.objectSchema(refStruct.Type())
+	if err != nil {
+		return nil, err
+	}
+	schema["$schema"] = JSONSchemaDialect
+	if options.ID != "" {
+		schema["$id"] = options.ID
+	}
+	if options.Title != "" {
+		schema["title"] = options.Title
+	}
+	if len(g.defs) != 0 {
+		schema["$defs"] = g.defs
+	}
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+type jsonSchemaGenerator struct {
+	options  JSONSchemaOptions
+	root     reflect.Type
+	defNames map[reflect.Type]string
+	defs     map[string]interface{}
+}
+
+type jsonSchemaObject = map[string]interface{}
+
+func (g *jsonSchemaGenerator) objectSchema(structType reflect.Type) (jsonSchemaObject, error) {
+	properties := make(map[string]interface{})
+	var required []string
+	if err := g.addProperties(structType, properties, &required, nil); err != nil {
+		return nil, err
+	}
+	schema := jsonSchemaObject{"type": "object", "properties": properties}
+	if len(required) != 0 {
+		schema["required"] = required
+	}
+	if g.options.DisallowUnknownFields {
+		schema["additionalProperties"] = false
+	}
+	return schema, nil
+}
+
+// addProperties adds a property for each field that json.Unmarshal would set. Fields of embedded
+// structs are added as if they were in the outer struct, unless the outer struct already has a
+// property with the same name.
+func (g *jsonSchemaGenerator) addProperties(
+	structType reflect.Type,
+	properties map[string]interface{},
+	required *[]string,
+	path ValidationPath,
+) error {
+	var embedded []reflect.StructField
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		name, ok := jsonPropertyName(field)
+		if !ok {
+			continue
+		}
+		if name == "" {
+			embedded = append(embedded, field)
+			continue
+		}
+		if _, exists := properties[name]; exists {
+			continue
+		}
+		fieldPath := path.child(PathField(field.Name))
+		tagInfo, err := getFieldTagInfo(field)
+		if err != nil {
+			return ValidationError{Path: fieldPath, Err: err}
+		}
+		schema, err := g.typeSchema(field.Type, !tagInfo.required)
+		if err != nil {
+			return ValidationError{Path: fieldPath, Err: err}
+		}
+		if tagInfo.description != "" {
+			schema = withJSONSchemaKeyword(schema, "description", tagInfo.description)
+		}
+		if tagInfo.secret {
+			schema = withJSONSchemaKeyword(schema, "writeOnly", true)
+		}
+		properties[name] = schema
+		if tagInfo.required {
+			*required = append(*required, name)
+		}
+	}
+	for _, field := range embedded {
+		t := field.Type
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		embeddedPath := path.child(PathField(field.Name))
+		if err := g.addProperties(t, properties, required, embeddedPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// jsonPropertyName returns the JSON property name for a field, or "" if it is an embedded struct
+// whose fields should be treated as fields of the outer struct, or false if json.Unmarshal ignores
+// the field.
+func jsonPropertyName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	if field.Anonymous && name == "" {
+		t := field.Type
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if isStructType(t) {
+			return "", true
+		}
+	}
+	if !isFieldExported(field) {
+		return "", false
+	}
+	if name == "" {
+		name = field.Name
+	}
+	return name, true
+}
+
+// typeSchema returns the schema for a type. If nullable is false, the schema does not allow null
+// even if json.Unmarshal would accept null for that type.
+func (g *jsonSchemaGenerator) typeSchema(t reflect.Type, nullable bool) (jsonSchemaObject, error) {
+	if schema := optTypeJSONSchema(t); schema != nil {
+		if nullable {
+			return withJSONSchemaNull(schema), nil
+		}
+		return schema, nil
+	}
+	if t.Kind() != reflect.Ptr && t.Kind() != reflect.Interface {
+		if reflect.PointerTo(t).Implements(reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()) {
+			return jsonSchemaObject{}, nil // no way to know what it accepts
+		}
+		if reflect.PointerTo(t).Implements(reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()) {
+			return jsonSchemaObject{"type": "string"}, nil
+		}
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		schema, err := g.typeSchema(t.Elem(), nullable)
+		if err != nil || !nullable {
+			return schema, err
+		}
+		return withJSONSchemaNull(schema), nil
+	case reflect.Struct:
+		return g.structRef(t)
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 && t.Kind() == reflect.Slice {
+			return jsonNullableType("string", nullable), nil // encoded as base64
+		}
+		items, err := g.typeSchema(t.Elem(), true)
+		if err != nil {
+			return nil, err
+		}
+		if t.Kind() == reflect.Array {
+			return jsonSchemaObject{"type": "array", "items": items, "maxItems": t.Len()}, nil
+		}
+		schema := jsonNullableType("array", nullable)
+		schema["items"] = items
+		return schema, nil
+	case reflect.Map:
+		values, err := g.typeSchema(t.Elem(), true)
+		if err != nil {
+			return nil, err
+		}
+		schema := jsonNullableType("object", nullable)
+		schema["additionalProperties"] = values
+		return schema, nil
+	case reflect.Interface:
+		return jsonSchemaObject{}, nil
+	case reflect.Bool:
+		return jsonSchemaObject{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return jsonSchemaObject{"type": "integer"}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return jsonSchemaObject{"type": "integer", "minimum": 0}, nil
+	case reflect.Float32, reflect.Float64:
+		return jsonSchemaObject{"type": "number"}, nil
+	case reflect.String:
+		return jsonSchemaObject{"type": "string"}, nil
+	}
+	return nil, fmt.Errorf("type %s cannot be represented in JSON", t)
+}
+
+// structRef returns a reference to the schema for a struct type, adding it to "$defs" if necessary.
+// The root type is referred to as "#" rather than being added.
+func (g *jsonSchemaGenerator) structRef(t reflect.Type) (jsonSchemaObject, error) {
+	if t == g.root {
+		return jsonSchemaObject{"$ref": "#"}, nil
+	}
+	if t.Name() == "" {
+		return g.objectSchema(t) // anonymous struct types cannot be recursive
+	}
+	if name, ok := g.defNames[t]; ok {
+		return jsonSchemaObject{"$ref": "#/$defs/" + name}, nil
+	}
+	name := t.Name()
+	for i := 2; g.defs[name] != nil; i++ {
+		name = fmt.Sprintf("%s%d", t.Name(), i) // two types from different packages have the same name
+	}
+	g.defNames[t] = name
+	g.defs[name] = jsonSchemaObject{} // placeholder in case the type refers to itself
+	schema, err := g.objectSchema(t)
+	if err != nil {
+		return nil, err
+	}
+	g.defs[name] = schema
+	return jsonSchemaObject{"$ref": "#/$defs/" + name}, nil
+}
+
+// optTypeJSONSchema returns the schema for one of our Opt types, not including null, or nil if it
+// is not an Opt type.
+func optTypeJSONSchema(t reflect.Type) jsonSchemaObject {
+	switch v := reflect.Zero(t).Interface().(type) {
+	case boundedValue:
+		return v.jsonSchema()
+	case OptBool:
+		return jsonSchemaObject{"type": "boolean"}
+	case OptInt:
+		return jsonSchemaObject{"type": "integer"}
+	case OptInt64:
+		return jsonSchemaObject{"type": "integer"}
+	case OptIntGreaterThanZero, OptInt64GreaterThanZero, OptUintGreaterThanZero, OptUint64GreaterThanZero:
+		return jsonSchemaObject{"type": "integer", "minimum": 1}
+	case OptUint, OptUint64:
+		return jsonSchemaObject{"type": "integer", "minimum": 0}
+	case OptFloat64:
+		return jsonSchemaObject{"type": "number"}
+	case OptDuration:
+		return jsonSchemaObject{"type": "string", "pattern": durationJSONPattern}
+	case OptDurationNonNegative:
+		return jsonSchemaObject{"type": "string", "pattern": durationNonNegativeJSONPattern}
+	case OptTime:
+		return jsonSchemaObject{"type": "string", "format": "date-time"}
+	case OptString:
+		return jsonSchemaObject{"type": "string"}
+	case OptStringNonEmpty:
+		return jsonSchemaObject{"type": "string", "minLength": 1}
+	case OptSecret:
+		return jsonSchemaObject{"type": "string"}
+	case ReqSecret:
+		return jsonSchemaObject{"type": "string", "minLength": 1}
+	case OptStringList:
+		return jsonSchemaObject{"type": []string{"string", "array"}, "items": jsonSchemaObject{"type": "string"}}
+	case OptURL:
+		return jsonSchemaObject{"type": "string", "format": "uri-reference"}
+	case OptURLAbsolute:
+		return jsonSchemaObject{"type": "string", "format": "uri"}
+	case OptBase2Bytes:
+		return jsonSchemaObject{"type": "string"}
+	}
+	return nil
+}
+
+func jsonNullableType(typeName string, nullable bool) jsonSchemaObject {
+	if nullable {
+		return jsonSchemaObject{"type": []string{typeName, "null"}}
+	}
+	return jsonSchemaObject{"type": typeName}
+}
+
+// withJSONSchemaNull returns a schema that is the same as the original one but also allows null.
+func withJSONSchemaNull(schema jsonSchemaObject) jsonSchemaObject {
+	ret := make(jsonSchemaObject, len(schema))
+	for k, v := range schema {
+		ret[k] = v
+	}
+	switch t := schema["type"].(type) {
+	case string:
+		ret["type"] = []string{t, "null"}
+	case []string:
+		for _, name := range t {
+			if name == "null" {
+				return ret
+			}
+		}
+		ret["type"] = append(append([]string(nil), t...), "null")
+	default:
+		if len(schema) == 0 {
+			return schema // already allows anything
+		}
+		ret = jsonSchemaObject{"anyOf": []interface{}{schema, jsonSchemaObject{"type": "null"}}}
+	}
+	return ret
+}
+
+// withJSONSchemaKeyword returns a schema that is the same as the original one but with an additional
+// keyword, such as "description".
+func withJSONSchemaKeyword(schema jsonSchemaObject, keyword string, value interface{}) jsonSchemaObject {
+	ret := make(jsonSchemaObject, len(schema)+1)
+	for k, v := range schema {
+		ret[k] = v
+	}
+	ret[keyword] = value
+	return ret
+}
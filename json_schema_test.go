@@ -0,0 +1,213 @@
+package configtypes
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testStructForJSONSchema struct {
+	testStructForJSONSchemaEmbedded
+	Bool        OptBool                `json:"bool" conf:",required"`
+	Int         OptInt                 `json:"int,omitempty"`
+	IntPositive OptIntGreaterThanZero  `json:"intPositive" conf:"INT,desc=must be positive"`
+	Float       OptFloat64             `json:"float"`
+	Duration    OptDuration            `json:"duration"`
+	NonNegative OptDurationNonNegative `json:"nonNegative"`
+	String      OptString              `json:"string"`
+	NonEmpty    OptStringNonEmpty      `json:"nonEmpty"`
+	List        OptStringList          `json:"list"`
+	URL         OptURL                 `json:"url"`
+	AbsURL      OptURLAbsolute         `json:"absURL"`
+	Bytes       OptBase2Bytes          `json:"bytes"`
+	Time        OptTime                `json:"time"`
+	Ignored     string                 `json:"-"`
+	NoJSONTag   string
+	unexported  string
+}
+
+type testStructForJSONSchemaEmbedded struct {
+	Embedded string `json:"embedded"`
+	Bool     int    `json:"bool"` // hidden by the outer struct's field
+}
+
+type testStructForJSONSchemaNesting struct {
+	Nested    testStructForJSONSchemaLeaf   `json:"nested"`
+	Pointer   *testStructForJSONSchemaLeaf  `json:"pointer" conf:",required"`
+	Slice     []testStructForJSONSchemaLeaf `json:"slice"`
+	Map       map[string]*int               `json:"map"`
+	Array     [2]bool                       `json:"array"`
+	Next      *testStructForJSONSchemaNesting
+	Anonymous struct {
+		A uint `json:"a"`
+	} `json:"anonymous"`
+}
+
+type testStructForJSONSchemaLeaf struct {
+	Name OptString `json:"name" conf:"NAME,required"`
+}
+
+func TestJSONSchema(t *testing.T) {
+	t.Run("Opt types and primitives", func(t *testing.T) {
+		schema, err := JSONSchema(testStructForJSONSchema{}, JSONSchemaOptions{ID: "urn:test", Title: "Test"})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"$id": "urn:test",
+			"title": "Test",
+			"type": "object",
+			"properties": {
+				"embedded": {"type": "string"},
+				"bool": {"type": "boolean"},
+				"int": {"type": ["integer", "null"]},
+				"intPositive": {"type": ["integer", "null"], "minimum": 1, "description": "must be positive"},
+				"float": {"type": ["number", "null"]},
+				"duration": {"type": ["string", "null"], "pattern": `+jsonString(durationJSONPattern)+`},
+				"nonNegative": {"type": ["string", "null"], "pattern": `+jsonString(durationNonNegativeJSONPattern)+`},
+				"string": {"type": ["string", "null"]},
+				"nonEmpty": {"type": ["string", "null"], "minLength": 1},
+				"list": {"type": ["string", "array", "null"], "items": {"type": "string"}},
+				"url": {"type": ["string", "null"], "format": "uri-reference"},
+				"absURL": {"type": ["string", "null"], "format": "uri"},
+				"bytes": {"type": ["string", "null"]},
+				"time": {"type": ["string", "null"], "format": "date-time"},
+				"NoJSONTag": {"type": "string"}
+			},
+			"required": ["bool"]
+		}`, string(schema))
+	})
+
+	t.Run("bounded types", func(t *testing.T) {
+		var s struct {
+			Int      OptIntBetween[testIntBounds]           `json:"int"`
+			Int64    OptInt64Between[testInt64Bounds]       `json:"int64"`
+			Float    OptFloat64Between[testFloat64Bounds]   `json:"float" conf:",required"`
+			Duration OptDurationBetween[testDurationBounds] `json:"duration"`
+		}
+		schema, err := JSONSchema(&s, JSONSchemaOptions{})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"type": "object",
+			"properties": {
+				"int": {"type": ["integer", "null"], "minimum": 1, "maximum": 10},
+				"int64": {"type": ["integer", "null"], "exclusiveMinimum": 0},
+				"float": {"type": "number", "minimum": 0, "exclusiveMaximum": 1},
+				"duration": {"type": ["string", "null"], "pattern": `+jsonString(durationJSONPattern)+`}
+			},
+			"required": ["float"]
+		}`, string(schema))
+	})
+
+	t.Run("integer types", func(t *testing.T) {
+		var s struct {
+			Int64     OptInt64                 `json:"int64"`
+			Int64Pos  OptInt64GreaterThanZero  `json:"int64Pos"`
+			Uint      OptUint                  `json:"uint"`
+			UintPos   OptUintGreaterThanZero   `json:"uintPos"`
+			Uint64    OptUint64                `json:"uint64" conf:",required"`
+			Uint64Pos OptUint64GreaterThanZero `json:"uint64Pos"`
+		}
+		schema, err := JSONSchema(&s, JSONSchemaOptions{})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"type": "object",
+			"properties": {
+				"int64": {"type": ["integer", "null"]},
+				"int64Pos": {"type": ["integer", "null"], "minimum": 1},
+				"uint": {"type": ["integer", "null"], "minimum": 0},
+				"uintPos": {"type": ["integer", "null"], "minimum": 1},
+				"uint64": {"type": "integer", "minimum": 0},
+				"uint64Pos": {"type": ["integer", "null"], "minimum": 1}
+			},
+			"required": ["uint64"]
+		}`, string(schema))
+	})
+
+	t.Run("nested structs and collections", func(t *testing.T) {
+		schema, err := JSONSchema(&testStructForJSONSchemaNesting{}, JSONSchemaOptions{DisallowUnknownFields: true})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"type": "object",
+			"additionalProperties": false,
+			"properties": {
+				"nested": {"$ref": "#/$defs/testStructForJSONSchemaLeaf"},
+				"pointer": {"$ref": "#/$defs/testStructForJSONSchemaLeaf"},
+				"slice": {"type": ["array", "null"], "items": {"$ref": "#/$defs/testStructForJSONSchemaLeaf"}},
+				"map": {"type": ["object", "null"], "additionalProperties": {"type": ["integer", "null"]}},
+				"array": {"type": "array", "items": {"type": "boolean"}, "maxItems": 2},
+				"Next": {"anyOf": [{"$ref": "#"}, {"type": "null"}]},
+				"anonymous": {
+					"type": "object",
+					"additionalProperties": false,
+					"properties": {"a": {"type": "integer", "minimum": 0}}
+				}
+			},
+			"required": ["pointer"],
+			"$defs": {
+				"testStructForJSONSchemaLeaf": {
+					"type": "object",
+					"additionalProperties": false,
+					"properties": {"name": {"type": "string"}},
+					"required": ["name"]
+				}
+			}
+		}`, string(schema))
+	})
+
+	t.Run("secrets", func(t *testing.T) {
+		schema, err := JSONSchema(struct {
+			Key      ReqSecret `json:"key"`
+			Password OptSecret `json:"password"`
+			Token    string    `json:"token" conf:"TOKEN,secret"`
+		}{}, JSONSchemaOptions{})
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"type": "object",
+			"properties": {
+				"key": {"type": "string", "minLength": 1, "writeOnly": true},
+				"password": {"type": ["string", "null"], "writeOnly": true},
+				"token": {"type": "string", "writeOnly": true}
+			},
+			"required": ["key"]
+		}`, string(schema))
+	})
+
+	t.Run("unsupported field type", func(t *testing.T) {
+		_, err := JSONSchema(struct{ F func() }{}, JSONSchemaOptions{})
+		assert.EqualError(t, err, "F: type func() cannot be represented in JSON")
+	})
+
+	t.Run("bad tag", func(t *testing.T) {
+		_, err := JSONSchema(testStructWithBadTag{}, JSONSchemaOptions{})
+		assert.EqualError(t, err, `F1: unrecognized field tag option "whatever"`)
+	})
+
+	t.Run("not a struct", func(t *testing.T) {
+		_, err := JSONSchema("x", JSONSchemaOptions{})
+		assert.Error(t, err)
+	})
+}
+
+func TestJSONSchemaDurationPatterns(t *testing.T) {
+	for _, s := range []string{"", "0", "-0", "1s", "+1s", "-1s", "1.5h", ".5m", "1h2m3.5s", "3us", "3µs", "x", "1", "1d", "1.s"} {
+		_, err := NewOptDurationFromString(s)
+		assert.Equal(t, err == nil, regexp.MustCompile(durationJSONPattern).MatchString(s), s)
+		_, err = NewOptDurationNonNegativeFromString(s)
+		assert.Equal(t, err == nil, regexp.MustCompile(durationNonNegativeJSONPattern).MatchString(s), s)
+	}
+	_, err := time.ParseDuration("1.s")
+	assert.NoError(t, err) // make sure the test covers this odd but valid case
+}
+
+func jsonString(s string) string {
+	data, _ := json.Marshal(s)
+	return string(data)
+}
@@ -0,0 +1,163 @@
+package configtypes
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+)
+
+// LDFlagVarSourceOptions specifies optional behavior for LDFlagVarSource.
+type LDFlagVarSourceOptions struct {
+	// OnInvalid, if not nil, is called for each flag whose value is skipped because it is not valid
+	// for the field. The ValidationError has the field's Path and VarName, and its Source is
+	// "feature flags".
+	OnInvalid func(ValidationError)
+}
+
+type ldFlagVarSource struct {
+	target    interface{}
+	recursive bool
+	lookup    func(key string) (ldvalue.Value, bool)
+	options   LDFlagVarSourceOptions
+}
+
+// LDFlagVarSource returns a VarSource that reads variables from LaunchDarkly feature flags. Its name is
+// "feature flags".
+//
+// The target is a struct or struct pointer that determines which variables are read: there is one for
+// each field that VarReader.ReadStruct would read, found with the same field tag logic as DescribeVars;
+// if recursive is true, nested struct fields and non-nil struct pointer fields are included. The flag
+// key for each variable is derived from the variable name in the same way as the flag name in
+// BindFlags, so the variable HTTP_READ_TIMEOUT is read from the flag "http-read-timeout".
+//
+// The lookup function returns the value of a flag and true, or false if the flag does not exist. With
+// a LaunchDarkly SDK, it would typically call JSONVariationDetail and return false if the evaluation
+// failed; in tests, it can be a stub. It is called for every variable each time Vars is called, so
+// a Watcher that uses this source will see changes to the flags.
+//
+// Each flag value is converted with the same rules as UnmarshalJSON for the field's type, which
+// includes the type's validation: for instance, the value for an OptDuration field must be a string
+// such as "5s", and the value for an OptIntGreaterThanZero field must be a number greater than zero.
+// Since the values are passed to VarReader as text, a value is also not valid if its text would not
+// be parsed as the same value: for instance, an OptStringList value of ["a,b"] is not valid, because
+// its text "a,b" would be parsed as ["a", "b"].
+//
+// A flag that does not exist, whose value is null, or whose value is not valid for the field is
+// skipped, so that the value from an earlier source in the list passed to NewVarReaderFromSources,
+// or else the field's current value, is used instead. Invalid values are reported to
+// LDFlagVarSourceOptions.OnInvalid. To let flags override environment variables:
+//
+//	source := configtypes.LDFlagVarSource(&config, true, func(key string) (ldvalue.Value, bool) {
+//	    value, detail, err := client.JSONVariationDetail(key, context, ldvalue.Null())
+//	    return value, err == nil && !detail.IsDefaultValue()
+//	}, configtypes.LDFlagVarSourceOptions{
+//	    OnInvalid: func(e configtypes.ValidationError) { log.Println(e) },
+//	})
+//	r, err := configtypes.NewVarReaderFromSources(configtypes.EnvironmentVarSource(), source)
+//
+// Vars returns an error if the target is not a struct or struct pointer, or if any field tag is
+// invalid.
+func LDFlagVarSource(
+	target interface{},
+	recursive bool,
+	lookup func(key string) (ldvalue.Value, bool),
+	options LDFlagVarSourceOptions,
+) VarSource {
+	return ldFlagVarSource{target: target, recursive: recursive, lookup: lookup, options: options}
+}
+
+func (s ldFlagVarSource) Name() string {
+	return "feature flags"
+}
+
+func (s ldFlagVarSource) Vars() (map[string]string, error) {
+	refStruct, ok := getReflectValueForStruct(s.target)
+	if !ok {
+		return nil, errors.New("LDFlagVarSource was called with something other than a struct or struct pointer")
+	}
+	c := ldFlagVarCollector{source: s, values: make(map[string]string)}
+	if err := c.collectFields(refStruct, nil); err != nil {
+		return nil, err
+	}
+	return c.values, nil
+}
+
+type ldFlagVarCollector struct {
+	source  ldFlagVarSource
+	visited visitSet
+	values  map[string]string
+}
+
+func (c *ldFlagVarCollector) collectFields(refStruct reflect.Value, path ValidationPath) error {
+	for _, field := range getStructPlan(refStruct.Type()).fields {
+		fieldPath := path.child(PathField(field.name))
+		if field.tagErr != nil {
+			return ValidationError{Path: fieldPath, Err: field.tagErr}
+		}
+		fieldInInstance := refStruct.Field(field.index)
+		if field.tagInfo.varName == "" {
+			if c.source.recursive && field.kind == fieldKindStruct {
+				err := walkNestedStruct(fieldInInstance, &c.visited, func(refStruct reflect.Value) error {
+					return c.collectFields(refStruct, fieldPath)
+				})
+				if err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		fieldType := fieldInInstance.Type()
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if setterForTarget(reflect.New(fieldType).Interface()) == nil {
+			continue // VarReader will report the unsupported type
+		}
+		value, found := c.source.lookup(flagNameForVar(field.tagInfo.varName))
+		if !found || value.IsNull() {
+			continue
+		}
+		text, err := ldFlagValueAsVarText(value, fieldType)
+		if err != nil {
+			if c.source.options.OnInvalid != nil {
+				c.source.options.OnInvalid(ValidationError{
+					Path: fieldPath, Err: err, VarName: field.tagInfo.varName, Source: c.source.Name(),
+				})
+			}
+			continue
+		}
+		c.values[field.tagInfo.varName] = text
+	}
+	return nil
+}
+
+// ldFlagValueAsVarText converts a flag value to a value of the field's type with the JSON rules for
+// that type, and then to the text form that VarReader will parse as the same value. It returns an
+// error if the flag value is not valid for the field, or if no such text exists.
+func ldFlagValueAsVarText(value ldvalue.Value, fieldType reflect.Type) (string, error) {
+	target := reflect.New(fieldType)
+	if err := json.Unmarshal([]byte(value.JSONString()), target.Interface()); err != nil {
+		return "", err
+	}
+	var text string
+	switch v := target.Interface().(type) {
+	case SecretValue:
+		text = v.Reveal()
+	case encoding.TextMarshaler:
+		data, err := v.MarshalText()
+		if err != nil {
+			return "", err
+		}
+		text = string(data)
+	default:
+		text = fmt.Sprint(target.Elem().Interface())
+	}
+	if !textParsesAsSameValue(target.Elem().Interface(), []byte(text)) {
+		return "", errLDFlagValueNotRepresentable()
+	}
+	return text, nil
+}
@@ -0,0 +1,138 @@
+package configtypes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testStructForLDFlags struct {
+	Port     OptIntGreaterThanZero `conf:"PORT"`
+	Timeout  OptDuration           `conf:"HTTP_READ_TIMEOUT"`
+	Hosts    OptStringList         `conf:"HOSTS"`
+	Password OptSecret             `conf:"PASSWORD"`
+	Debug    bool                  `conf:"DEBUG"`
+	Count    *int                  `conf:"COUNT"`
+	Ratio    float64               `conf:"RATIO"`
+	Label    string                `conf:"LABEL"`
+	Server   testStructForLDFlagsServer
+	Backup   *testStructForLDFlagsServer
+}
+
+type testStructForLDFlagsServer struct {
+	Host OptURLAbsolute `conf:"SERVER_HOST"`
+}
+
+func testLDFlagLookup(flags map[string]ldvalue.Value) func(string) (ldvalue.Value, bool) {
+	return func(key string) (ldvalue.Value, bool) {
+		value, ok := flags[key]
+		return value, ok
+	}
+}
+
+func TestLDFlagVarSource(t *testing.T) {
+	t.Run("converts flag values", func(t *testing.T) {
+		source := LDFlagVarSource(&testStructForLDFlags{}, true, testLDFlagLookup(map[string]ldvalue.Value{
+			"port":              ldvalue.Int(8080),
+			"http-read-timeout": ldvalue.String("5s"),
+			"hosts":             ldvalue.ArrayOf(ldvalue.String("a"), ldvalue.String("b")),
+			"password":          ldvalue.String(testLongSecret),
+			"debug":             ldvalue.Bool(true),
+			"count":             ldvalue.Int(3),
+			"ratio":             ldvalue.Float64(0.5),
+			"label":             ldvalue.String("x"),
+			"server-host":       ldvalue.String("http://a"),
+			"other":             ldvalue.Int(1),
+		}), LDFlagVarSourceOptions{})
+		assert.Equal(t, "feature flags", source.Name())
+		values, err := source.Vars()
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"PORT":              "8080",
+			"HTTP_READ_TIMEOUT": "5s",
+			"HOSTS":             "a,b",
+			"PASSWORD":          testLongSecret,
+			"DEBUG":             "true",
+			"COUNT":             "3",
+			"RATIO":             "0.5",
+			"LABEL":             "x",
+			"SERVER_HOST":       "http://a",
+		}, values)
+	})
+
+	t.Run("skips missing, null, and invalid values", func(t *testing.T) {
+		var invalid []ValidationError
+		source := LDFlagVarSource(testStructForLDFlags{}, true, testLDFlagLookup(map[string]ldvalue.Value{
+			"port":              ldvalue.Int(0),
+			"http-read-timeout": ldvalue.Int(5),
+			"hosts":             ldvalue.Null(),
+			"debug":             ldvalue.String("true"),
+			"count":             ldvalue.Float64(1.5),
+			"server-host":       ldvalue.String("/relative"),
+		}), LDFlagVarSourceOptions{OnInvalid: func(e ValidationError) { invalid = append(invalid, e) }})
+		values, err := source.Vars()
+		require.NoError(t, err)
+		assert.Empty(t, values)
+
+		require.Len(t, invalid, 5)
+		assert.Equal(t, ValidationError{Path: NewValidationPath("Port"), Err: errMustBeGreaterThanZero(),
+			VarName: "PORT", Source: "feature flags"}, invalid[0])
+		assert.Equal(t, NewValidationPath("Server", "Host"), invalid[4].Path)
+		assert.Equal(t, errURLNotAbsolute(), invalid[4].Err)
+	})
+
+	t.Run("skips values whose text would be parsed as a different value", func(t *testing.T) {
+		var invalid []ValidationError
+		source := LDFlagVarSource(&testStructForLDFlags{}, true, testLDFlagLookup(map[string]ldvalue.Value{
+			"hosts": ldvalue.ArrayOf(ldvalue.String("a,b")),
+			"label": ldvalue.String("x"),
+		}), LDFlagVarSourceOptions{OnInvalid: func(e ValidationError) { invalid = append(invalid, e) }})
+		values, err := source.Vars()
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"LABEL": "x"}, values)
+		assert.Equal(t, []ValidationError{{Path: NewValidationPath("Hosts"), Err: errLDFlagValueNotRepresentable(),
+			VarName: "HOSTS", Source: "feature flags"}}, invalid)
+	})
+
+	t.Run("nested fields are only read if recursive", func(t *testing.T) {
+		lookup := testLDFlagLookup(map[string]ldvalue.Value{"server-host": ldvalue.String("http://a")})
+		values, err := LDFlagVarSource(&testStructForLDFlags{}, false, lookup, LDFlagVarSourceOptions{}).Vars()
+		require.NoError(t, err)
+		assert.Empty(t, values)
+	})
+
+	t.Run("falls back to earlier sources", func(t *testing.T) {
+		t.Setenv("PORT", "9000")
+		t.Setenv("HTTP_READ_TIMEOUT", "1s")
+		config := testStructForLDFlags{Label: "default"}
+		source := LDFlagVarSource(&config, true, testLDFlagLookup(map[string]ldvalue.Value{
+			"port":              ldvalue.Int(-1),
+			"http-read-timeout": ldvalue.String("5s"),
+			"label":             ldvalue.Bool(true),
+			"password":          ldvalue.String(testLongSecret),
+		}), LDFlagVarSourceOptions{})
+		r, err := NewVarReaderFromSources(EnvironmentVarSource(), source)
+		require.NoError(t, err)
+		r.ReadStruct(&config, true)
+		require.True(t, r.Result().OK(), r.Result().Errors())
+		assert.Equal(t, mustOptIntGreaterThanZero(9000), config.Port)
+		assert.Equal(t, NewOptDuration(5*time.Second), config.Timeout)
+		assert.Equal(t, "default", config.Label)
+		assert.Equal(t, NewOptSecret(testLongSecret), config.Password)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		lookup := testLDFlagLookup(nil)
+		_, err := LDFlagVarSource(3, false, lookup, LDFlagVarSourceOptions{}).Vars()
+		assert.Error(t, err)
+
+		var badTag struct {
+			X OptInt `conf:",bad"`
+		}
+		_, err = LDFlagVarSource(&badTag, false, lookup, LDFlagVarSourceOptions{}).Vars()
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,77 @@
+package configtypes
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+)
+
+// LoadOptions specifies optional behavior for functions that load a configuration file into a struct,
+// such as LoadJSON.
+type LoadOptions struct {
+	// Source is used as the Source of every error, such as the path of the file.
+	Source string
+	// DisallowUnknownKeys causes an error to be reported for every key in the file that does not
+	// correspond to a struct field. By default, such keys are ignored.
+	DisallowUnknownKeys bool
+}
+
+// loadFile does the parts of loading a file that are the same for every format: reading the data,
+// checking the target type, and calling ValidateStruct if the data was parsed successfully. The decode
+// function returns false if the data could not be parsed at all.
+func loadFile(
+	r io.Reader,
+	target interface{},
+	options LoadOptions,
+	decode func(data []byte, refStruct reflect.Value, result *ValidationResult) bool,
+) ValidationResult {
+	var result ValidationResult
+	refStruct, ok := getReflectValueForStructPtr(target)
+	if !ok {
+		result.AddError(nil, errLoadNonStruct())
+		return result
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		result.Add(ValidationError{Err: err, Source: options.Source})
+		return result
+	}
+	if decode(data, refStruct, &result) {
+		result.AddAll(nil, ValidateStruct(target, true))
+	}
+	return result
+}
+
+// lineAndColumn converts a byte offset in a file to a line and column, both starting at 1. The column
+// is counted in characters rather than bytes.
+func lineAndColumn(data []byte, offset int) (int, int) {
+	if offset > len(data) {
+		offset = len(data)
+	}
+	before := data[:offset]
+	lineStart := bytes.LastIndexByte(before, '\n') + 1
+	return bytes.Count(before, []byte{'\n'}) + 1, utf8.RuneCount(before[lineStart:]) + 1
+}
+
+// findFieldForKey finds the field of a struct that corresponds to a key in a configuration file, for
+// file formats that use "conf:" tags rather than format-specific tags. The key can match either the
+// field name or the variable name in the tag, ignoring case and treating "-" and "_" as the same.
+func findFieldForKey(structType reflect.Type, name string) (fieldPlan, bool) {
+	normalized := normalizeKeyName(name)
+	for _, f := range getStructPlan(structType).fields {
+		if f.tagErr != nil {
+			continue // ValidateStruct will report this
+		}
+		if normalizeKeyName(f.name) == normalized ||
+			(f.tagInfo.varName != "" && normalizeKeyName(f.tagInfo.varName) == normalized) {
+			return f, true
+		}
+	}
+	return fieldPlan{}, false
+}
+
+func normalizeKeyName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "-", "_"))
+}
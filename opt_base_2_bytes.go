@@ -1,11 +1,13 @@
 package configtypes
 
 import (
+	"database/sql/driver"
 	"encoding/json"
 
 	"github.com/alecthomas/units"
 
 	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"gopkg.in/yaml.v3"
 )
 
 // OptBase2Bytes represents an optional parameter which, if present, must be a
@@ -43,6 +45,18 @@ func NewOptBase2BytesFromString(sizeAsString string) (OptBase2Bytes, error) {
 	return OptBase2Bytes{}, errBase2BytesFormat()
 }
 
+// NewOptBase2BytesFromLDValue converts an ldvalue.Value to OptBase2Bytes, with the same rules as UnmarshalJSON.
+func NewOptBase2BytesFromLDValue(v ldvalue.Value) (OptBase2Bytes, error) {
+	switch {
+	case v.IsNull():
+		return OptBase2Bytes{}, nil
+	case v.IsString():
+		return NewOptBase2BytesFromString(v.StringValue())
+	default:
+		return OptBase2Bytes{}, errBase2BytesFormat()
+	}
+}
+
 func (o OptBase2Bytes) IsDefined() bool {
 	return o.hasValue
 }
@@ -84,6 +98,21 @@ func (o *OptBase2Bytes) UnmarshalText(data []byte) error {
 	return err
 }
 
+// Set is the same as UnmarshalText. It allows OptBase2Bytes to be used as a flag.Value. It cannot be used as
+// a flag.Getter, because its Get method returns a units.Base2Bytes; a flag registered by BindFlags is
+// a flag.Getter for any field type.
+func (o *OptBase2Bytes) Set(s string) error {
+	return o.UnmarshalText([]byte(s))
+}
+
+// AsLDValue converts the value to an ldvalue.Value, with the same rules as MarshalJSON.
+func (o OptBase2Bytes) AsLDValue() ldvalue.Value {
+	if !o.IsDefined() {
+		return ldvalue.Null()
+	}
+	return ldvalue.String(o.size.String())
+}
+
 func (o OptBase2Bytes) MarshalJSON() ([]byte, error) {
 	if o.IsDefined() {
 		return json.Marshal(o.size.String())
@@ -93,18 +122,78 @@ func (o OptBase2Bytes) MarshalJSON() ([]byte, error) {
 
 func (o *OptBase2Bytes) UnmarshalJSON(data []byte) error {
 	var v ldvalue.Value
-	var err error
-	if err = v.UnmarshalJSON(data); err != nil {
+	if err := v.UnmarshalJSON(data); err != nil {
 		return err
 	}
-	switch {
-	case v.IsNull():
+	opt, err := NewOptBase2BytesFromLDValue(v)
+	if err == nil || v.IsString() {
+		*o = opt // a string that cannot be parsed leaves the value empty
+	}
+	return err
+}
+
+// MarshalYAML converts this type to a YAML value, with the same rules as MarshalJSON.
+func (o OptBase2Bytes) MarshalYAML() (interface{}, error) {
+	if o.IsDefined() {
+		return o.size.String(), nil
+	}
+	return nil, nil //nolint:nilnil
+}
+
+// UnmarshalYAML converts a YAML value to this type, with the same rules as UnmarshalJSON.
+func (o *OptBase2Bytes) UnmarshalYAML(node *yaml.Node) error {
+	return unmarshalYAMLAsJSON(node, o)
+}
+
+// Value implements driver.Valuer, so that the value can be written to a database with database/sql.
+// An empty value is NULL; otherwise it is an int64 count of bytes.
+func (o OptBase2Bytes) Value() (driver.Value, error) {
+	if !o.IsDefined() {
+		return nil, nil //nolint:nilnil
+	}
+	return int64(o.size), nil
+}
+
+// Scan implements sql.Scanner, so that the value can be read from a database with database/sql. NULL
+// becomes an empty value; an integer count of bytes, or a string in the same format as
+// UnmarshalText, is also allowed.
+func (o *OptBase2Bytes) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
 		*o = OptBase2Bytes{}
 		return nil
-	case v.IsString():
-		*o, err = NewOptBase2BytesFromString(v.StringValue())
+	case int64:
+		*o = NewOptBase2Bytes(units.Base2Bytes(v))
+		return nil
+	}
+	return scanSQLText(src, o)
+}
+
+// MarshalBinary converts the value to the binary format described in the package documentation.
+func (o OptBase2Bytes) MarshalBinary() ([]byte, error) {
+	return marshalOptBinaryVarint(o.hasValue, int64(o.size)), nil
+}
+
+// UnmarshalBinary converts data produced by MarshalBinary, with the same validation as UnmarshalText.
+func (o *OptBase2Bytes) UnmarshalBinary(data []byte) error {
+	value, defined, err := unmarshalOptBinaryVarint(data)
+	switch {
+	case err != nil:
 		return err
+	case !defined:
+		*o = OptBase2Bytes{}
 	default:
-		return errBase2BytesFormat()
+		*o = NewOptBase2Bytes(units.Base2Bytes(value))
 	}
+	return nil
+}
+
+// GobEncode is the same as MarshalBinary. It allows OptBase2Bytes to be used with encoding/gob.
+func (o OptBase2Bytes) GobEncode() ([]byte, error) {
+	return o.MarshalBinary()
+}
+
+// GobDecode is the same as UnmarshalBinary. It allows OptBase2Bytes to be used with encoding/gob.
+func (o *OptBase2Bytes) GobDecode(data []byte) error {
+	return o.UnmarshalBinary(data)
 }
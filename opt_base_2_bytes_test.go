@@ -67,4 +67,7 @@ func TestOptBase2Bytes(t *testing.T) {
 
 	assertConvertFromJSONFails(t, &OptBase2Bytes{},
 		`true`, `0.5`, quoteJSONString(malformedSizeString), `[]`, `{}`)
+
+	assertUnmarshalJSONClearsForInvalidString(t, &OptBase2Bytes{}, NewOptBase2Bytes(gigBytes),
+		quoteJSONString(malformedSizeString))
 }
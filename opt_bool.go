@@ -1,10 +1,12 @@
 package configtypes
 
 import (
+	"database/sql/driver"
 	"encoding/json"
 	"strings"
 
 	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"gopkg.in/yaml.v3"
 )
 
 // OptBool represents an optional boolean parameter.
@@ -43,6 +45,18 @@ func NewOptBoolFromString(s string) (OptBool, error) {
 	return OptBool{}, errBoolFormat()
 }
 
+// NewOptBoolFromLDValue converts an ldvalue.Value to OptBool, with the same rules as UnmarshalJSON.
+func NewOptBoolFromLDValue(v ldvalue.Value) (OptBool, error) {
+	switch {
+	case v.IsNull():
+		return OptBool{}, nil
+	case v.IsBool():
+		return NewOptBool(v.BoolValue()), nil
+	default:
+		return OptBool{}, errBoolFormat()
+	}
+}
+
 func (o OptBool) IsDefined() bool {
 	return o.v.IsDefined()
 }
@@ -74,6 +88,34 @@ func (o *OptBool) UnmarshalText(data []byte) error {
 	return err
 }
 
+// Set is the same as UnmarshalText. It allows OptBool to be used as a flag.Value.
+func (o *OptBool) Set(s string) error {
+	return o.UnmarshalText([]byte(s))
+}
+
+// Get returns the value as a bool, or nil if it is not defined. It allows OptBool to be used as a
+// flag.Getter.
+func (o OptBool) Get() interface{} {
+	if !o.IsDefined() {
+		return nil
+	}
+	return o.GetOrElse(false)
+}
+
+// IsBoolFlag returns true, so that if an OptBool is used as a flag.Value, the flag can be given
+// without a value to mean true.
+func (o *OptBool) IsBoolFlag() bool {
+	return true
+}
+
+// AsLDValue converts the value to an ldvalue.Value, with the same rules as MarshalJSON.
+func (o OptBool) AsLDValue() ldvalue.Value {
+	if !o.IsDefined() {
+		return ldvalue.Null()
+	}
+	return ldvalue.Bool(o.v.BoolValue())
+}
+
 func (o OptBool) MarshalJSON() ([]byte, error) {
 	if o.IsDefined() {
 		return json.Marshal(o.v.BoolValue())
@@ -86,16 +128,86 @@ func (o *OptBool) UnmarshalJSON(data []byte) error {
 	if err := v.UnmarshalJSON(data); err != nil {
 		return err
 	}
-	switch {
-	case v.IsNull():
+	opt, err := NewOptBoolFromLDValue(v)
+	if err == nil {
+		*o = opt
+	}
+	return err
+}
+
+// MarshalYAML converts this type to a YAML value, with the same rules as MarshalJSON.
+func (o OptBool) MarshalYAML() (interface{}, error) {
+	if o.IsDefined() {
+		return o.v.BoolValue(), nil
+	}
+	return nil, nil //nolint:nilnil
+}
+
+// UnmarshalYAML converts a YAML value to this type, with the same rules as UnmarshalJSON.
+func (o *OptBool) UnmarshalYAML(node *yaml.Node) error {
+	return unmarshalYAMLAsJSON(node, o)
+}
+
+// Value implements driver.Valuer, so that the value can be written to a database with database/sql.
+// An empty value is NULL; otherwise it is a bool.
+func (o OptBool) Value() (driver.Value, error) {
+	if !o.IsDefined() {
+		return nil, nil //nolint:nilnil
+	}
+	return o.GetOrElse(false), nil
+}
+
+// Scan implements sql.Scanner, so that the value can be read from a database with database/sql. NULL
+// becomes an empty value; a bool, the integer 0 or 1, or a string in the same format as UnmarshalText
+// is also allowed.
+func (o *OptBool) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
 		*o = OptBool{}
-	case v.IsBool():
-		*o = NewOptBool(v.BoolValue())
-	default:
-		if v.IsString() {
+		return nil
+	case bool:
+		*o = NewOptBool(v)
+		return nil
+	case int64:
+		if v != 0 && v != 1 {
 			return errBoolFormat()
 		}
-		return errBoolFormat()
+		*o = NewOptBool(v == 1)
+		return nil
+	}
+	return scanSQLText(src, o)
+}
+
+// MarshalBinary converts the value to the binary format described in the package documentation.
+func (o OptBool) MarshalBinary() ([]byte, error) {
+	if o.GetOrElse(false) {
+		return marshalOptBinary(true, []byte{1}), nil
+	}
+	return marshalOptBinary(o.IsDefined(), []byte{0}), nil
+}
+
+// UnmarshalBinary converts data produced by MarshalBinary, with the same validation as UnmarshalText.
+func (o *OptBool) UnmarshalBinary(data []byte) error {
+	payload, defined, err := unmarshalOptBinary(data)
+	switch {
+	case err != nil:
+		return err
+	case !defined:
+		*o = OptBool{}
+	case len(payload) == 1 && payload[0] <= 1:
+		*o = NewOptBool(payload[0] == 1)
+	default:
+		return errBinaryFormat()
 	}
 	return nil
 }
+
+// GobEncode is the same as MarshalBinary. It allows OptBool to be used with encoding/gob.
+func (o OptBool) GobEncode() ([]byte, error) {
+	return o.MarshalBinary()
+}
+
+// GobDecode is the same as UnmarshalBinary. It allows OptBool to be used with encoding/gob.
+func (o *OptBool) GobDecode(data []byte) error {
+	return o.UnmarshalBinary(data)
+}
@@ -1,10 +1,12 @@
 package configtypes
 
 import (
+	"database/sql/driver"
 	"encoding/json"
 	"time"
 
 	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"gopkg.in/yaml.v3"
 )
 
 // OptDuration represents an optional time.Duration parameter. Any time.Duration value is allowed; if you
@@ -38,6 +40,18 @@ func NewOptDurationFromString(s string) (OptDuration, error) {
 	return OptDuration{}, errDurationFormat()
 }
 
+// NewOptDurationFromLDValue converts an ldvalue.Value to OptDuration, with the same rules as UnmarshalJSON.
+func NewOptDurationFromLDValue(v ldvalue.Value) (OptDuration, error) {
+	switch {
+	case v.IsNull():
+		return OptDuration{}, nil
+	case v.IsString():
+		return NewOptDurationFromString(v.StringValue())
+	default:
+		return OptDuration{}, errDurationFormat()
+	}
+}
+
 func (o OptDuration) IsDefined() bool {
 	return o.hasValue
 }
@@ -68,6 +82,28 @@ func (o *OptDuration) UnmarshalText(data []byte) error {
 	return err
 }
 
+// Set is the same as UnmarshalText. It allows OptDuration to be used as a flag.Value.
+func (o *OptDuration) Set(s string) error {
+	return o.UnmarshalText([]byte(s))
+}
+
+// Get returns the value as a time.Duration, or nil if it is not defined. It allows OptDuration to
+// be used as a flag.Getter.
+func (o OptDuration) Get() interface{} {
+	if !o.IsDefined() {
+		return nil
+	}
+	return o.GetOrElse(0)
+}
+
+// AsLDValue converts the value to an ldvalue.Value, with the same rules as MarshalJSON.
+func (o OptDuration) AsLDValue() ldvalue.Value {
+	if !o.hasValue {
+		return ldvalue.Null()
+	}
+	return ldvalue.String(o.String())
+}
+
 func (o OptDuration) MarshalJSON() ([]byte, error) {
 	if o.hasValue {
 		return json.Marshal(o.String())
@@ -77,18 +113,79 @@ func (o OptDuration) MarshalJSON() ([]byte, error) {
 
 func (o *OptDuration) UnmarshalJSON(data []byte) error {
 	var v ldvalue.Value
-	var err error
-	if err = v.UnmarshalJSON(data); err != nil {
+	if err := v.UnmarshalJSON(data); err != nil {
 		return err
 	}
-	switch {
-	case v.IsNull():
+	opt, err := NewOptDurationFromLDValue(v)
+	if err == nil || v.IsString() {
+		*o = opt // a string that cannot be parsed leaves the value empty
+	}
+	return err
+}
+
+// MarshalYAML converts this type to a YAML value, with the same rules as MarshalJSON.
+func (o OptDuration) MarshalYAML() (interface{}, error) {
+	if o.hasValue {
+		return o.String(), nil
+	}
+	return nil, nil //nolint:nilnil
+}
+
+// UnmarshalYAML converts a YAML value to this type, with the same rules as UnmarshalJSON.
+func (o *OptDuration) UnmarshalYAML(node *yaml.Node) error {
+	return unmarshalYAMLAsJSON(node, o)
+}
+
+// Value implements driver.Valuer, so that the value can be written to a database with database/sql.
+// An empty value is NULL; otherwise it is a string in the same format as
+// String. To store it as an int64 count of nanoseconds instead, use SQLDurationAsNanoseconds.
+func (o OptDuration) Value() (driver.Value, error) {
+	if !o.IsDefined() {
+		return nil, nil //nolint:nilnil
+	}
+	return o.String(), nil
+}
+
+// Scan implements sql.Scanner, so that the value can be read from a database with database/sql. NULL
+// becomes an empty value; either a string in the same format as UnmarshalText or
+// an integer count of nanoseconds is allowed, so the column can have either format.
+func (o *OptDuration) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
 		*o = OptDuration{}
 		return nil
-	case v.IsString():
-		*o, err = NewOptDurationFromString(v.StringValue())
+	case int64:
+		*o = NewOptDuration(time.Duration(v))
+		return nil
+	}
+	return scanSQLText(src, o)
+}
+
+// MarshalBinary converts the value to the binary format described in the package documentation.
+func (o OptDuration) MarshalBinary() ([]byte, error) {
+	return marshalOptBinaryVarint(o.hasValue, int64(o.value)), nil
+}
+
+// UnmarshalBinary converts data produced by MarshalBinary, with the same validation as UnmarshalText.
+func (o *OptDuration) UnmarshalBinary(data []byte) error {
+	value, defined, err := unmarshalOptBinaryVarint(data)
+	switch {
+	case err != nil:
 		return err
+	case !defined:
+		*o = OptDuration{}
 	default:
-		return errDurationFormat()
+		*o = NewOptDuration(time.Duration(value))
 	}
+	return nil
+}
+
+// GobEncode is the same as MarshalBinary. It allows OptDuration to be used with encoding/gob.
+func (o OptDuration) GobEncode() ([]byte, error) {
+	return o.MarshalBinary()
+}
+
+// GobDecode is the same as UnmarshalBinary. It allows OptDuration to be used with encoding/gob.
+func (o *OptDuration) GobDecode(data []byte) error {
+	return o.UnmarshalBinary(data)
 }
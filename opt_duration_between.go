@@ -0,0 +1,197 @@
+package configtypes
+
+import (
+	"database/sql/driver"
+	"time"
+
+	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"gopkg.in/yaml.v3"
+)
+
+// OptDurationBetween represents an optional time.Duration parameter which, if defined, must be within
+// the range specified by the Bounds type B.
+//
+// This is the same as OptDuration, but with additional validation for the constructor and unmarshalers,
+// like OptDurationNonNegative. Since the range is part of the type, it is impossible (except with
+// reflection) for code outside this package to construct an instance of OptDurationBetween[B] with a
+// defined value that is out of range. The error for such a value describes the range, such as "value
+// must be between 1s and 1m0s".
+//
+//	type TimeoutBounds struct{}
+//
+//	func (TimeoutBounds) Range() configtypes.NumericRange[time.Duration] {
+//	    return configtypes.RangeBetween(time.Second, time.Minute)
+//	}
+//
+//	type Config struct {
+//	    Timeout configtypes.OptDurationBetween[TimeoutBounds] `conf:"TIMEOUT"`
+//	}
+//
+// See the package documentation for the general contract for methods that have no specific documentation
+// here.
+type OptDurationBetween[B Bounds[time.Duration]] struct {
+	opt OptDuration
+}
+
+func NewOptDurationBetween[B Bounds[time.Duration]](value time.Duration) (OptDurationBetween[B], error) {
+	return optDurationBetweenFromOptDuration[B](NewOptDuration(value))
+}
+
+func NewOptDurationBetweenFromString[B Bounds[time.Duration]](s string) (OptDurationBetween[B], error) {
+	o, err := NewOptDurationFromString(s)
+	if err != nil {
+		return OptDurationBetween[B]{}, err
+	}
+	return optDurationBetweenFromOptDuration[B](o)
+}
+
+// NewOptDurationBetweenFromLDValue converts an ldvalue.Value to OptDurationBetween, with the same rules
+// as UnmarshalJSON.
+func NewOptDurationBetweenFromLDValue[B Bounds[time.Duration]](
+	v ldvalue.Value,
+) (OptDurationBetween[B], error) {
+	opt, err := NewOptDurationFromLDValue(v)
+	if err != nil {
+		return OptDurationBetween[B]{}, err
+	}
+	return optDurationBetweenFromOptDuration[B](opt)
+}
+
+func optDurationBetweenFromOptDuration[B Bounds[time.Duration]](o OptDuration) (OptDurationBetween[B], error) {
+	if err := checkRange[time.Duration, B](o.IsDefined(), o.GetOrElse(0)); err != nil {
+		return OptDurationBetween[B]{}, err
+	}
+	return OptDurationBetween[B]{o}, nil
+}
+
+func (o OptDurationBetween[B]) IsDefined() bool {
+	return o.opt.IsDefined()
+}
+
+func (o OptDurationBetween[B]) GetOrElse(orElseValue time.Duration) time.Duration {
+	return o.opt.GetOrElse(orElseValue)
+}
+
+// Range returns the range specified by B.
+func (o OptDurationBetween[B]) Range() NumericRange[time.Duration] {
+	return rangeOf[time.Duration, B]()
+}
+
+func (o *OptDurationBetween[B]) UnmarshalText(data []byte) error {
+	var opt OptDuration
+	if err := opt.UnmarshalText(data); err != nil {
+		return err
+	}
+	value, err := optDurationBetweenFromOptDuration[B](opt)
+	if err == nil {
+		*o = value
+	}
+	return err
+}
+
+// Set is the same as UnmarshalText. It allows OptDurationBetween to be used as a flag.Value.
+func (o *OptDurationBetween[B]) Set(s string) error {
+	return o.UnmarshalText([]byte(s))
+}
+
+// Get returns the value as a time.Duration, or nil if it is not defined. It allows OptDurationBetween to
+// be used as a flag.Getter.
+func (o OptDurationBetween[B]) Get() interface{} {
+	if !o.IsDefined() {
+		return nil
+	}
+	return o.GetOrElse(0)
+}
+
+func (o OptDurationBetween[B]) String() string {
+	return o.opt.String()
+}
+
+func (o OptDurationBetween[B]) MarshalText() ([]byte, error) {
+	return o.opt.MarshalText()
+}
+
+func (o *OptDurationBetween[B]) UnmarshalJSON(data []byte) error {
+	var v ldvalue.Value
+	if err := v.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	opt, err := NewOptDurationBetweenFromLDValue[B](v)
+	if err == nil {
+		*o = opt
+	}
+	return err
+}
+
+// AsLDValue converts the value to an ldvalue.Value, with the same rules as MarshalJSON.
+func (o OptDurationBetween[B]) AsLDValue() ldvalue.Value {
+	return o.opt.AsLDValue()
+}
+
+func (o OptDurationBetween[B]) MarshalJSON() ([]byte, error) {
+	return o.opt.MarshalJSON()
+}
+
+// MarshalYAML converts this type to a YAML value, with the same rules as MarshalJSON.
+func (o OptDurationBetween[B]) MarshalYAML() (interface{}, error) {
+	return o.opt.MarshalYAML()
+}
+
+// UnmarshalYAML converts a YAML value to this type, with the same rules as UnmarshalJSON.
+func (o *OptDurationBetween[B]) UnmarshalYAML(node *yaml.Node) error {
+	return unmarshalYAMLAsJSON(node, o)
+}
+
+// Value implements driver.Valuer, with the same rules as OptDuration.
+func (o OptDurationBetween[B]) Value() (driver.Value, error) {
+	return o.opt.Value()
+}
+
+// Scan implements sql.Scanner, with the same rules as OptDuration.
+func (o *OptDurationBetween[B]) Scan(src interface{}) error {
+	var opt OptDuration
+	if err := opt.Scan(src); err != nil {
+		return err
+	}
+	value, err := optDurationBetweenFromOptDuration[B](opt)
+	if err == nil {
+		*o = value
+	}
+	return err
+}
+
+// MarshalBinary converts the value to the binary format described in the package documentation.
+func (o OptDurationBetween[B]) MarshalBinary() ([]byte, error) {
+	return o.opt.MarshalBinary()
+}
+
+// UnmarshalBinary converts data produced by MarshalBinary, with the same validation as UnmarshalText.
+func (o *OptDurationBetween[B]) UnmarshalBinary(data []byte) error {
+	var opt OptDuration
+	if err := opt.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	value, err := optDurationBetweenFromOptDuration[B](opt)
+	if err == nil {
+		*o = value
+	}
+	return err
+}
+
+// GobEncode is the same as MarshalBinary. It allows OptDurationBetween to be used with encoding/gob.
+func (o OptDurationBetween[B]) GobEncode() ([]byte, error) {
+	return o.MarshalBinary()
+}
+
+// GobDecode is the same as UnmarshalBinary. It allows OptDurationBetween to be used with encoding/gob.
+func (o *OptDurationBetween[B]) GobDecode(data []byte) error {
+	return o.UnmarshalBinary(data)
+}
+
+func (o OptDurationBetween[B]) describeVarFormat() string {
+	return "duration like 1m30s, " + o.Range().String()
+}
+
+func (o OptDurationBetween[B]) jsonSchema() jsonSchemaObject {
+	return jsonSchemaObject{"type": "string", "pattern": durationJSONPattern}
+}
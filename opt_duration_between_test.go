@@ -0,0 +1,73 @@
+package configtypes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func mustOptDurationBetween(d time.Duration) OptDurationBetween[testDurationBounds] {
+	o, err := NewOptDurationBetween[testDurationBounds](d)
+	if err != nil {
+		panic(err)
+	}
+	return o
+}
+
+func TestOptDurationBetween(t *testing.T) {
+	outOfRange := errOutOfRange("at least 1s")
+
+	t.Run("empty value", func(t *testing.T) {
+		unsetValue := OptDurationBetween[testDurationBounds]{}
+		assertIsDefined(t, false, unsetValue)
+		assert.Equal(t, time.Hour, unsetValue.GetOrElse(time.Hour))
+	})
+
+	t.Run("defined value", func(t *testing.T) {
+		value, err := NewOptDurationBetween[testDurationBounds](time.Second)
+		assert.NoError(t, err)
+		assertIsDefined(t, true, value)
+		assert.Equal(t, time.Second, value.GetOrElse(time.Hour))
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		value, err := NewOptDurationBetween[testDurationBounds](time.Millisecond)
+		assert.Equal(t, outOfRange, err)
+		assert.Equal(t, OptDurationBetween[testDurationBounds]{}, value)
+	})
+
+	stringCtor := func(input string) (interface{}, error) {
+		o, err := NewOptDurationBetweenFromString[testDurationBounds](input)
+		return o, err
+	}
+
+	assertConvertToText(t, map[string]textMarshalerAndStringer{
+		"": OptDurationBetween[testDurationBounds]{}, "1m30s": mustOptDurationBetween(90 * time.Second),
+	})
+
+	assertConvertFromText(t, &OptDurationBetween[testDurationBounds]{}, stringCtor, map[string]interface{}{
+		"":      OptDurationBetween[testDurationBounds]{},
+		"1s":    mustOptDurationBetween(time.Second),
+		"1m30s": mustOptDurationBetween(90 * time.Second),
+	})
+
+	assertConvertFromTextFails(t, &OptDurationBetween[testDurationBounds]{}, stringCtor, errDurationFormat(),
+		"1", "x",
+	)
+
+	assertConvertFromTextFails(t, &OptDurationBetween[testDurationBounds]{}, stringCtor, outOfRange,
+		"999ms", "0s", "-1m",
+	)
+
+	assertConvertToJSON(t, map[string]SingleValue{
+		`null`: OptDurationBetween[testDurationBounds]{}, `"1m30s"`: mustOptDurationBetween(90 * time.Second),
+	})
+
+	assertConvertFromJSON(t, &OptDurationBetween[testDurationBounds]{}, map[string]interface{}{
+		`null`: OptDurationBetween[testDurationBounds]{}, `"1m30s"`: mustOptDurationBetween(90 * time.Second),
+	})
+
+	assertConvertFromJSONFails(t, &OptDurationBetween[testDurationBounds]{},
+		`true`, `1`, `"0s"`, `"x"`, `[]`, `{}`)
+}
@@ -1,6 +1,12 @@
 package configtypes
 
-import "time"
+import (
+	"database/sql/driver"
+	"time"
+
+	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"gopkg.in/yaml.v3"
+)
 
 // OptDurationNonNegative represents an optional time.Duration parameter which, if defined, must be
 // greater than or equal to zero.
@@ -27,6 +33,16 @@ func NewOptDurationNonNegativeFromString(s string) (OptDurationNonNegative, erro
 	return optDurationNonNegativeFromOptDuration(o)
 }
 
+// NewOptDurationNonNegativeFromLDValue converts an ldvalue.Value to OptDurationNonNegative, with the same rules as
+// UnmarshalJSON.
+func NewOptDurationNonNegativeFromLDValue(v ldvalue.Value) (OptDurationNonNegative, error) {
+	opt, err := NewOptDurationFromLDValue(v)
+	if err != nil {
+		return OptDurationNonNegative{}, err
+	}
+	return optDurationNonNegativeFromOptDuration(opt)
+}
+
 func optDurationNonNegativeFromOptDuration(o OptDuration) (OptDurationNonNegative, error) {
 	if !o.IsDefined() || o.GetOrElse(0) >= 0 {
 		return OptDurationNonNegative{o}, nil
@@ -54,6 +70,20 @@ func (o *OptDurationNonNegative) UnmarshalText(data []byte) error {
 	return err
 }
 
+// Set is the same as UnmarshalText. It allows OptDurationNonNegative to be used as a flag.Value.
+func (o *OptDurationNonNegative) Set(s string) error {
+	return o.UnmarshalText([]byte(s))
+}
+
+// Get returns the value as a time.Duration, or nil if it is not defined. It allows
+// OptDurationNonNegative to be used as a flag.Getter.
+func (o OptDurationNonNegative) Get() interface{} {
+	if !o.IsDefined() {
+		return nil
+	}
+	return o.GetOrElse(0)
+}
+
 func (o OptDurationNonNegative) String() string {
 	return o.opt.String()
 }
@@ -63,8 +93,45 @@ func (o OptDurationNonNegative) MarshalText() ([]byte, error) {
 }
 
 func (o *OptDurationNonNegative) UnmarshalJSON(data []byte) error {
+	var v ldvalue.Value
+	if err := v.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	opt, err := NewOptDurationNonNegativeFromLDValue(v)
+	if err == nil {
+		*o = opt
+	}
+	return err
+}
+
+// AsLDValue converts the value to an ldvalue.Value, with the same rules as MarshalJSON.
+func (o OptDurationNonNegative) AsLDValue() ldvalue.Value {
+	return o.opt.AsLDValue()
+}
+
+func (o OptDurationNonNegative) MarshalJSON() ([]byte, error) {
+	return o.opt.MarshalJSON()
+}
+
+// MarshalYAML converts this type to a YAML value, with the same rules as MarshalJSON.
+func (o OptDurationNonNegative) MarshalYAML() (interface{}, error) {
+	return o.opt.MarshalYAML()
+}
+
+// UnmarshalYAML converts a YAML value to this type, with the same rules as UnmarshalJSON.
+func (o *OptDurationNonNegative) UnmarshalYAML(node *yaml.Node) error {
+	return unmarshalYAMLAsJSON(node, o)
+}
+
+// Value implements driver.Valuer, with the same rules as OptDuration.
+func (o OptDurationNonNegative) Value() (driver.Value, error) {
+	return o.opt.Value()
+}
+
+// Scan implements sql.Scanner, with the same rules as OptDuration.
+func (o *OptDurationNonNegative) Scan(src interface{}) error {
 	var opt OptDuration
-	if err := opt.UnmarshalJSON(data); err != nil {
+	if err := opt.Scan(src); err != nil {
 		return err
 	}
 	value, err := optDurationNonNegativeFromOptDuration(opt)
@@ -74,6 +141,30 @@ func (o *OptDurationNonNegative) UnmarshalJSON(data []byte) error {
 	return err
 }
 
-func (o OptDurationNonNegative) MarshalJSON() ([]byte, error) {
-	return o.opt.MarshalJSON()
+// MarshalBinary converts the value to the binary format described in the package documentation.
+func (o OptDurationNonNegative) MarshalBinary() ([]byte, error) {
+	return o.opt.MarshalBinary()
+}
+
+// UnmarshalBinary converts data produced by MarshalBinary, with the same validation as UnmarshalText.
+func (o *OptDurationNonNegative) UnmarshalBinary(data []byte) error {
+	var opt OptDuration
+	if err := opt.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	value, err := optDurationNonNegativeFromOptDuration(opt)
+	if err == nil {
+		*o = value
+	}
+	return err
+}
+
+// GobEncode is the same as MarshalBinary. It allows OptDurationNonNegative to be used with encoding/gob.
+func (o OptDurationNonNegative) GobEncode() ([]byte, error) {
+	return o.MarshalBinary()
+}
+
+// GobDecode is the same as UnmarshalBinary. It allows OptDurationNonNegative to be used with encoding/gob.
+func (o *OptDurationNonNegative) GobDecode(data []byte) error {
+	return o.UnmarshalBinary(data)
 }
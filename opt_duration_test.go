@@ -71,4 +71,6 @@ func TestOptDuration(t *testing.T) {
 
 	assertConvertFromJSONFails(t, &OptDuration{},
 		`true`, `1`, `"x"`, `[]`, `{}`)
+
+	assertUnmarshalJSONClearsForInvalidString(t, &OptDuration{}, NewOptDuration(time.Second), `"x"`)
 }
@@ -1,10 +1,14 @@
 package configtypes
 
 import (
+	"database/sql/driver"
+	"encoding/binary"
 	"encoding/json"
+	"math"
 	"strconv"
 
 	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"gopkg.in/yaml.v3"
 )
 
 // OptFloat64 represents an optional float64 parameter.
@@ -36,6 +40,18 @@ func NewOptFloat64FromString(s string) (OptFloat64, error) {
 	return NewOptFloat64(n), nil
 }
 
+// NewOptFloat64FromLDValue converts an ldvalue.Value to OptFloat64, with the same rules as UnmarshalJSON.
+func NewOptFloat64FromLDValue(v ldvalue.Value) (OptFloat64, error) {
+	switch {
+	case v.IsNull():
+		return OptFloat64{}, nil
+	case v.IsNumber():
+		return NewOptFloat64(v.Float64Value()), nil
+	default:
+		return OptFloat64{}, errFloatFormat()
+	}
+}
+
 func (o OptFloat64) IsDefined() bool {
 	return o.hasValue
 }
@@ -66,6 +82,28 @@ func (o *OptFloat64) UnmarshalText(data []byte) error {
 	return err
 }
 
+// Set is the same as UnmarshalText. It allows OptFloat64 to be used as a flag.Value.
+func (o *OptFloat64) Set(s string) error {
+	return o.UnmarshalText([]byte(s))
+}
+
+// Get returns the value as a float64, or nil if it is not defined. It allows OptFloat64 to be used
+// as a flag.Getter.
+func (o OptFloat64) Get() interface{} {
+	if !o.IsDefined() {
+		return nil
+	}
+	return o.GetOrElse(0)
+}
+
+// AsLDValue converts the value to an ldvalue.Value, with the same rules as MarshalJSON.
+func (o OptFloat64) AsLDValue() ldvalue.Value {
+	if !o.hasValue {
+		return ldvalue.Null()
+	}
+	return ldvalue.Float64(o.value)
+}
+
 func (o OptFloat64) MarshalJSON() ([]byte, error) {
 	if o.hasValue {
 		return json.Marshal(o.value)
@@ -78,13 +116,79 @@ func (o *OptFloat64) UnmarshalJSON(data []byte) error {
 	if err := v.UnmarshalJSON(data); err != nil {
 		return err
 	}
+	opt, err := NewOptFloat64FromLDValue(v)
+	if err == nil {
+		*o = opt
+	}
+	return err
+}
+
+// MarshalYAML converts this type to a YAML value, with the same rules as MarshalJSON.
+func (o OptFloat64) MarshalYAML() (interface{}, error) {
+	if o.hasValue {
+		return o.value, nil
+	}
+	return nil, nil //nolint:nilnil
+}
+
+// UnmarshalYAML converts a YAML value to this type, with the same rules as UnmarshalJSON.
+func (o *OptFloat64) UnmarshalYAML(node *yaml.Node) error {
+	return unmarshalYAMLAsJSON(node, o)
+}
+
+// Value implements driver.Valuer, so that the value can be written to a database with database/sql.
+// An empty value is NULL; otherwise it is a float64.
+func (o OptFloat64) Value() (driver.Value, error) {
+	if !o.IsDefined() {
+		return nil, nil //nolint:nilnil
+	}
+	return o.GetOrElse(0), nil
+}
+
+// Scan implements sql.Scanner, so that the value can be read from a database with database/sql. NULL
+// becomes an empty value; a number, or a string in the same format as UnmarshalText, is also allowed.
+func (o *OptFloat64) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*o = OptFloat64{}
+		return nil
+	case float64:
+		*o = NewOptFloat64(v)
+		return nil
+	case int64:
+		*o = NewOptFloat64(float64(v))
+		return nil
+	}
+	return scanSQLText(src, o)
+}
+
+// MarshalBinary converts the value to the binary format described in the package documentation.
+func (o OptFloat64) MarshalBinary() ([]byte, error) {
+	return marshalOptBinary(o.hasValue, binary.BigEndian.AppendUint64(nil, math.Float64bits(o.value))), nil
+}
+
+// UnmarshalBinary converts data produced by MarshalBinary, with the same validation as UnmarshalText.
+func (o *OptFloat64) UnmarshalBinary(data []byte) error {
+	payload, defined, err := unmarshalOptBinary(data)
 	switch {
-	case v.IsNull():
+	case err != nil:
+		return err
+	case !defined:
 		*o = OptFloat64{}
-	case v.IsNumber():
-		*o = NewOptFloat64(v.Float64Value())
+	case len(payload) == 8:
+		*o = NewOptFloat64(math.Float64frombits(binary.BigEndian.Uint64(payload)))
 	default:
-		return errFloatFormat()
+		return errBinaryFormat()
 	}
 	return nil
 }
+
+// GobEncode is the same as MarshalBinary. It allows OptFloat64 to be used with encoding/gob.
+func (o OptFloat64) GobEncode() ([]byte, error) {
+	return o.MarshalBinary()
+}
+
+// GobDecode is the same as UnmarshalBinary. It allows OptFloat64 to be used with encoding/gob.
+func (o *OptFloat64) GobDecode(data []byte) error {
+	return o.UnmarshalBinary(data)
+}
@@ -0,0 +1,196 @@
+package configtypes
+
+import (
+	"database/sql/driver"
+
+	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"gopkg.in/yaml.v3"
+)
+
+// OptFloat64Between represents an optional float64 parameter which, if defined, must be within the range
+// specified by the Bounds type B.
+//
+// This is the same as OptFloat64, but with additional validation for the constructor and unmarshalers,
+// like OptIntGreaterThanZero. Since the range is part of the type, it is impossible (except with
+// reflection) for code outside this package to construct an instance of OptFloat64Between[B] with a
+// defined value that is out of range. The error for such a value describes the range, such as "value
+// must be between 0 and 1".
+//
+//	type RatioBounds struct{}
+//
+//	func (RatioBounds) Range() configtypes.NumericRange[float64] {
+//	    return configtypes.RangeBetween(0.0, 1.0)
+//	}
+//
+//	type Config struct {
+//	    SampleRatio configtypes.OptFloat64Between[RatioBounds] `conf:"SAMPLE_RATIO"`
+//	}
+//
+// See the package documentation for the general contract for methods that have no specific documentation
+// here.
+type OptFloat64Between[B Bounds[float64]] struct {
+	opt OptFloat64
+}
+
+func NewOptFloat64Between[B Bounds[float64]](value float64) (OptFloat64Between[B], error) {
+	return optFloat64BetweenFromOptFloat64[B](NewOptFloat64(value))
+}
+
+func NewOptFloat64BetweenFromString[B Bounds[float64]](s string) (OptFloat64Between[B], error) {
+	o, err := NewOptFloat64FromString(s)
+	if err != nil {
+		return OptFloat64Between[B]{}, err
+	}
+	return optFloat64BetweenFromOptFloat64[B](o)
+}
+
+// NewOptFloat64BetweenFromLDValue converts an ldvalue.Value to OptFloat64Between, with the same rules as
+// UnmarshalJSON.
+func NewOptFloat64BetweenFromLDValue[B Bounds[float64]](
+	v ldvalue.Value,
+) (OptFloat64Between[B], error) {
+	opt, err := NewOptFloat64FromLDValue(v)
+	if err != nil {
+		return OptFloat64Between[B]{}, err
+	}
+	return optFloat64BetweenFromOptFloat64[B](opt)
+}
+
+func optFloat64BetweenFromOptFloat64[B Bounds[float64]](o OptFloat64) (OptFloat64Between[B], error) {
+	if err := checkRange[float64, B](o.IsDefined(), o.GetOrElse(0)); err != nil {
+		return OptFloat64Between[B]{}, err
+	}
+	return OptFloat64Between[B]{o}, nil
+}
+
+func (o OptFloat64Between[B]) IsDefined() bool {
+	return o.opt.IsDefined()
+}
+
+func (o OptFloat64Between[B]) GetOrElse(orElseValue float64) float64 {
+	return o.opt.GetOrElse(orElseValue)
+}
+
+// Range returns the range specified by B.
+func (o OptFloat64Between[B]) Range() NumericRange[float64] {
+	return rangeOf[float64, B]()
+}
+
+func (o *OptFloat64Between[B]) UnmarshalText(data []byte) error {
+	var opt OptFloat64
+	if err := opt.UnmarshalText(data); err != nil {
+		return err
+	}
+	value, err := optFloat64BetweenFromOptFloat64[B](opt)
+	if err == nil {
+		*o = value
+	}
+	return err
+}
+
+// Set is the same as UnmarshalText. It allows OptFloat64Between to be used as a flag.Value.
+func (o *OptFloat64Between[B]) Set(s string) error {
+	return o.UnmarshalText([]byte(s))
+}
+
+// Get returns the value as a float64, or nil if it is not defined. It allows OptFloat64Between to be
+// used as a flag.Getter.
+func (o OptFloat64Between[B]) Get() interface{} {
+	if !o.IsDefined() {
+		return nil
+	}
+	return o.GetOrElse(0)
+}
+
+func (o OptFloat64Between[B]) String() string {
+	return o.opt.String()
+}
+
+func (o OptFloat64Between[B]) MarshalText() ([]byte, error) {
+	return o.opt.MarshalText()
+}
+
+func (o *OptFloat64Between[B]) UnmarshalJSON(data []byte) error {
+	var v ldvalue.Value
+	if err := v.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	opt, err := NewOptFloat64BetweenFromLDValue[B](v)
+	if err == nil {
+		*o = opt
+	}
+	return err
+}
+
+// AsLDValue converts the value to an ldvalue.Value, with the same rules as MarshalJSON.
+func (o OptFloat64Between[B]) AsLDValue() ldvalue.Value {
+	return o.opt.AsLDValue()
+}
+
+func (o OptFloat64Between[B]) MarshalJSON() ([]byte, error) {
+	return o.opt.MarshalJSON()
+}
+
+// MarshalYAML converts this type to a YAML value, with the same rules as MarshalJSON.
+func (o OptFloat64Between[B]) MarshalYAML() (interface{}, error) {
+	return o.opt.MarshalYAML()
+}
+
+// UnmarshalYAML converts a YAML value to this type, with the same rules as UnmarshalJSON.
+func (o *OptFloat64Between[B]) UnmarshalYAML(node *yaml.Node) error {
+	return unmarshalYAMLAsJSON(node, o)
+}
+
+// Value implements driver.Valuer, with the same rules as OptFloat64.
+func (o OptFloat64Between[B]) Value() (driver.Value, error) {
+	return o.opt.Value()
+}
+
+// Scan implements sql.Scanner, with the same rules as OptFloat64.
+func (o *OptFloat64Between[B]) Scan(src interface{}) error {
+	var opt OptFloat64
+	if err := opt.Scan(src); err != nil {
+		return err
+	}
+	value, err := optFloat64BetweenFromOptFloat64[B](opt)
+	if err == nil {
+		*o = value
+	}
+	return err
+}
+
+// MarshalBinary converts the value to the binary format described in the package documentation.
+func (o OptFloat64Between[B]) MarshalBinary() ([]byte, error) {
+	return o.opt.MarshalBinary()
+}
+
+// UnmarshalBinary converts data produced by MarshalBinary, with the same validation as UnmarshalText.
+func (o *OptFloat64Between[B]) UnmarshalBinary(data []byte) error {
+	var opt OptFloat64
+	if err := opt.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	value, err := optFloat64BetweenFromOptFloat64[B](opt)
+	if err == nil {
+		*o = value
+	}
+	return err
+}
+
+// GobEncode is the same as MarshalBinary. It allows OptFloat64Between to be used with encoding/gob.
+func (o OptFloat64Between[B]) GobEncode() ([]byte, error) {
+	return o.MarshalBinary()
+}
+
+// GobDecode is the same as UnmarshalBinary. It allows OptFloat64Between to be used with encoding/gob.
+func (o *OptFloat64Between[B]) GobDecode(data []byte) error {
+	return o.UnmarshalBinary(data)
+}
+
+func (o OptFloat64Between[B]) describeVarFormat() string {
+	return "number " + o.Range().String()
+}
+
+func (o OptFloat64Between[B]) jsonSchema() jsonSchemaObject {
+	return rangeJSONSchema(o.Range(), jsonSchemaObject{"type": "number"})
+}
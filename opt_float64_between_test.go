@@ -0,0 +1,73 @@
+package configtypes
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func mustOptFloat64Between(n float64) OptFloat64Between[testFloat64Bounds] {
+	o, err := NewOptFloat64Between[testFloat64Bounds](n)
+	if err != nil {
+		panic(err)
+	}
+	return o
+}
+
+func TestOptFloat64Between(t *testing.T) {
+	outOfRange := errOutOfRange("at least 0 and less than 1")
+
+	t.Run("empty value", func(t *testing.T) {
+		unsetValue := OptFloat64Between[testFloat64Bounds]{}
+		assertIsDefined(t, false, unsetValue)
+		assert.Equal(t, 0.5, unsetValue.GetOrElse(0.5))
+	})
+
+	t.Run("defined value", func(t *testing.T) {
+		value, err := NewOptFloat64Between[testFloat64Bounds](0)
+		assert.NoError(t, err)
+		assertIsDefined(t, true, value)
+		assert.Equal(t, 0.0, value.GetOrElse(0.5))
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		for _, n := range []float64{-0.1, 1, math.NaN(), math.Inf(1)} {
+			value, err := NewOptFloat64Between[testFloat64Bounds](n)
+			assert.Equal(t, outOfRange, err)
+			assert.Equal(t, OptFloat64Between[testFloat64Bounds]{}, value)
+		}
+	})
+
+	stringCtor := func(input string) (interface{}, error) {
+		o, err := NewOptFloat64BetweenFromString[testFloat64Bounds](input)
+		return o, err
+	}
+
+	assertConvertToText(t, map[string]textMarshalerAndStringer{
+		"": OptFloat64Between[testFloat64Bounds]{}, "0.5": mustOptFloat64Between(0.5),
+	})
+
+	assertConvertFromText(t, &OptFloat64Between[testFloat64Bounds]{}, stringCtor, map[string]interface{}{
+		"": OptFloat64Between[testFloat64Bounds]{}, "0": mustOptFloat64Between(0), "0.5": mustOptFloat64Between(0.5),
+	})
+
+	assertConvertFromTextFails(t, &OptFloat64Between[testFloat64Bounds]{}, stringCtor, errFloatFormat(),
+		"-", "x",
+	)
+
+	assertConvertFromTextFails(t, &OptFloat64Between[testFloat64Bounds]{}, stringCtor, outOfRange,
+		"1", "-1", "NaN",
+	)
+
+	assertConvertToJSON(t, map[string]SingleValue{
+		`null`: OptFloat64Between[testFloat64Bounds]{}, `0.5`: mustOptFloat64Between(0.5),
+	})
+
+	assertConvertFromJSON(t, &OptFloat64Between[testFloat64Bounds]{}, map[string]interface{}{
+		`null`: OptFloat64Between[testFloat64Bounds]{}, `0.5`: mustOptFloat64Between(0.5),
+	})
+
+	assertConvertFromJSONFails(t, &OptFloat64Between[testFloat64Bounds]{},
+		`true`, `1`, `-0.5`, `"0.5"`, `[]`, `{}`)
+}
@@ -1,10 +1,13 @@
 package configtypes
 
 import (
+	"database/sql/driver"
 	"encoding/json"
+	"math"
 	"strconv"
 
 	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"gopkg.in/yaml.v3"
 )
 
 // OptInt represents an optional int parameter.
@@ -35,6 +38,18 @@ func NewOptIntFromString(s string) (OptInt, error) {
 	return NewOptInt(n), nil
 }
 
+// NewOptIntFromLDValue converts an ldvalue.Value to OptInt, with the same rules as UnmarshalJSON.
+func NewOptIntFromLDValue(v ldvalue.Value) (OptInt, error) {
+	switch {
+	case v.IsNull():
+		return OptInt{}, nil
+	case v.IsInt():
+		return NewOptInt(v.IntValue()), nil
+	default:
+		return OptInt{}, errIntFormat()
+	}
+}
+
 func (o OptInt) IsDefined() bool {
 	return o.v.IsDefined()
 }
@@ -62,6 +77,28 @@ func (o *OptInt) UnmarshalText(data []byte) error {
 	return err
 }
 
+// Set is the same as UnmarshalText. It allows OptInt to be used as a flag.Value.
+func (o *OptInt) Set(s string) error {
+	return o.UnmarshalText([]byte(s))
+}
+
+// Get returns the value as an int, or nil if it is not defined. It allows OptInt to be used as a
+// flag.Getter.
+func (o OptInt) Get() interface{} {
+	if !o.IsDefined() {
+		return nil
+	}
+	return o.GetOrElse(0)
+}
+
+// AsLDValue converts the value to an ldvalue.Value, with the same rules as MarshalJSON.
+func (o OptInt) AsLDValue() ldvalue.Value {
+	if !o.IsDefined() {
+		return ldvalue.Null()
+	}
+	return ldvalue.Int(o.v.IntValue())
+}
+
 func (o OptInt) MarshalJSON() ([]byte, error) {
 	if o.IsDefined() {
 		return json.Marshal(o.v.IntValue())
@@ -74,13 +111,79 @@ func (o *OptInt) UnmarshalJSON(data []byte) error {
 	if err := v.UnmarshalJSON(data); err != nil {
 		return err
 	}
+	opt, err := NewOptIntFromLDValue(v)
+	if err == nil {
+		*o = opt
+	}
+	return err
+}
+
+// MarshalYAML converts this type to a YAML value, with the same rules as MarshalJSON.
+func (o OptInt) MarshalYAML() (interface{}, error) {
+	if o.IsDefined() {
+		return o.v.IntValue(), nil
+	}
+	return nil, nil //nolint:nilnil
+}
+
+// UnmarshalYAML converts a YAML value to this type, with the same rules as UnmarshalJSON.
+func (o *OptInt) UnmarshalYAML(node *yaml.Node) error {
+	return unmarshalYAMLAsJSON(node, o)
+}
+
+// Value implements driver.Valuer, so that the value can be written to a database with database/sql.
+// An empty value is NULL; otherwise it is an int64.
+func (o OptInt) Value() (driver.Value, error) {
+	if !o.IsDefined() {
+		return nil, nil //nolint:nilnil
+	}
+	return int64(o.GetOrElse(0)), nil
+}
+
+// Scan implements sql.Scanner, so that the value can be read from a database with database/sql. NULL
+// becomes an empty value; an integer, or a string in the same format as UnmarshalText, is also allowed.
+func (o *OptInt) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*o = OptInt{}
+		return nil
+	case int64:
+		if int64(int(v)) != v {
+			return errIntFormat()
+		}
+		*o = NewOptInt(int(v))
+		return nil
+	}
+	return scanSQLText(src, o)
+}
+
+// MarshalBinary converts the value to the binary format described in the package documentation.
+func (o OptInt) MarshalBinary() ([]byte, error) {
+	return marshalOptBinaryVarint(o.IsDefined(), int64(o.GetOrElse(0))), nil
+}
+
+// UnmarshalBinary converts data produced by MarshalBinary, with the same validation as UnmarshalText.
+func (o *OptInt) UnmarshalBinary(data []byte) error {
+	value, defined, err := unmarshalOptBinaryVarint(data)
 	switch {
-	case v.IsNull():
+	case err != nil:
+		return err
+	case !defined:
 		*o = OptInt{}
-	case v.IsInt():
-		*o = NewOptInt(v.IntValue())
+	case int64(int(value)) != value:
+		return errIntOutOfRange(math.MinInt, math.MaxInt)
 	default:
-		return errIntFormat()
+		*o = NewOptInt(int(value))
 	}
 	return nil
 }
+
+// GobEncode is the same as MarshalBinary. It allows OptInt to be used with encoding/gob.
+func (o OptInt) GobEncode() ([]byte, error) {
+	return o.MarshalBinary()
+}
+
+// GobDecode is the same as UnmarshalBinary. It allows OptInt to be used with encoding/gob.
+func (o *OptInt) GobDecode(data []byte) error {
+	return o.UnmarshalBinary(data)
+}
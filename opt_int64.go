@@ -0,0 +1,187 @@
+package configtypes
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"strconv"
+
+	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"gopkg.in/yaml.v3"
+)
+
+// OptInt64 represents an optional int64 parameter.
+//
+// This is the same as OptInt, but the value is always 64 bits regardless of platform, and parsing is
+// exact for the whole int64 range. A value that is an integer but does not fit in an int64 causes an
+// "out of range" error rather than a format error.
+//
+// When converting from JSON, the value must be either a JSON null, a JSON number that is an integer, or
+// a JSON string in the same format as UnmarshalText. A JSON number is never converted to float64, so
+// integers beyond 2^53 are exact. When converting to JSON, the value is always a JSON number.
+//
+// See the package documentation for the general contract for methods that have no specific documentation
+// here.
+type OptInt64 struct {
+	hasValue bool
+	value    int64
+}
+
+func NewOptInt64(value int64) OptInt64 {
+	return OptInt64{hasValue: true, value: value}
+}
+
+func NewOptInt64FromString(s string) (OptInt64, error) {
+	if s == "" {
+		return OptInt64{}, nil
+	}
+	return optInt64FromIntegerText(s, true, nil)
+}
+
+// NewOptInt64FromLDValue converts an ldvalue.Value to OptInt64, with the same rules as UnmarshalJSON.
+// Since ldvalue.Value stores numbers as float64, a number beyond 2^53 may not be exact; use a string
+// value if that is a concern.
+func NewOptInt64FromLDValue(v ldvalue.Value) (OptInt64, error) {
+	return optInt64FromIntegerText(ldValueInteger(v))
+}
+
+// optInt64FromIntegerText converts decimal text, or the results of parseJSONInteger or
+// ldValueInteger, to OptInt64.
+func optInt64FromIntegerText(text string, defined bool, err error) (OptInt64, error) {
+	if err != nil || !defined {
+		return OptInt64{}, err
+	}
+	value, err := parseInt64(text)
+	if err != nil {
+		return OptInt64{}, err
+	}
+	return NewOptInt64(value), nil
+}
+
+func (o OptInt64) IsDefined() bool {
+	return o.hasValue
+}
+
+func (o OptInt64) GetOrElse(orElseValue int64) int64 {
+	if !o.hasValue {
+		return orElseValue
+	}
+	return o.value
+}
+
+func (o OptInt64) String() string {
+	if !o.hasValue {
+		return ""
+	}
+	return strconv.FormatInt(o.value, 10)
+}
+
+func (o OptInt64) MarshalText() ([]byte, error) {
+	return []byte(o.String()), nil
+}
+
+func (o *OptInt64) UnmarshalText(data []byte) error {
+	value, err := NewOptInt64FromString(string(data))
+	if err == nil {
+		*o = value
+	}
+	return err
+}
+
+// Set is the same as UnmarshalText. It allows OptInt64 to be used as a flag.Value.
+func (o *OptInt64) Set(s string) error {
+	return o.UnmarshalText([]byte(s))
+}
+
+// Get returns the value as an int64, or nil if it is not defined. It allows OptInt64 to be used as a
+// flag.Getter.
+func (o OptInt64) Get() interface{} {
+	if !o.hasValue {
+		return nil
+	}
+	return o.value
+}
+
+// AsLDValue converts the value to an ldvalue.Value, with the same rules as MarshalJSON. Since
+// ldvalue.Value stores numbers as float64, a value beyond 2^53 may not be exact.
+func (o OptInt64) AsLDValue() ldvalue.Value {
+	if !o.hasValue {
+		return ldvalue.Null()
+	}
+	return ldvalue.Float64(float64(o.value))
+}
+
+func (o OptInt64) MarshalJSON() ([]byte, error) {
+	if o.hasValue {
+		return json.Marshal(o.value)
+	}
+	return json.Marshal(nil)
+}
+
+func (o *OptInt64) UnmarshalJSON(data []byte) error {
+	opt, err := optInt64FromIntegerText(parseJSONInteger(data))
+	if err == nil {
+		*o = opt
+	}
+	return err
+}
+
+// MarshalYAML converts this type to a YAML value, with the same rules as MarshalJSON.
+func (o OptInt64) MarshalYAML() (interface{}, error) {
+	if o.hasValue {
+		return o.value, nil
+	}
+	return nil, nil //nolint:nilnil
+}
+
+// UnmarshalYAML converts a YAML value to this type, with the same rules as UnmarshalJSON.
+func (o *OptInt64) UnmarshalYAML(node *yaml.Node) error {
+	return unmarshalYAMLAsJSON(node, o)
+}
+
+// Value implements driver.Valuer, so that the value can be written to a database with database/sql.
+// An empty value is NULL; otherwise it is an int64.
+func (o OptInt64) Value() (driver.Value, error) {
+	if !o.hasValue {
+		return nil, nil //nolint:nilnil
+	}
+	return o.value, nil
+}
+
+// Scan implements sql.Scanner, so that the value can be read from a database with database/sql. NULL
+// becomes an empty value; an integer, or a string in the same format as UnmarshalText, is also allowed.
+func (o *OptInt64) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*o = OptInt64{}
+		return nil
+	case int64:
+		*o = NewOptInt64(v)
+		return nil
+	}
+	return scanSQLText(src, o)
+}
+
+// MarshalBinary converts the value to the binary format described in the package documentation.
+func (o OptInt64) MarshalBinary() ([]byte, error) {
+	return marshalOptBinaryVarint(o.hasValue, o.value), nil
+}
+
+// UnmarshalBinary converts data produced by MarshalBinary, with the same validation as UnmarshalText.
+func (o *OptInt64) UnmarshalBinary(data []byte) error {
+	value, defined, err := unmarshalOptBinaryVarint(data)
+	if err != nil {
+		return err
+	}
+	*o = OptInt64{hasValue: defined, value: value}
+	return nil
+}
+
+// GobEncode is the same as MarshalBinary. It allows OptInt64 to be used with encoding/gob.
+func (o OptInt64) GobEncode() ([]byte, error) {
+	return o.MarshalBinary()
+}
+
+// GobDecode is the same as UnmarshalBinary. It allows OptInt64 to be used with encoding/gob.
+func (o *OptInt64) GobDecode(data []byte) error {
+	return o.UnmarshalBinary(data)
+}
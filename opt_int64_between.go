@@ -0,0 +1,196 @@
+package configtypes
+
+import (
+	"database/sql/driver"
+
+	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"gopkg.in/yaml.v3"
+)
+
+// OptInt64Between represents an optional int64 parameter which, if defined, must be within the range
+// specified by the Bounds type B.
+//
+// This is the same as OptInt64, but with additional validation for the constructor and unmarshalers,
+// like OptInt64GreaterThanZero. Since the range is part of the type, it is impossible (except with
+// reflection) for code outside this package to construct an instance of OptInt64Between[B] with a
+// defined value that is out of range. The error for such a value describes the range, such as "value
+// must be greater than 0".
+//
+//	type MaxBytesBounds struct{}
+//
+//	func (MaxBytesBounds) Range() configtypes.NumericRange[int64] {
+//	    return configtypes.RangeGreaterThan[int64](0)
+//	}
+//
+//	type Config struct {
+//	    MaxBytes configtypes.OptInt64Between[MaxBytesBounds] `conf:"MAX_BYTES"`
+//	}
+//
+// See the package documentation for the general contract for methods that have no specific documentation
+// here.
+type OptInt64Between[B Bounds[int64]] struct {
+	opt OptInt64
+}
+
+func NewOptInt64Between[B Bounds[int64]](value int64) (OptInt64Between[B], error) {
+	return optInt64BetweenFromOptInt64[B](NewOptInt64(value))
+}
+
+func NewOptInt64BetweenFromString[B Bounds[int64]](s string) (OptInt64Between[B], error) {
+	o, err := NewOptInt64FromString(s)
+	if err != nil {
+		return OptInt64Between[B]{}, err
+	}
+	return optInt64BetweenFromOptInt64[B](o)
+}
+
+// NewOptInt64BetweenFromLDValue converts an ldvalue.Value to OptInt64Between, with the same rules as
+// UnmarshalJSON.
+func NewOptInt64BetweenFromLDValue[B Bounds[int64]](
+	v ldvalue.Value,
+) (OptInt64Between[B], error) {
+	opt, err := NewOptInt64FromLDValue(v)
+	if err != nil {
+		return OptInt64Between[B]{}, err
+	}
+	return optInt64BetweenFromOptInt64[B](opt)
+}
+
+func optInt64BetweenFromOptInt64[B Bounds[int64]](o OptInt64) (OptInt64Between[B], error) {
+	if err := checkRange[int64, B](o.IsDefined(), o.GetOrElse(0)); err != nil {
+		return OptInt64Between[B]{}, err
+	}
+	return OptInt64Between[B]{o}, nil
+}
+
+func (o OptInt64Between[B]) IsDefined() bool {
+	return o.opt.IsDefined()
+}
+
+func (o OptInt64Between[B]) GetOrElse(orElseValue int64) int64 {
+	return o.opt.GetOrElse(orElseValue)
+}
+
+// Range returns the range specified by B.
+func (o OptInt64Between[B]) Range() NumericRange[int64] {
+	return rangeOf[int64, B]()
+}
+
+func (o *OptInt64Between[B]) UnmarshalText(data []byte) error {
+	var opt OptInt64
+	if err := opt.UnmarshalText(data); err != nil {
+		return err
+	}
+	value, err := optInt64BetweenFromOptInt64[B](opt)
+	if err == nil {
+		*o = value
+	}
+	return err
+}
+
+// Set is the same as UnmarshalText. It allows OptInt64Between to be used as a flag.Value.
+func (o *OptInt64Between[B]) Set(s string) error {
+	return o.UnmarshalText([]byte(s))
+}
+
+// Get returns the value as an int64, or nil if it is not defined. It allows OptInt64Between to be used
+// as a flag.Getter.
+func (o OptInt64Between[B]) Get() interface{} {
+	if !o.IsDefined() {
+		return nil
+	}
+	return o.GetOrElse(0)
+}
+
+func (o OptInt64Between[B]) String() string {
+	return o.opt.String()
+}
+
+func (o OptInt64Between[B]) MarshalText() ([]byte, error) {
+	return o.opt.MarshalText()
+}
+
+func (o *OptInt64Between[B]) UnmarshalJSON(data []byte) error {
+	var opt OptInt64
+	if err := opt.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	value, err := optInt64BetweenFromOptInt64[B](opt)
+	if err == nil {
+		*o = value
+	}
+	return err
+}
+
+// AsLDValue converts the value to an ldvalue.Value, with the same rules as MarshalJSON.
+func (o OptInt64Between[B]) AsLDValue() ldvalue.Value {
+	return o.opt.AsLDValue()
+}
+
+func (o OptInt64Between[B]) MarshalJSON() ([]byte, error) {
+	return o.opt.MarshalJSON()
+}
+
+// MarshalYAML converts this type to a YAML value, with the same rules as MarshalJSON.
+func (o OptInt64Between[B]) MarshalYAML() (interface{}, error) {
+	return o.opt.MarshalYAML()
+}
+
+// UnmarshalYAML converts a YAML value to this type, with the same rules as UnmarshalJSON.
+func (o *OptInt64Between[B]) UnmarshalYAML(node *yaml.Node) error {
+	return unmarshalYAMLAsJSON(node, o)
+}
+
+// Value implements driver.Valuer, with the same rules as OptInt64.
+func (o OptInt64Between[B]) Value() (driver.Value, error) {
+	return o.opt.Value()
+}
+
+// Scan implements sql.Scanner, with the same rules as OptInt64.
+func (o *OptInt64Between[B]) Scan(src interface{}) error {
+	var opt OptInt64
+	if err := opt.Scan(src); err != nil {
+		return err
+	}
+	value, err := optInt64BetweenFromOptInt64[B](opt)
+	if err == nil {
+		*o = value
+	}
+	return err
+}
+
+// MarshalBinary converts the value to the binary format described in the package documentation.
+func (o OptInt64Between[B]) MarshalBinary() ([]byte, error) {
+	return o.opt.MarshalBinary()
+}
+
+// UnmarshalBinary converts data produced by MarshalBinary, with the same validation as UnmarshalText.
+func (o *OptInt64Between[B]) UnmarshalBinary(data []byte) error {
+	var opt OptInt64
+	if err := opt.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	value, err := optInt64BetweenFromOptInt64[B](opt)
+	if err == nil {
+		*o = value
+	}
+	return err
+}
+
+// GobEncode is the same as MarshalBinary. It allows OptInt64Between to be used with encoding/gob.
+func (o OptInt64Between[B]) GobEncode() ([]byte, error) {
+	return o.MarshalBinary()
+}
+
+// GobDecode is the same as UnmarshalBinary. It allows OptInt64Between to be used with encoding/gob.
+func (o *OptInt64Between[B]) GobDecode(data []byte) error {
+	return o.UnmarshalBinary(data)
+}
+
+func (o OptInt64Between[B]) describeVarFormat() string {
+	return "integer " + o.Range().String()
+}
+
+func (o OptInt64Between[B]) jsonSchema() jsonSchemaObject {
+	return rangeJSONSchema(o.Range(), jsonSchemaObject{"type": "integer"})
+}
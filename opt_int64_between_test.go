@@ -0,0 +1,93 @@
+package configtypes
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func mustOptInt64Between(n int64) OptInt64Between[testInt64Bounds] {
+	o, err := NewOptInt64Between[testInt64Bounds](n)
+	if err != nil {
+		panic(err)
+	}
+	return o
+}
+
+func TestOptInt64Between(t *testing.T) {
+	outOfRange := errOutOfRange("greater than 0")
+
+	t.Run("empty value", func(t *testing.T) {
+		unsetValue := OptInt64Between[testInt64Bounds]{}
+		assertIsDefined(t, false, unsetValue)
+		assert.Equal(t, int64(999), unsetValue.GetOrElse(999))
+		assert.Nil(t, unsetValue.Get())
+	})
+
+	t.Run("defined value", func(t *testing.T) {
+		value, err := NewOptInt64Between[testInt64Bounds](math.MaxInt64)
+		assert.NoError(t, err)
+		assertIsDefined(t, true, value)
+		assert.Equal(t, int64(math.MaxInt64), value.GetOrElse(0))
+		assert.Equal(t, int64(math.MaxInt64), value.Get())
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		value, err := NewOptInt64Between[testInt64Bounds](0)
+		assert.Equal(t, outOfRange, err)
+		assert.Equal(t, OptInt64Between[testInt64Bounds]{}, value)
+	})
+
+	stringCtor := func(input string) (interface{}, error) {
+		o, err := NewOptInt64BetweenFromString[testInt64Bounds](input)
+		return o, err
+	}
+
+	assertConvertToText(t, map[string]textMarshalerAndStringer{
+		"":                    OptInt64Between[testInt64Bounds]{},
+		"9223372036854775807": mustOptInt64Between(math.MaxInt64),
+	})
+
+	assertConvertFromText(t, &OptInt64Between[testInt64Bounds]{}, stringCtor, map[string]interface{}{
+		"":                    OptInt64Between[testInt64Bounds]{},
+		"1":                   mustOptInt64Between(1),
+		"9223372036854775807": mustOptInt64Between(math.MaxInt64),
+	})
+
+	assertConvertFromTextFails(t, &OptInt64Between[testInt64Bounds]{}, stringCtor, errIntFormat(),
+		"-", "0.5", "x",
+	)
+
+	assertConvertFromTextFails(t, &OptInt64Between[testInt64Bounds]{}, stringCtor,
+		errIntOutOfRange(math.MinInt64, math.MaxInt64), "9223372036854775808",
+	)
+
+	assertConvertFromTextFails(t, &OptInt64Between[testInt64Bounds]{}, stringCtor, outOfRange,
+		"0", "-9223372036854775808",
+	)
+
+	assertConvertToJSON(t, map[string]SingleValue{
+		`null`: OptInt64Between[testInt64Bounds]{},
+		`5`:    mustOptInt64Between(5),
+	})
+
+	assertConvertFromJSON(t, &OptInt64Between[testInt64Bounds]{}, map[string]interface{}{
+		`null`: OptInt64Between[testInt64Bounds]{},
+		`5`:    mustOptInt64Between(5),
+		`1e3`:  mustOptInt64Between(1000),
+		`"5"`:  mustOptInt64Between(5),
+	})
+
+	assertConvertFromJSONFails(t, &OptInt64Between[testInt64Bounds]{},
+		`true`, `0`, `0.5`, `"x"`, `[]`, `{}`, `1e100`)
+
+	t.Run("JSON is exact beyond 2^53", func(t *testing.T) {
+		var o OptInt64Between[testInt64Bounds]
+		assert.NoError(t, o.UnmarshalJSON([]byte("9007199254740993")))
+		assert.Equal(t, int64(9007199254740993), o.GetOrElse(0))
+		data, err := o.MarshalJSON()
+		assert.NoError(t, err)
+		assert.Equal(t, "9007199254740993", string(data))
+	})
+}
@@ -0,0 +1,169 @@
+package configtypes
+
+import (
+	"database/sql/driver"
+
+	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"gopkg.in/yaml.v3"
+)
+
+// OptInt64GreaterThanZero represents an optional int64 parameter which, if defined, must be greater than
+// zero.
+//
+// This is the same as OptInt64, but with additional validation for the constructor and unmarshalers. It
+// is impossible (except with reflection) for code outside this package to construct an instance of this
+// type with a defined value that is zero or negative.
+//
+// See the package documentation for the general contract for methods that have no specific documentation
+// here.
+type OptInt64GreaterThanZero struct {
+	opt OptInt64
+}
+
+func NewOptInt64GreaterThanZero(value int64) (OptInt64GreaterThanZero, error) {
+	return optInt64GreaterThanZeroFromOptInt64(NewOptInt64(value))
+}
+
+func NewOptInt64GreaterThanZeroFromString(s string) (OptInt64GreaterThanZero, error) {
+	o, err := NewOptInt64FromString(s)
+	if err != nil {
+		return OptInt64GreaterThanZero{}, err
+	}
+	return optInt64GreaterThanZeroFromOptInt64(o)
+}
+
+// NewOptInt64GreaterThanZeroFromLDValue converts an ldvalue.Value to OptInt64GreaterThanZero, with the
+// same rules as UnmarshalJSON.
+func NewOptInt64GreaterThanZeroFromLDValue(v ldvalue.Value) (OptInt64GreaterThanZero, error) {
+	opt, err := NewOptInt64FromLDValue(v)
+	if err != nil {
+		return OptInt64GreaterThanZero{}, err
+	}
+	return optInt64GreaterThanZeroFromOptInt64(opt)
+}
+
+func optInt64GreaterThanZeroFromOptInt64(o OptInt64) (OptInt64GreaterThanZero, error) {
+	if !o.IsDefined() || o.GetOrElse(0) > 0 {
+		return OptInt64GreaterThanZero{o}, nil
+	}
+	return OptInt64GreaterThanZero{}, errMustBeGreaterThanZero()
+}
+
+func (o OptInt64GreaterThanZero) IsDefined() bool {
+	return o.opt.IsDefined()
+}
+
+func (o OptInt64GreaterThanZero) GetOrElse(orElseValue int64) int64 {
+	return o.opt.GetOrElse(orElseValue)
+}
+
+func (o *OptInt64GreaterThanZero) UnmarshalText(data []byte) error {
+	var opt OptInt64
+	if err := opt.UnmarshalText(data); err != nil {
+		return err
+	}
+	value, err := optInt64GreaterThanZeroFromOptInt64(opt)
+	if err == nil {
+		*o = value
+	}
+	return err
+}
+
+// Set is the same as UnmarshalText. It allows OptInt64GreaterThanZero to be used as a flag.Value.
+func (o *OptInt64GreaterThanZero) Set(s string) error {
+	return o.UnmarshalText([]byte(s))
+}
+
+// Get returns the value as an int64, or nil if it is not defined. It allows OptInt64GreaterThanZero to
+// be used as a flag.Getter.
+func (o OptInt64GreaterThanZero) Get() interface{} {
+	if !o.IsDefined() {
+		return nil
+	}
+	return o.GetOrElse(0)
+}
+
+func (o OptInt64GreaterThanZero) String() string {
+	return o.opt.String()
+}
+
+func (o OptInt64GreaterThanZero) MarshalText() ([]byte, error) {
+	return o.opt.MarshalText()
+}
+
+func (o *OptInt64GreaterThanZero) UnmarshalJSON(data []byte) error {
+	var opt OptInt64
+	if err := opt.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	value, err := optInt64GreaterThanZeroFromOptInt64(opt)
+	if err == nil {
+		*o = value
+	}
+	return err
+}
+
+// AsLDValue converts the value to an ldvalue.Value, with the same rules as MarshalJSON.
+func (o OptInt64GreaterThanZero) AsLDValue() ldvalue.Value {
+	return o.opt.AsLDValue()
+}
+
+func (o OptInt64GreaterThanZero) MarshalJSON() ([]byte, error) {
+	return o.opt.MarshalJSON()
+}
+
+// MarshalYAML converts this type to a YAML value, with the same rules as MarshalJSON.
+func (o OptInt64GreaterThanZero) MarshalYAML() (interface{}, error) {
+	return o.opt.MarshalYAML()
+}
+
+// UnmarshalYAML converts a YAML value to this type, with the same rules as UnmarshalJSON.
+func (o *OptInt64GreaterThanZero) UnmarshalYAML(node *yaml.Node) error {
+	return unmarshalYAMLAsJSON(node, o)
+}
+
+// Value implements driver.Valuer, with the same rules as OptInt64.
+func (o OptInt64GreaterThanZero) Value() (driver.Value, error) {
+	return o.opt.Value()
+}
+
+// Scan implements sql.Scanner, with the same rules as OptInt64.
+func (o *OptInt64GreaterThanZero) Scan(src interface{}) error {
+	var opt OptInt64
+	if err := opt.Scan(src); err != nil {
+		return err
+	}
+	value, err := optInt64GreaterThanZeroFromOptInt64(opt)
+	if err == nil {
+		*o = value
+	}
+	return err
+}
+
+// MarshalBinary converts the value to the binary format described in the package documentation.
+func (o OptInt64GreaterThanZero) MarshalBinary() ([]byte, error) {
+	return o.opt.MarshalBinary()
+}
+
+// UnmarshalBinary converts data produced by MarshalBinary, with the same validation as UnmarshalText.
+func (o *OptInt64GreaterThanZero) UnmarshalBinary(data []byte) error {
+	var opt OptInt64
+	if err := opt.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	value, err := optInt64GreaterThanZeroFromOptInt64(opt)
+	if err == nil {
+		*o = value
+	}
+	return err
+}
+
+// GobEncode is the same as MarshalBinary. It allows OptInt64GreaterThanZero to be used with encoding/gob.
+func (o OptInt64GreaterThanZero) GobEncode() ([]byte, error) {
+	return o.MarshalBinary()
+}
+
+// GobDecode is the same as UnmarshalBinary. It allows OptInt64GreaterThanZero to be used with encoding/gob.
+func (o *OptInt64GreaterThanZero) GobDecode(data []byte) error {
+	return o.UnmarshalBinary(data)
+}
@@ -0,0 +1,84 @@
+package configtypes
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func mustOptInt64GreaterThanZero(n int64) OptInt64GreaterThanZero {
+	o, err := NewOptInt64GreaterThanZero(n)
+	if err != nil {
+		panic(err)
+	}
+	return o
+}
+
+func TestOptInt64GreaterThanZero(t *testing.T) {
+	t.Run("empty value", func(t *testing.T) {
+		unsetValue := OptInt64GreaterThanZero{}
+		assertIsDefined(t, false, unsetValue)
+		assert.Equal(t, int64(999), unsetValue.GetOrElse(999))
+	})
+
+	t.Run("defined value", func(t *testing.T) {
+		oneValue, err := NewOptInt64GreaterThanZero(1)
+		assert.NoError(t, err)
+		assertIsDefined(t, true, oneValue)
+		assert.Equal(t, int64(1), oneValue.GetOrElse(0))
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		zeroValue, err := NewOptInt64GreaterThanZero(0)
+		assert.Equal(t, errMustBeGreaterThanZero(), err)
+		assert.Equal(t, OptInt64GreaterThanZero{}, zeroValue)
+
+		negativeValue, err := NewOptInt64GreaterThanZero(-1)
+		assert.Equal(t, errMustBeGreaterThanZero(), err)
+		assert.Equal(t, OptInt64GreaterThanZero{}, negativeValue)
+	})
+
+	stringCtor := func(input string) (interface{}, error) {
+		o, err := NewOptInt64GreaterThanZeroFromString(input)
+		return o, err
+	}
+
+	assertConvertToText(t, map[string]textMarshalerAndStringer{
+		"": OptInt64GreaterThanZero{}, "100": mustOptInt64GreaterThanZero(100),
+	})
+
+	assertConvertFromText(t, &OptInt64GreaterThanZero{}, stringCtor, map[string]interface{}{
+		"": OptInt64GreaterThanZero{}, "100": mustOptInt64GreaterThanZero(100),
+	})
+
+	assertConvertFromTextFails(t, &OptInt64GreaterThanZero{}, stringCtor, errIntFormat(),
+		"-", "0.5", "x",
+	)
+
+	assertConvertFromTextFails(t, &OptInt64GreaterThanZero{}, stringCtor, errMustBeGreaterThanZero(),
+		"0", "-1",
+	)
+
+	assertConvertFromTextFails(t, &OptInt64GreaterThanZero{}, stringCtor,
+		errIntOutOfRange(math.MinInt64, math.MaxInt64), "9223372036854775808",
+	)
+
+	assertConvertToJSON(t, map[string]SingleValue{
+		`null`: OptInt64GreaterThanZero{}, `100`: mustOptInt64GreaterThanZero(100),
+	})
+
+	assertConvertFromJSON(t, &OptInt64GreaterThanZero{}, map[string]interface{}{
+		`null`: OptInt64GreaterThanZero{}, `100`: mustOptInt64GreaterThanZero(100),
+		`"100"`: mustOptInt64GreaterThanZero(100),
+	})
+
+	assertConvertFromJSONFails(t, &OptInt64GreaterThanZero{},
+		`true`, `0`, `-1`, `"0"`, `0.5`, `"x"`, `[]`, `{}`)
+
+	t.Run("JSON is exact beyond 2^53", func(t *testing.T) {
+		var o OptInt64GreaterThanZero
+		assert.NoError(t, o.UnmarshalJSON([]byte("9007199254740993")))
+		assert.Equal(t, mustOptInt64GreaterThanZero(9007199254740993), o)
+	})
+}
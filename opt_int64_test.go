@@ -0,0 +1,108 @@
+package configtypes
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptInt64(t *testing.T) {
+	outOfRange := errIntOutOfRange(math.MinInt64, math.MaxInt64)
+
+	t.Run("empty value", func(t *testing.T) {
+		unsetValue := OptInt64{}
+		assertIsDefined(t, false, unsetValue)
+		assert.Equal(t, int64(999), unsetValue.GetOrElse(999))
+		assert.Nil(t, unsetValue.Get())
+	})
+
+	t.Run("defined value", func(t *testing.T) {
+		zeroValue := NewOptInt64(0)
+		assertIsDefined(t, true, zeroValue)
+		assert.Equal(t, int64(0), zeroValue.GetOrElse(999))
+
+		minValue := NewOptInt64(math.MinInt64)
+		assertIsDefined(t, true, minValue)
+		assert.Equal(t, int64(math.MinInt64), minValue.GetOrElse(0))
+
+		maxValue := NewOptInt64(math.MaxInt64)
+		assertIsDefined(t, true, maxValue)
+		assert.Equal(t, int64(math.MaxInt64), maxValue.GetOrElse(0))
+		assert.Equal(t, int64(math.MaxInt64), maxValue.Get())
+	})
+
+	stringCtor := func(input string) (interface{}, error) {
+		o, err := NewOptInt64FromString(input)
+		return o, err
+	}
+
+	assertConvertToText(t, map[string]textMarshalerAndStringer{
+		"":                     OptInt64{},
+		"0":                    NewOptInt64(0),
+		"-100":                 NewOptInt64(-100),
+		"9223372036854775807":  NewOptInt64(math.MaxInt64),
+		"-9223372036854775808": NewOptInt64(math.MinInt64),
+	})
+
+	assertConvertFromText(t, &OptInt64{}, stringCtor, map[string]interface{}{
+		"":                     OptInt64{},
+		"0":                    NewOptInt64(0),
+		"+100":                 NewOptInt64(100),
+		"-100":                 NewOptInt64(-100),
+		"9223372036854775807":  NewOptInt64(math.MaxInt64),
+		"-9223372036854775808": NewOptInt64(math.MinInt64),
+	})
+
+	assertConvertFromTextFails(t, &OptInt64{}, stringCtor, errIntFormat(),
+		"-", "0.5", "1e3", "x", " 1", "0x10",
+	)
+
+	assertConvertFromTextFails(t, &OptInt64{}, stringCtor, outOfRange,
+		"9223372036854775808", "-9223372036854775809", "100000000000000000000",
+	)
+
+	assertConvertToJSON(t, map[string]SingleValue{
+		`null`: OptInt64{}, `0`: NewOptInt64(0), `100`: NewOptInt64(100), `-100`: NewOptInt64(-100),
+	})
+
+	assertConvertFromJSON(t, &OptInt64{}, map[string]interface{}{
+		`null`:   OptInt64{},
+		`0`:      NewOptInt64(0),
+		`-100`:   NewOptInt64(-100),
+		`1e3`:    NewOptInt64(1000),
+		`1.0`:    NewOptInt64(1),
+		`"100"`:  NewOptInt64(100),
+		`"-100"`: NewOptInt64(-100),
+	})
+
+	assertConvertFromJSONFails(t, &OptInt64{},
+		`true`, `0.5`, `""`, `"x"`, `"1.0"`, `[]`, `{}`, `1e100`, `"9223372036854775808"`)
+
+	t.Run("JSON is exact beyond 2^53", func(t *testing.T) {
+		for input, expected := range map[string]int64{
+			`9007199254740993`:       9007199254740993,
+			`9223372036854775807`:    math.MaxInt64,
+			`-9223372036854775808`:   math.MinInt64,
+			`"9223372036854775807"`:  math.MaxInt64,
+			`9.007199254740993e15`:   9007199254740993,
+			`-9223372036854775808.0`: math.MinInt64,
+		} {
+			var o OptInt64
+			assert.NoError(t, o.UnmarshalJSON([]byte(input)), input)
+			assert.Equal(t, NewOptInt64(expected), o, input)
+		}
+
+		data, err := NewOptInt64(math.MaxInt64).MarshalJSON()
+		assert.NoError(t, err)
+		assert.Equal(t, "9223372036854775807", string(data))
+	})
+
+	t.Run("JSON out of range", func(t *testing.T) {
+		var o OptInt64
+		assert.Equal(t, outOfRange, o.UnmarshalJSON([]byte(`9223372036854775808`)))
+		assert.Equal(t, outOfRange, o.UnmarshalJSON([]byte(`"-9223372036854775809"`)))
+		assert.Equal(t, outOfRange, o.UnmarshalJSON([]byte(`1e1000000000`)))
+		assert.Equal(t, errIntFormat(), o.UnmarshalJSON([]byte(`9223372036854775807.5`)))
+	})
+}
@@ -0,0 +1,196 @@
+package configtypes
+
+import (
+	"database/sql/driver"
+
+	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"gopkg.in/yaml.v3"
+)
+
+// OptIntBetween represents an optional int parameter which, if defined, must be within the range
+// specified by the Bounds type B.
+//
+// This is the same as OptInt, but with additional validation for the constructor and unmarshalers, like
+// OptIntGreaterThanZero. Since the range is part of the type, it is impossible (except with reflection)
+// for code outside this package to construct an instance of OptIntBetween[B] with a defined value that
+// is out of range. The error for such a value describes the range, such as "value must be between 1 and
+// 65535".
+//
+//	type PortBounds struct{}
+//
+//	func (PortBounds) Range() configtypes.NumericRange[int] {
+//	    return configtypes.RangeBetween(1, 65535)
+//	}
+//
+//	type Config struct {
+//	    Port configtypes.OptIntBetween[PortBounds] `conf:"PORT"`
+//	}
+//
+// See the package documentation for the general contract for methods that have no specific documentation
+// here.
+type OptIntBetween[B Bounds[int]] struct {
+	opt OptInt
+}
+
+func NewOptIntBetween[B Bounds[int]](value int) (OptIntBetween[B], error) {
+	return optIntBetweenFromOptInt[B](NewOptInt(value))
+}
+
+func NewOptIntBetweenFromString[B Bounds[int]](s string) (OptIntBetween[B], error) {
+	o, err := NewOptIntFromString(s)
+	if err != nil {
+		return OptIntBetween[B]{}, err
+	}
+	return optIntBetweenFromOptInt[B](o)
+}
+
+// NewOptIntBetweenFromLDValue converts an ldvalue.Value to OptIntBetween, with the same rules as
+// UnmarshalJSON.
+func NewOptIntBetweenFromLDValue[B Bounds[int]](
+	v ldvalue.Value,
+) (OptIntBetween[B], error) {
+	opt, err := NewOptIntFromLDValue(v)
+	if err != nil {
+		return OptIntBetween[B]{}, err
+	}
+	return optIntBetweenFromOptInt[B](opt)
+}
+
+func optIntBetweenFromOptInt[B Bounds[int]](o OptInt) (OptIntBetween[B], error) {
+	if err := checkRange[int, B](o.IsDefined(), o.GetOrElse(0)); err != nil {
+		return OptIntBetween[B]{}, err
+	}
+	return OptIntBetween[B]{o}, nil
+}
+
+func (o OptIntBetween[B]) IsDefined() bool {
+	return o.opt.IsDefined()
+}
+
+func (o OptIntBetween[B]) GetOrElse(orElseValue int) int {
+	return o.opt.GetOrElse(orElseValue)
+}
+
+// Range returns the range specified by B.
+func (o OptIntBetween[B]) Range() NumericRange[int] {
+	return rangeOf[int, B]()
+}
+
+func (o *OptIntBetween[B]) UnmarshalText(data []byte) error {
+	var opt OptInt
+	if err := opt.UnmarshalText(data); err != nil {
+		return err
+	}
+	value, err := optIntBetweenFromOptInt[B](opt)
+	if err == nil {
+		*o = value
+	}
+	return err
+}
+
+// Set is the same as UnmarshalText. It allows OptIntBetween to be used as a flag.Value.
+func (o *OptIntBetween[B]) Set(s string) error {
+	return o.UnmarshalText([]byte(s))
+}
+
+// Get returns the value as an int, or nil if it is not defined. It allows OptIntBetween to be used as a
+// flag.Getter.
+func (o OptIntBetween[B]) Get() interface{} {
+	if !o.IsDefined() {
+		return nil
+	}
+	return o.GetOrElse(0)
+}
+
+func (o OptIntBetween[B]) String() string {
+	return o.opt.String()
+}
+
+func (o OptIntBetween[B]) MarshalText() ([]byte, error) {
+	return o.opt.MarshalText()
+}
+
+func (o *OptIntBetween[B]) UnmarshalJSON(data []byte) error {
+	var v ldvalue.Value
+	if err := v.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	opt, err := NewOptIntBetweenFromLDValue[B](v)
+	if err == nil {
+		*o = opt
+	}
+	return err
+}
+
+// AsLDValue converts the value to an ldvalue.Value, with the same rules as MarshalJSON.
+func (o OptIntBetween[B]) AsLDValue() ldvalue.Value {
+	return o.opt.AsLDValue()
+}
+
+func (o OptIntBetween[B]) MarshalJSON() ([]byte, error) {
+	return o.opt.MarshalJSON()
+}
+
+// MarshalYAML converts this type to a YAML value, with the same rules as MarshalJSON.
+func (o OptIntBetween[B]) MarshalYAML() (interface{}, error) {
+	return o.opt.MarshalYAML()
+}
+
+// UnmarshalYAML converts a YAML value to this type, with the same rules as UnmarshalJSON.
+func (o *OptIntBetween[B]) UnmarshalYAML(node *yaml.Node) error {
+	return unmarshalYAMLAsJSON(node, o)
+}
+
+// Value implements driver.Valuer, with the same rules as OptInt.
+func (o OptIntBetween[B]) Value() (driver.Value, error) {
+	return o.opt.Value()
+}
+
+// Scan implements sql.Scanner, with the same rules as OptInt.
+func (o *OptIntBetween[B]) Scan(src interface{}) error {
+	var opt OptInt
+	if err := opt.Scan(src); err != nil {
+		return err
+	}
+	value, err := optIntBetweenFromOptInt[B](opt)
+	if err == nil {
+		*o = value
+	}
+	return err
+}
+
+// MarshalBinary converts the value to the binary format described in the package documentation.
+func (o OptIntBetween[B]) MarshalBinary() ([]byte, error) {
+	return o.opt.MarshalBinary()
+}
+
+// UnmarshalBinary converts data produced by MarshalBinary, with the same validation as UnmarshalText.
+func (o *OptIntBetween[B]) UnmarshalBinary(data []byte) error {
+	var opt OptInt
+	if err := opt.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	value, err := optIntBetweenFromOptInt[B](opt)
+	if err == nil {
+		*o = value
+	}
+	return err
+}
+
+// GobEncode is the same as MarshalBinary. It allows OptIntBetween to be used with encoding/gob.
+func (o OptIntBetween[B]) GobEncode() ([]byte, error) {
+	return o.MarshalBinary()
+}
+
+// GobDecode is the same as UnmarshalBinary. It allows OptIntBetween to be used with encoding/gob.
+func (o *OptIntBetween[B]) GobDecode(data []byte) error {
+	return o.UnmarshalBinary(data)
+}
+
+func (o OptIntBetween[B]) describeVarFormat() string {
+	return "integer " + o.Range().String()
+}
+
+func (o OptIntBetween[B]) jsonSchema() jsonSchemaObject {
+	return rangeJSONSchema(o.Range(), jsonSchemaObject{"type": "integer"})
+}
@@ -0,0 +1,74 @@
+package configtypes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func mustOptIntBetween(n int) OptIntBetween[testIntBounds] {
+	o, err := NewOptIntBetween[testIntBounds](n)
+	if err != nil {
+		panic(err)
+	}
+	return o
+}
+
+func TestOptIntBetween(t *testing.T) {
+	outOfRange := errOutOfRange("between 1 and 10")
+
+	t.Run("empty value", func(t *testing.T) {
+		unsetValue := OptIntBetween[testIntBounds]{}
+		assertIsDefined(t, false, unsetValue)
+		assert.Equal(t, 999, unsetValue.GetOrElse(999))
+		assert.Equal(t, RangeBetween(1, 10), unsetValue.Range())
+	})
+
+	t.Run("defined value", func(t *testing.T) {
+		value, err := NewOptIntBetween[testIntBounds](10)
+		assert.NoError(t, err)
+		assertIsDefined(t, true, value)
+		assert.Equal(t, 10, value.GetOrElse(0))
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		for _, n := range []int{0, 11} {
+			value, err := NewOptIntBetween[testIntBounds](n)
+			assert.Equal(t, outOfRange, err)
+			assert.Equal(t, OptIntBetween[testIntBounds]{}, value)
+		}
+		assert.Equal(t, "value must be between 1 and 10", outOfRange.Error())
+	})
+
+	stringCtor := func(input string) (interface{}, error) {
+		o, err := NewOptIntBetweenFromString[testIntBounds](input)
+		return o, err
+	}
+
+	assertConvertToText(t, map[string]textMarshalerAndStringer{
+		"": OptIntBetween[testIntBounds]{}, "5": mustOptIntBetween(5),
+	})
+
+	assertConvertFromText(t, &OptIntBetween[testIntBounds]{}, stringCtor, map[string]interface{}{
+		"": OptIntBetween[testIntBounds]{}, "1": mustOptIntBetween(1), "10": mustOptIntBetween(10),
+	})
+
+	assertConvertFromTextFails(t, &OptIntBetween[testIntBounds]{}, stringCtor, errIntFormat(),
+		"-", "0.5", "x",
+	)
+
+	assertConvertFromTextFails(t, &OptIntBetween[testIntBounds]{}, stringCtor, outOfRange,
+		"0", "11",
+	)
+
+	assertConvertToJSON(t, map[string]SingleValue{
+		`null`: OptIntBetween[testIntBounds]{}, `5`: mustOptIntBetween(5),
+	})
+
+	assertConvertFromJSON(t, &OptIntBetween[testIntBounds]{}, map[string]interface{}{
+		`null`: OptIntBetween[testIntBounds]{}, `5`: mustOptIntBetween(5),
+	})
+
+	assertConvertFromJSONFails(t, &OptIntBetween[testIntBounds]{},
+		`true`, `0`, `11`, `0.5`, `"5"`, `[]`, `{}`)
+}
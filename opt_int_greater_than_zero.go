@@ -1,5 +1,12 @@
 package configtypes
 
+import (
+	"database/sql/driver"
+
+	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"gopkg.in/yaml.v3"
+)
+
 // OptIntGreaterThanZero represents an optional int parameter which, if defined, must be greater than zero.
 //
 // This is the same as OptInt, but with additional validation for the constructor and unmarshalers. It
@@ -24,6 +31,16 @@ func NewOptIntGreaterThanZeroFromString(s string) (OptIntGreaterThanZero, error)
 	return optIntGreaterThanZeroFromOptInt(o)
 }
 
+// NewOptIntGreaterThanZeroFromLDValue converts an ldvalue.Value to OptIntGreaterThanZero, with the same rules as
+// UnmarshalJSON.
+func NewOptIntGreaterThanZeroFromLDValue(v ldvalue.Value) (OptIntGreaterThanZero, error) {
+	opt, err := NewOptIntFromLDValue(v)
+	if err != nil {
+		return OptIntGreaterThanZero{}, err
+	}
+	return optIntGreaterThanZeroFromOptInt(opt)
+}
+
 func optIntGreaterThanZeroFromOptInt(o OptInt) (OptIntGreaterThanZero, error) {
 	if !o.IsDefined() || o.GetOrElse(0) > 0 {
 		return OptIntGreaterThanZero{o}, nil
@@ -51,6 +68,20 @@ func (o *OptIntGreaterThanZero) UnmarshalText(data []byte) error {
 	return err
 }
 
+// Set is the same as UnmarshalText. It allows OptIntGreaterThanZero to be used as a flag.Value.
+func (o *OptIntGreaterThanZero) Set(s string) error {
+	return o.UnmarshalText([]byte(s))
+}
+
+// Get returns the value as an int, or nil if it is not defined. It allows OptIntGreaterThanZero to
+// be used as a flag.Getter.
+func (o OptIntGreaterThanZero) Get() interface{} {
+	if !o.IsDefined() {
+		return nil
+	}
+	return o.GetOrElse(0)
+}
+
 func (o OptIntGreaterThanZero) String() string {
 	return o.opt.String()
 }
@@ -60,8 +91,45 @@ func (o OptIntGreaterThanZero) MarshalText() ([]byte, error) {
 }
 
 func (o *OptIntGreaterThanZero) UnmarshalJSON(data []byte) error {
+	var v ldvalue.Value
+	if err := v.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	opt, err := NewOptIntGreaterThanZeroFromLDValue(v)
+	if err == nil {
+		*o = opt
+	}
+	return err
+}
+
+// AsLDValue converts the value to an ldvalue.Value, with the same rules as MarshalJSON.
+func (o OptIntGreaterThanZero) AsLDValue() ldvalue.Value {
+	return o.opt.AsLDValue()
+}
+
+func (o OptIntGreaterThanZero) MarshalJSON() ([]byte, error) {
+	return o.opt.MarshalJSON()
+}
+
+// MarshalYAML converts this type to a YAML value, with the same rules as MarshalJSON.
+func (o OptIntGreaterThanZero) MarshalYAML() (interface{}, error) {
+	return o.opt.MarshalYAML()
+}
+
+// UnmarshalYAML converts a YAML value to this type, with the same rules as UnmarshalJSON.
+func (o *OptIntGreaterThanZero) UnmarshalYAML(node *yaml.Node) error {
+	return unmarshalYAMLAsJSON(node, o)
+}
+
+// Value implements driver.Valuer, with the same rules as OptInt.
+func (o OptIntGreaterThanZero) Value() (driver.Value, error) {
+	return o.opt.Value()
+}
+
+// Scan implements sql.Scanner, with the same rules as OptInt.
+func (o *OptIntGreaterThanZero) Scan(src interface{}) error {
 	var opt OptInt
-	if err := opt.UnmarshalJSON(data); err != nil {
+	if err := opt.Scan(src); err != nil {
 		return err
 	}
 	value, err := optIntGreaterThanZeroFromOptInt(opt)
@@ -71,6 +139,30 @@ func (o *OptIntGreaterThanZero) UnmarshalJSON(data []byte) error {
 	return err
 }
 
-func (o OptIntGreaterThanZero) MarshalJSON() ([]byte, error) {
-	return o.opt.MarshalJSON()
+// MarshalBinary converts the value to the binary format described in the package documentation.
+func (o OptIntGreaterThanZero) MarshalBinary() ([]byte, error) {
+	return o.opt.MarshalBinary()
+}
+
+// UnmarshalBinary converts data produced by MarshalBinary, with the same validation as UnmarshalText.
+func (o *OptIntGreaterThanZero) UnmarshalBinary(data []byte) error {
+	var opt OptInt
+	if err := opt.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	value, err := optIntGreaterThanZeroFromOptInt(opt)
+	if err == nil {
+		*o = value
+	}
+	return err
+}
+
+// GobEncode is the same as MarshalBinary. It allows OptIntGreaterThanZero to be used with encoding/gob.
+func (o OptIntGreaterThanZero) GobEncode() ([]byte, error) {
+	return o.MarshalBinary()
+}
+
+// GobDecode is the same as UnmarshalBinary. It allows OptIntGreaterThanZero to be used with encoding/gob.
+func (o *OptIntGreaterThanZero) GobDecode(data []byte) error {
+	return o.UnmarshalBinary(data)
 }
@@ -0,0 +1,233 @@
+package configtypes
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"unicode/utf8"
+
+	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	redactedSecretPrefix = "****"
+
+	// A redacted secret only shows its last few characters if it is long enough that this does not
+	// reveal too much of it.
+	redactedSecretMinLengthForSuffix = 12
+	redactedSecretSuffixLength       = 4
+)
+
+// OptSecret represents an optional string parameter whose value is sensitive, such as a password or
+// an API key.
+//
+// Every way of converting an OptSecret to a string, other than the Reveal method, produces a redacted
+// form such as "****abcd", so that the value will not be exposed if a configuration struct is logged
+// or serialized. That includes String, MarshalText, MarshalJSON, GoString, fmt formatting with any
+// verb, and the slog.LogValuer interface. Since the redacted form is not the same as the value,
+// converting an OptSecret to text or JSON and back does not produce the same value; use Reveal if
+// you need to do that. VarWriter only writes secrets if you have opted in with WithSecretsRevealed.
+//
+// When converting from a string, an empty string becomes an undefined value. When converting to or
+// from JSON, the value must be either a JSON null or a JSON string.
+//
+// See the package documentation for the general contract for methods that have no specific documentation
+// here.
+type OptSecret struct {
+	value string
+}
+
+// NewOptSecret returns a defined OptSecret with the specified value, or an empty OptSecret if the
+// value is "".
+func NewOptSecret(value string) OptSecret {
+	return OptSecret{value: value}
+}
+
+// NewOptSecretFromLDValue converts an ldvalue.Value to OptSecret, with the same rules as UnmarshalJSON.
+func NewOptSecretFromLDValue(v ldvalue.Value) (OptSecret, error) {
+	switch {
+	case v.IsNull():
+		return OptSecret{}, nil
+	case v.IsString():
+		return NewOptSecret(v.StringValue()), nil
+	default:
+		return OptSecret{}, errStringFormat()
+	}
+}
+
+func (o OptSecret) IsDefined() bool {
+	return o.value != ""
+}
+
+// Reveal returns the actual value of the secret, or "" if it is empty.
+func (o OptSecret) Reveal() string {
+	return o.value
+}
+
+// String returns the redacted form of the secret, or "" if it is empty.
+func (o OptSecret) String() string {
+	return redactSecret(o.value)
+}
+
+// GoString returns the redacted form of the secret in a format that is used by the %#v verb.
+func (o OptSecret) GoString() string {
+	return secretGoString("OptSecret", o.value)
+}
+
+// Format implements fmt.Formatter so that the secret is redacted regardless of the formatting verb.
+func (o OptSecret) Format(f fmt.State, verb rune) {
+	formatSecret(f, verb, o)
+}
+
+// LogValue implements slog.LogValuer so that the secret is redacted in structured logs.
+func (o OptSecret) LogValue() slog.Value {
+	return slog.StringValue(o.String())
+}
+
+// MarshalText returns the redacted form of the secret, or an empty value if it is empty.
+func (o OptSecret) MarshalText() ([]byte, error) {
+	return []byte(o.String()), nil
+}
+
+func (o *OptSecret) UnmarshalText(data []byte) error {
+	*o = NewOptSecret(string(data))
+	return nil // cannot fail
+}
+
+// Set is the same as UnmarshalText. It allows OptSecret to be used as a flag.Value.
+func (o *OptSecret) Set(s string) error {
+	return o.UnmarshalText([]byte(s))
+}
+
+// Get returns the OptSecret itself, rather than the actual value, so that the value stays redacted
+// if it is printed. It allows OptSecret to be used as a flag.Getter.
+func (o OptSecret) Get() interface{} {
+	return o
+}
+
+// AsLDValue converts the value to an ldvalue.Value, with the same rules as MarshalJSON: the result is
+// the redacted form of the value, so that it is not exposed if it is used in an evaluation context.
+func (o OptSecret) AsLDValue() ldvalue.Value {
+	if !o.IsDefined() {
+		return ldvalue.Null()
+	}
+	return ldvalue.String(o.String())
+}
+
+// MarshalJSON returns the redacted form of the secret as a JSON string, or a JSON null if it is
+// empty.
+func (o OptSecret) MarshalJSON() ([]byte, error) {
+	if !o.IsDefined() {
+		return json.Marshal(nil)
+	}
+	return json.Marshal(o.String())
+}
+
+func (o *OptSecret) UnmarshalJSON(data []byte) error {
+	var s ldvalue.OptionalString
+	if err := s.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	*o = NewOptSecret(s.StringValue())
+	return nil
+}
+
+// redactSecret returns the redacted form of a secret value, which is "" if the value is "".
+func redactSecret(value string) string {
+	if value == "" {
+		return ""
+	}
+	if utf8.RuneCountInString(value) < redactedSecretMinLengthForSuffix {
+		return redactedSecretPrefix
+	}
+	runes := []rune(value)
+	return redactedSecretPrefix + string(runes[len(runes)-redactedSecretSuffixLength:])
+}
+
+func secretGoString(typeName, value string) string {
+	if value == "" {
+		return "configtypes." + typeName + "{}"
+	}
+	return fmt.Sprintf("configtypes.%s{%s}", typeName, redactSecret(value))
+}
+
+func formatSecret(f fmt.State, verb rune, secret interface {
+	fmt.Stringer
+	fmt.GoStringer
+}) {
+	switch {
+	case verb == 'v' && f.Flag('#'):
+		_, _ = io.WriteString(f, secret.GoString())
+	case verb == 'q':
+		_, _ = fmt.Fprintf(f, "%q", secret.String())
+	default:
+		_, _ = io.WriteString(f, secret.String())
+	}
+}
+
+// MarshalYAML converts this type to a YAML value, with the same rules as MarshalJSON.
+func (o OptSecret) MarshalYAML() (interface{}, error) {
+	if o.IsDefined() {
+		return o.String(), nil
+	}
+	return nil, nil //nolint:nilnil
+}
+
+// UnmarshalYAML converts a YAML value to this type, with the same rules as UnmarshalJSON.
+func (o *OptSecret) UnmarshalYAML(node *yaml.Node) error {
+	return unmarshalYAMLAsJSON(node, o)
+}
+
+// Value implements driver.Valuer, so that the value can be written to a database with database/sql.
+// An empty value is NULL; otherwise it is a string. Unlike the other ways of converting an OptSecret,
+// this uses the actual value rather than the redacted form, since otherwise the value could not be
+// read back from the database.
+func (o OptSecret) Value() (driver.Value, error) {
+	if !o.IsDefined() {
+		return nil, nil //nolint:nilnil
+	}
+	return o.value, nil
+}
+
+// Scan implements sql.Scanner, so that the value can be read from a database with database/sql. NULL
+// or an empty string becomes an empty value.
+func (o *OptSecret) Scan(src interface{}) error {
+	if src == nil {
+		*o = OptSecret{}
+		return nil
+	}
+	return scanSQLText(src, o)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. Unlike MarshalText and MarshalJSON, it uses the
+// actual value rather than the redacted form, so the result should be treated as sensitive.
+func (o OptSecret) MarshalBinary() ([]byte, error) {
+	return marshalOptBinary(o.IsDefined(), []byte(o.value)), nil
+}
+
+// UnmarshalBinary converts data produced by MarshalBinary, with the same validation as UnmarshalText.
+func (o *OptSecret) UnmarshalBinary(data []byte) error {
+	payload, defined, err := unmarshalOptBinary(data)
+	switch {
+	case err != nil:
+		return err
+	case !defined:
+		*o = OptSecret{}
+	default:
+		*o = NewOptSecret(string(payload))
+	}
+	return nil
+}
+
+// GobEncode is the same as MarshalBinary. It allows OptSecret to be used with encoding/gob.
+func (o OptSecret) GobEncode() ([]byte, error) {
+	return o.MarshalBinary()
+}
+
+// GobDecode is the same as UnmarshalBinary. It allows OptSecret to be used with encoding/gob.
+func (o *OptSecret) GobDecode(data []byte) error {
+	return o.UnmarshalBinary(data)
+}
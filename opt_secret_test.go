@@ -0,0 +1,110 @@
+package configtypes
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testLongSecret  = "sdk-12345678-abcd"
+	testShortSecret = "hunter2"
+)
+
+func TestOptSecret(t *testing.T) {
+	t.Run("empty value", func(t *testing.T) {
+		unsetValue := OptSecret{}
+		assertIsDefined(t, false, unsetValue)
+		assert.Equal(t, "", unsetValue.Reveal())
+
+		emptyString := NewOptSecret("")
+		assert.Equal(t, unsetValue, emptyString)
+	})
+
+	t.Run("defined value", func(t *testing.T) {
+		o := NewOptSecret(testLongSecret)
+		assertIsDefined(t, true, o)
+		assert.Equal(t, testLongSecret, o.Reveal())
+	})
+
+	t.Run("redaction", func(t *testing.T) {
+		assert.Equal(t, "", redactSecret(""))
+		assert.Equal(t, "****", redactSecret("a"))
+		assert.Equal(t, "****", redactSecret(testShortSecret))
+		assert.Equal(t, "****", redactSecret("12345678901"))
+		assert.Equal(t, "****9012", redactSecret("123456789012"))
+		assert.Equal(t, "****abcd", redactSecret(testLongSecret))
+		assert.Equal(t, "****çøñƒ", redactSecret("ábcdéfgh-çøñƒ"))
+	})
+
+	t.Run("formatting", func(t *testing.T) {
+		o := NewOptSecret(testLongSecret)
+		assert.Equal(t, "****abcd", fmt.Sprintf("%v", o))
+		assert.Equal(t, "****abcd", fmt.Sprintf("%s", o))
+		assert.Equal(t, "****abcd", fmt.Sprintf("%d", o))
+		assert.Equal(t, "****abcd", fmt.Sprintf("%x", o))
+		assert.Equal(t, `"****abcd"`, fmt.Sprintf("%q", o))
+		assert.Equal(t, "configtypes.OptSecret{****abcd}", fmt.Sprintf("%#v", o))
+		assert.Equal(t, "configtypes.OptSecret{}", fmt.Sprintf("%#v", OptSecret{}))
+		assert.Equal(t, "****abcd", fmt.Sprint(&o))
+	})
+
+	t.Run("formatting a containing struct", func(t *testing.T) {
+		s := struct {
+			Name OptString
+			Key  OptSecret
+			Ptr  *OptSecret
+		}{NewOptString("x"), NewOptSecret(testLongSecret), &OptSecret{testShortSecret}}
+		for _, format := range []string{"%v", "%+v", "%#v", "%s"} {
+			out := fmt.Sprintf(format, s)
+			assert.NotContains(t, out, testLongSecret, format)
+			assert.NotContains(t, out, "1234", format)
+			assert.Contains(t, out, "****abcd", format)
+		}
+		assert.NotContains(t, fmt.Sprintf("%+v", *s.Ptr), testShortSecret)
+	})
+
+	t.Run("JSON of a containing struct", func(t *testing.T) {
+		data, err := json.Marshal(struct {
+			Key   OptSecret
+			Empty OptSecret
+		}{Key: NewOptSecret(testLongSecret)})
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"Key": "****abcd", "Empty": null}`, string(data))
+	})
+
+	t.Run("slog", func(t *testing.T) {
+		var buf bytes.Buffer
+		logger := slog.New(slog.NewTextHandler(&buf, nil))
+		logger.Info("config", "key", NewOptSecret(testLongSecret))
+		assert.NotContains(t, buf.String(), testLongSecret)
+		assert.Contains(t, buf.String(), "key=****abcd")
+	})
+
+	stringCtor := func(input string) (interface{}, error) {
+		return NewOptSecret(input), nil // can't fail
+	}
+
+	assertConvertToText(t, map[string]textMarshalerAndStringer{
+		"": OptSecret{}, "****": NewOptSecret(testShortSecret), "****abcd": NewOptSecret(testLongSecret),
+	})
+
+	assertConvertFromText(t, &OptSecret{}, stringCtor, map[string]interface{}{
+		"": OptSecret{}, testLongSecret: NewOptSecret(testLongSecret),
+	})
+
+	assertConvertToJSON(t, map[string]SingleValue{
+		`null`: OptSecret{}, `"****"`: NewOptSecret(testShortSecret), `"****abcd"`: NewOptSecret(testLongSecret),
+	})
+
+	assertConvertFromJSON(t, &OptSecret{}, map[string]interface{}{
+		`null`: OptSecret{}, `""`: OptSecret{}, `"a"`: NewOptSecret("a"),
+	})
+
+	assertConvertFromJSONFails(t, &OptSecret{},
+		`true`, `1`, `[]`, `{}`)
+}
@@ -1,7 +1,10 @@
 package configtypes
 
 import (
+	"database/sql/driver"
+
 	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"gopkg.in/yaml.v3"
 )
 
 // OptString represents an optional string parameter.
@@ -25,6 +28,18 @@ func NewOptString(value string) OptString {
 	return OptString{ldvalue.NewOptionalString(value)}
 }
 
+// NewOptStringFromLDValue converts an ldvalue.Value to OptString, with the same rules as UnmarshalJSON.
+func NewOptStringFromLDValue(v ldvalue.Value) (OptString, error) {
+	switch {
+	case v.IsNull():
+		return OptString{}, nil
+	case v.IsString():
+		return NewOptString(v.StringValue()), nil
+	default:
+		return OptString{}, errStringFormat()
+	}
+}
+
 func (o OptString) IsDefined() bool {
 	return o.s.IsDefined()
 }
@@ -46,6 +61,25 @@ func (o *OptString) UnmarshalText(data []byte) error {
 	return nil // cannot fail
 }
 
+// Set is the same as UnmarshalText. It allows OptString to be used as a flag.Value.
+func (o *OptString) Set(s string) error {
+	return o.UnmarshalText([]byte(s))
+}
+
+// Get returns the value as a string, or nil if it is not defined. It allows OptString to be used as
+// a flag.Getter.
+func (o OptString) Get() interface{} {
+	if !o.IsDefined() {
+		return nil
+	}
+	return o.GetOrElse("")
+}
+
+// AsLDValue converts the value to an ldvalue.Value, with the same rules as MarshalJSON.
+func (o OptString) AsLDValue() ldvalue.Value {
+	return o.s.AsValue()
+}
+
 func (o OptString) MarshalJSON() ([]byte, error) {
 	return o.s.MarshalJSON()
 }
@@ -58,3 +92,64 @@ func (o *OptString) UnmarshalJSON(data []byte) error {
 	*o = OptString{s}
 	return nil
 }
+
+// MarshalYAML converts this type to a YAML value, with the same rules as MarshalJSON.
+func (o OptString) MarshalYAML() (interface{}, error) {
+	if o.IsDefined() {
+		return o.GetOrElse(""), nil
+	}
+	return nil, nil //nolint:nilnil
+}
+
+// UnmarshalYAML converts a YAML value to this type, with the same rules as UnmarshalJSON.
+func (o *OptString) UnmarshalYAML(node *yaml.Node) error {
+	return unmarshalYAMLAsJSON(node, o)
+}
+
+// Value implements driver.Valuer, so that the value can be written to a database with database/sql.
+// An empty value is NULL; otherwise it is a string.
+func (o OptString) Value() (driver.Value, error) {
+	if !o.IsDefined() {
+		return nil, nil //nolint:nilnil
+	}
+	return o.GetOrElse(""), nil
+}
+
+// Scan implements sql.Scanner, so that the value can be read from a database with database/sql. NULL
+// becomes an empty value; any string is allowed.
+func (o *OptString) Scan(src interface{}) error {
+	if src == nil {
+		*o = OptString{}
+		return nil
+	}
+	return scanSQLText(src, o)
+}
+
+// MarshalBinary converts the value to the binary format described in the package documentation.
+func (o OptString) MarshalBinary() ([]byte, error) {
+	return marshalOptBinary(o.IsDefined(), []byte(o.GetOrElse(""))), nil
+}
+
+// UnmarshalBinary converts data produced by MarshalBinary, with the same validation as UnmarshalText.
+func (o *OptString) UnmarshalBinary(data []byte) error {
+	payload, defined, err := unmarshalOptBinary(data)
+	switch {
+	case err != nil:
+		return err
+	case !defined:
+		*o = OptString{}
+	default:
+		*o = NewOptString(string(payload))
+	}
+	return nil
+}
+
+// GobEncode is the same as MarshalBinary. It allows OptString to be used with encoding/gob.
+func (o OptString) GobEncode() ([]byte, error) {
+	return o.MarshalBinary()
+}
+
+// GobDecode is the same as UnmarshalBinary. It allows OptString to be used with encoding/gob.
+func (o *OptString) GobDecode(data []byte) error {
+	return o.UnmarshalBinary(data)
+}
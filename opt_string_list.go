@@ -1,10 +1,13 @@
 package configtypes
 
 import (
+	"database/sql/driver"
+	"encoding/binary"
 	"encoding/json"
 	"strings"
 
 	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"gopkg.in/yaml.v3"
 )
 
 // OptStringList represents an optional parameter that is a slice of string values. A nil slice is
@@ -46,6 +49,29 @@ func NewOptStringListFromString(s string) OptStringList {
 	return NewOptStringList([]string{s})
 }
 
+// NewOptStringListFromLDValue converts an ldvalue.Value to OptStringList, with the same rules as
+// UnmarshalJSON.
+func NewOptStringListFromLDValue(v ldvalue.Value) (OptStringList, error) {
+	switch v.Type() {
+	case ldvalue.NullType:
+		return OptStringList{}, nil
+	case ldvalue.StringType:
+		return NewOptStringList([]string{v.StringValue()}), nil
+	case ldvalue.ArrayType:
+		values := make([]string, 0, v.Count())
+		for i := 0; i < v.Count(); i++ {
+			elem := v.GetByIndex(i)
+			if !elem.IsString() {
+				return OptStringList{}, errStringListJSONFormat()
+			}
+			values = append(values, elem.StringValue())
+		}
+		return NewOptStringList(values), nil
+	default:
+		return OptStringList{}, errStringListJSONFormat()
+	}
+}
+
 func (o OptStringList) IsDefined() bool {
 	return o.hasValue
 }
@@ -80,6 +106,34 @@ func (o *OptStringList) UnmarshalText(data []byte) error {
 	return nil
 }
 
+// Set is the same as UnmarshalText. It allows OptStringList to be used as a flag.Value; if the flag
+// is repeated, the values are added to the list. Note that this means a non-empty list that was set
+// as a default is added to rather than replaced, unless the flag was registered with BindFlags.
+func (o *OptStringList) Set(s string) error {
+	return o.UnmarshalText([]byte(s))
+}
+
+// Get returns the value as a []string, or nil if it is not defined. It allows OptStringList to be
+// used as a flag.Getter.
+func (o OptStringList) Get() interface{} {
+	if !o.IsDefined() {
+		return nil
+	}
+	return o.Values()
+}
+
+// AsLDValue converts the value to an ldvalue.Value, with the same rules as MarshalJSON.
+func (o OptStringList) AsLDValue() ldvalue.Value {
+	if !o.hasValue {
+		return ldvalue.Null()
+	}
+	builder := ldvalue.ArrayBuildWithCapacity(len(o.values))
+	for _, s := range o.values {
+		builder.Add(ldvalue.String(s))
+	}
+	return builder.Build()
+}
+
 func (o OptStringList) MarshalJSON() ([]byte, error) {
 	if o.hasValue {
 		return json.Marshal(o.values)
@@ -92,23 +146,112 @@ func (o *OptStringList) UnmarshalJSON(data []byte) error {
 	if err := v.UnmarshalJSON(data); err != nil {
 		return err
 	}
-	switch v.Type() {
-	case ldvalue.NullType:
+	opt, err := NewOptStringListFromLDValue(v)
+	if err == nil {
+		*o = opt
+	}
+	return err
+}
+
+// MarshalYAML converts this type to a YAML value, with the same rules as MarshalJSON.
+func (o OptStringList) MarshalYAML() (interface{}, error) {
+	if o.hasValue {
+		return o.Values(), nil
+	}
+	return nil, nil //nolint:nilnil
+}
+
+// UnmarshalYAML converts a YAML value to this type, with the same rules as UnmarshalJSON.
+func (o *OptStringList) UnmarshalYAML(node *yaml.Node) error {
+	return unmarshalYAMLAsJSON(node, o)
+}
+
+// Value implements driver.Valuer, so that the value can be written to a database with database/sql.
+// An empty value is NULL; otherwise it is a comma-delimited string, the same as
+// String. To store it as a JSON array instead, use SQLStringListAsJSON.
+//
+// A list that would not be read back by Scan as the same list from its comma-delimited string is
+// stored as a JSON array instead: for instance, a list with no values, a list whose only value is an
+// empty string, or a list with a value that contains a comma.
+func (o OptStringList) Value() (driver.Value, error) {
+	if !o.IsDefined() {
+		return nil, nil //nolint:nilnil
+	}
+	// Scan treats a string that begins with "[" as a JSON array if it can be parsed as one
+	if s := o.String(); textParsesAsSameValue(o, []byte(s)) && !strings.HasPrefix(strings.TrimSpace(s), "[") {
+		return s, nil
+	}
+	return SQLStringListAsJSON(&o).Value()
+}
+
+// Scan implements sql.Scanner, so that the value can be read from a database with database/sql. NULL
+// becomes an empty value; a string can be either a JSON array of strings or a
+// comma-delimited list, so the column can have either format. Unlike UnmarshalText, Scan always
+// replaces the previous value rather than adding to it.
+func (o *OptStringList) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case nil:
 		*o = OptStringList{}
-	case ldvalue.StringType:
-		*o = NewOptStringList([]string{v.StringValue()})
-	case ldvalue.ArrayType:
-		values := make([]string, 0, v.Count())
-		for i := 0; i < v.Count(); i++ {
-			elem := v.GetByIndex(i)
-			if !elem.IsString() {
-				return errStringListJSONFormat()
-			}
-			values = append(values, elem.StringValue())
-		}
-		*o = NewOptStringList(values)
+		return nil
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
 	default:
-		return errStringListJSONFormat()
+		return errSQLScanType(src, o)
+	}
+	if strings.HasPrefix(strings.TrimSpace(s), "[") {
+		var values []string
+		if err := json.Unmarshal([]byte(s), &values); err == nil {
+			*o = NewOptStringList(values)
+			return nil
+		}
 	}
+	*o = NewOptStringListFromString(s)
 	return nil
 }
+
+// MarshalBinary converts the value to the binary format described in the package documentation.
+func (o OptStringList) MarshalBinary() ([]byte, error) {
+	var payload []byte
+	for _, s := range o.values {
+		payload = binary.AppendUvarint(payload, uint64(len(s)))
+		payload = append(payload, s...)
+	}
+	return marshalOptBinary(o.hasValue, payload), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. Unlike UnmarshalText, it always replaces the
+// previous value rather than adding to it.
+func (o *OptStringList) UnmarshalBinary(data []byte) error {
+	payload, defined, err := unmarshalOptBinary(data)
+	if err != nil {
+		return err
+	}
+	if !defined {
+		*o = OptStringList{}
+		return nil
+	}
+	values := []string{}
+	for len(payload) > 0 {
+		length, n := binary.Uvarint(payload)
+		if n <= 0 || length > uint64(len(payload)-n) {
+			return errBinaryFormat()
+		}
+		values = append(values, string(payload[n:n+int(length)]))
+		payload = payload[n+int(length):]
+	}
+	*o = NewOptStringList(values)
+	return nil
+}
+
+// GobEncode is the same as MarshalBinary. It allows OptStringList to be used with encoding/gob.
+func (o OptStringList) GobEncode() ([]byte, error) {
+	return o.MarshalBinary()
+}
+
+// GobDecode is the same as UnmarshalBinary. It allows OptStringList to be used with encoding/gob.
+func (o *OptStringList) GobDecode(data []byte) error {
+	return o.UnmarshalBinary(data)
+}
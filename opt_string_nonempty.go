@@ -1,7 +1,10 @@
 package configtypes
 
 import (
+	"database/sql/driver"
+
 	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"gopkg.in/yaml.v3"
 )
 
 // OptStringNonEmpty represents an optional string parameter which, if defined, must be non-empty.
@@ -31,6 +34,19 @@ func NewOptStringNonEmpty(value string) OptStringNonEmpty {
 	return OptStringNonEmpty{NewOptString(value)}
 }
 
+// NewOptStringNonEmptyFromLDValue converts an ldvalue.Value to OptStringNonEmpty, with the same rules
+// as UnmarshalJSON.
+func NewOptStringNonEmptyFromLDValue(v ldvalue.Value) (OptStringNonEmpty, error) {
+	opt, err := NewOptStringFromLDValue(v)
+	if err != nil {
+		return OptStringNonEmpty{}, err
+	}
+	if opt.IsDefined() && opt.GetOrElse("") == "" {
+		return OptStringNonEmpty{}, errMustBeNonEmptyString()
+	}
+	return OptStringNonEmpty{opt}, nil
+}
+
 func (o OptStringNonEmpty) IsDefined() bool {
 	return o.opt.IsDefined()
 }
@@ -48,6 +64,20 @@ func (o *OptStringNonEmpty) UnmarshalText(data []byte) error {
 	return nil
 }
 
+// Set is the same as UnmarshalText. It allows OptStringNonEmpty to be used as a flag.Value.
+func (o *OptStringNonEmpty) Set(s string) error {
+	return o.UnmarshalText([]byte(s))
+}
+
+// Get returns the value as a string, or nil if it is not defined. It allows OptStringNonEmpty to be
+// used as a flag.Getter.
+func (o OptStringNonEmpty) Get() interface{} {
+	if !o.IsDefined() {
+		return nil
+	}
+	return o.GetOrElse("")
+}
+
 func (o OptStringNonEmpty) MarshalText() ([]byte, error) {
 	return []byte(o.opt.GetOrElse("")), nil
 }
@@ -68,6 +98,62 @@ func (o *OptStringNonEmpty) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// AsLDValue converts the value to an ldvalue.Value, with the same rules as MarshalJSON.
+func (o OptStringNonEmpty) AsLDValue() ldvalue.Value {
+	return o.opt.AsLDValue()
+}
+
 func (o OptStringNonEmpty) MarshalJSON() ([]byte, error) {
 	return o.opt.MarshalJSON()
 }
+
+// MarshalYAML converts this type to a YAML value, with the same rules as MarshalJSON.
+func (o OptStringNonEmpty) MarshalYAML() (interface{}, error) {
+	return o.opt.MarshalYAML()
+}
+
+// UnmarshalYAML converts a YAML value to this type, with the same rules as UnmarshalJSON.
+func (o *OptStringNonEmpty) UnmarshalYAML(node *yaml.Node) error {
+	return unmarshalYAMLAsJSON(node, o)
+}
+
+// Value implements driver.Valuer, with the same rules as OptString.
+func (o OptStringNonEmpty) Value() (driver.Value, error) {
+	return o.opt.Value()
+}
+
+// Scan implements sql.Scanner, with the same rules as UnmarshalText: NULL or an empty string becomes an
+// empty value.
+func (o *OptStringNonEmpty) Scan(src interface{}) error {
+	if src == nil {
+		*o = OptStringNonEmpty{}
+		return nil
+	}
+	return scanSQLText(src, o)
+}
+
+// MarshalBinary converts the value to the binary format described in the package documentation.
+func (o OptStringNonEmpty) MarshalBinary() ([]byte, error) {
+	return o.opt.MarshalBinary()
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. As with UnmarshalText, a defined empty
+// string becomes an empty value.
+func (o *OptStringNonEmpty) UnmarshalBinary(data []byte) error {
+	var opt OptString
+	if err := opt.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	*o = NewOptStringNonEmpty(opt.GetOrElse(""))
+	return nil
+}
+
+// GobEncode is the same as MarshalBinary. It allows OptStringNonEmpty to be used with encoding/gob.
+func (o OptStringNonEmpty) GobEncode() ([]byte, error) {
+	return o.MarshalBinary()
+}
+
+// GobDecode is the same as UnmarshalBinary. It allows OptStringNonEmpty to be used with encoding/gob.
+func (o *OptStringNonEmpty) GobDecode(data []byte) error {
+	return o.UnmarshalBinary(data)
+}
@@ -0,0 +1,219 @@
+package configtypes
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"gopkg.in/yaml.v3"
+)
+
+// OptTime represents an optional time.Time parameter, such as an expiration date.
+//
+// When setting this value from a string representation, it uses the RFC 3339 format, such as
+// "2006-01-02T15:04:05Z" or "2006-01-02T15:04:05.999-07:00"; the "T" can also be a space. A date and
+// time with no time zone, such as "2006-01-02T15:04:05", or a date with no time, such as "2006-01-02",
+// is also allowed and is interpreted as UTC. Converting to a string always uses the full RFC 3339
+// format, with fractional seconds only if they are nonzero.
+//
+// When converting to or from JSON, an empty value is null, and all other values are strings. In a TOML
+// file read by LoadTOML, the value can also be a TOML date-time or date.
+//
+// See the package documentation for the general contract for methods that have no specific documentation
+// here.
+type OptTime struct {
+	hasValue bool
+	value    time.Time
+}
+
+// optTimeLayouts are the formats that NewOptTimeFromString accepts, after replacing a space between
+// the date and the time with "T". Fractional seconds are allowed in any format that has seconds.
+var optTimeLayouts = []string{ //nolint:gochecknoglobals
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02",
+}
+
+func NewOptTime(value time.Time) OptTime {
+	return OptTime{hasValue: true, value: value}
+}
+
+func NewOptTimeFromString(s string) (OptTime, error) {
+	if s == "" {
+		return OptTime{}, nil
+	}
+	if len(s) > 10 && s[10] == ' ' {
+		s = s[:10] + "T" + s[11:]
+	}
+	s = strings.ToUpper(s) // RFC 3339 allows "t" and "z"
+	for _, layout := range optTimeLayouts {
+		if value, err := time.Parse(layout, s); err == nil {
+			return NewOptTime(value), nil
+		}
+	}
+	return OptTime{}, errTimeFormat()
+}
+
+// NewOptTimeFromLDValue converts an ldvalue.Value to OptTime, with the same rules as UnmarshalJSON.
+func NewOptTimeFromLDValue(v ldvalue.Value) (OptTime, error) {
+	switch {
+	case v.IsNull():
+		return OptTime{}, nil
+	case v.IsString():
+		return NewOptTimeFromString(v.StringValue())
+	default:
+		return OptTime{}, errTimeFormat()
+	}
+}
+
+func (o OptTime) IsDefined() bool {
+	return o.hasValue
+}
+
+func (o OptTime) GetOrElse(orElseValue time.Time) time.Time {
+	if !o.hasValue {
+		return orElseValue
+	}
+	return o.value
+}
+
+func (o OptTime) String() string {
+	if !o.hasValue {
+		return ""
+	}
+	return o.value.Format(time.RFC3339Nano)
+}
+
+func (o OptTime) MarshalText() ([]byte, error) {
+	return []byte(o.String()), nil
+}
+
+func (o *OptTime) UnmarshalText(data []byte) error {
+	opt, err := NewOptTimeFromString(string(data))
+	if err == nil {
+		*o = opt
+	}
+	return err
+}
+
+// Set is the same as UnmarshalText. It allows OptTime to be used as a flag.Value.
+func (o *OptTime) Set(s string) error {
+	return o.UnmarshalText([]byte(s))
+}
+
+// Get returns the value as a time.Time, or nil if it is not defined. It allows OptTime to be used
+// as a flag.Getter.
+func (o OptTime) Get() interface{} {
+	if !o.IsDefined() {
+		return nil
+	}
+	return o.value
+}
+
+// AsLDValue converts the value to an ldvalue.Value, with the same rules as MarshalJSON.
+func (o OptTime) AsLDValue() ldvalue.Value {
+	if !o.hasValue {
+		return ldvalue.Null()
+	}
+	return ldvalue.String(o.String())
+}
+
+func (o OptTime) MarshalJSON() ([]byte, error) {
+	if o.hasValue {
+		return json.Marshal(o.String())
+	}
+	return json.Marshal(nil)
+}
+
+func (o *OptTime) UnmarshalJSON(data []byte) error {
+	var v ldvalue.Value
+	if err := v.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	opt, err := NewOptTimeFromLDValue(v)
+	if err == nil {
+		*o = opt
+	}
+	return err
+}
+
+// MarshalYAML converts this type to a YAML value, with the same rules as MarshalJSON.
+func (o OptTime) MarshalYAML() (interface{}, error) {
+	if o.hasValue {
+		return o.String(), nil
+	}
+	return nil, nil //nolint:nilnil
+}
+
+// UnmarshalYAML converts a YAML value to this type, with the same rules as UnmarshalJSON. A YAML
+// timestamp is also allowed.
+func (o *OptTime) UnmarshalYAML(node *yaml.Node) error {
+	return unmarshalYAMLAsJSON(node, o)
+}
+
+// Value implements driver.Valuer, so that the value can be written to a database with database/sql.
+// An empty value is NULL; otherwise it is a time.Time.
+func (o OptTime) Value() (driver.Value, error) {
+	if !o.IsDefined() {
+		return nil, nil //nolint:nilnil
+	}
+	return o.value, nil
+}
+
+// Scan implements sql.Scanner, so that the value can be read from a database with database/sql. NULL
+// becomes an empty value; a time.Time, or a string in the same format as UnmarshalText, is
+// also allowed.
+func (o *OptTime) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*o = OptTime{}
+		return nil
+	case time.Time:
+		*o = NewOptTime(v)
+		return nil
+	}
+	return scanSQLText(src, o)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. Unlike MarshalText, it preserves the time
+// zone offset and nanoseconds exactly, in the same format as time.Time.MarshalBinary.
+func (o OptTime) MarshalBinary() ([]byte, error) {
+	if !o.hasValue {
+		return marshalOptBinary(false, nil), nil
+	}
+	payload, err := o.value.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return marshalOptBinary(true, payload), nil
+}
+
+// UnmarshalBinary converts data produced by MarshalBinary, with the same validation as UnmarshalText.
+func (o *OptTime) UnmarshalBinary(data []byte) error {
+	payload, defined, err := unmarshalOptBinary(data)
+	if err != nil {
+		return err
+	}
+	if !defined {
+		*o = OptTime{}
+		return nil
+	}
+	var value time.Time
+	if err := value.UnmarshalBinary(payload); err != nil {
+		return errBinaryFormat()
+	}
+	*o = NewOptTime(value)
+	return nil
+}
+
+// GobEncode is the same as MarshalBinary. It allows OptTime to be used with encoding/gob.
+func (o OptTime) GobEncode() ([]byte, error) {
+	return o.MarshalBinary()
+}
+
+// GobDecode is the same as UnmarshalBinary. It allows OptTime to be used with encoding/gob.
+func (o *OptTime) GobDecode(data []byte) error {
+	return o.UnmarshalBinary(data)
+}
@@ -0,0 +1,74 @@
+package configtypes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptTime(t *testing.T) {
+	someTime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	otherTime := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	fractionalTime := time.Date(2020, 1, 2, 3, 4, 5, 500000000, time.UTC)
+	zonedTime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.FixedZone("", -7*60*60))
+	midnight := time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	t.Run("empty value", func(t *testing.T) {
+		unsetValue := OptTime{}
+		assertIsDefined(t, false, unsetValue)
+		assert.Equal(t, otherTime, unsetValue.GetOrElse(otherTime))
+	})
+
+	t.Run("defined value", func(t *testing.T) {
+		value := NewOptTime(someTime)
+		assertIsDefined(t, true, value)
+		assert.Equal(t, someTime, value.GetOrElse(otherTime))
+
+		zeroValue := NewOptTime(time.Time{})
+		assertIsDefined(t, true, zeroValue)
+		assert.Equal(t, time.Time{}, zeroValue.GetOrElse(otherTime))
+	})
+
+	stringCtor := func(input string) (interface{}, error) {
+		o, err := NewOptTimeFromString(input)
+		return o, err
+	}
+
+	assertConvertToText(t, map[string]textMarshalerAndStringer{
+		"":                          OptTime{},
+		"2020-01-02T03:04:05Z":      NewOptTime(someTime),
+		"2020-01-02T03:04:05.5Z":    NewOptTime(fractionalTime),
+		"2020-01-02T03:04:05-07:00": NewOptTime(zonedTime),
+		"2020-01-02T00:00:00Z":      NewOptTime(midnight),
+	})
+
+	assertConvertFromText(t, &OptTime{}, stringCtor, map[string]interface{}{
+		"":                          OptTime{},
+		"2020-01-02T03:04:05Z":      NewOptTime(someTime),
+		"2020-01-02t03:04:05z":      NewOptTime(someTime),
+		"2020-01-02 03:04:05Z":      NewOptTime(someTime),
+		"2020-01-02T03:04:05.5Z":    NewOptTime(fractionalTime),
+		"2020-01-02T03:04:05-07:00": NewOptTime(zonedTime),
+		"2020-01-02T03:04:05":       NewOptTime(someTime),
+		"2020-01-02":                NewOptTime(midnight),
+	})
+
+	assertConvertFromTextFails(t, &OptTime{}, stringCtor, errTimeFormat(),
+		"x", "2020", "2020-01-32", "03:04:05", "2020-01-02T03:04", "2020-01-02T03:04:05+7",
+	)
+
+	assertConvertToJSON(t, map[string]SingleValue{
+		`null`:                   OptTime{},
+		`"2020-01-02T03:04:05Z"`: NewOptTime(someTime),
+	})
+
+	assertConvertFromJSON(t, &OptTime{}, map[string]interface{}{
+		`null`:                   OptTime{},
+		`"2020-01-02T03:04:05Z"`: NewOptTime(someTime),
+		`"2020-01-02"`:           NewOptTime(midnight),
+	})
+
+	assertConvertFromJSONFails(t, &OptTime{},
+		`true`, `1`, `"x"`, `[]`, `{}`)
+}
@@ -0,0 +1,190 @@
+package configtypes
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"math"
+	"strconv"
+
+	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"gopkg.in/yaml.v3"
+)
+
+// OptUint represents an optional uint parameter.
+//
+// This is the same as OptUint64, but the value is a uint, so its range depends on the platform. A value
+// that is an integer but is negative or too large for a uint causes an "out of range" error rather than
+// a format error.
+//
+// JSON conversion has the same rules as for OptUint64.
+//
+// See the package documentation for the general contract for methods that have no specific documentation
+// here.
+type OptUint struct {
+	hasValue bool
+	value    uint
+}
+
+func NewOptUint(value uint) OptUint {
+	return OptUint{hasValue: true, value: value}
+}
+
+func NewOptUintFromString(s string) (OptUint, error) {
+	if s == "" {
+		return OptUint{}, nil
+	}
+	return optUintFromIntegerText(s, true, nil)
+}
+
+// NewOptUintFromLDValue converts an ldvalue.Value to OptUint, with the same rules as UnmarshalJSON.
+// Since ldvalue.Value stores numbers as float64, a number beyond 2^53 may not be exact; use a string
+// value if that is a concern.
+func NewOptUintFromLDValue(v ldvalue.Value) (OptUint, error) {
+	return optUintFromIntegerText(ldValueInteger(v))
+}
+
+// optUintFromIntegerText converts decimal text, or the results of parseJSONInteger or
+// ldValueInteger, to OptUint.
+func optUintFromIntegerText(text string, defined bool, err error) (OptUint, error) {
+	if err != nil || !defined {
+		return OptUint{}, err
+	}
+	value, err := parseUint64(text, math.MaxUint)
+	if err != nil {
+		return OptUint{}, err
+	}
+	return NewOptUint(uint(value)), nil
+}
+
+func (o OptUint) IsDefined() bool {
+	return o.hasValue
+}
+
+func (o OptUint) GetOrElse(orElseValue uint) uint {
+	if !o.hasValue {
+		return orElseValue
+	}
+	return o.value
+}
+
+func (o OptUint) String() string {
+	if !o.hasValue {
+		return ""
+	}
+	return strconv.FormatUint(uint64(o.value), 10)
+}
+
+func (o OptUint) MarshalText() ([]byte, error) {
+	return []byte(o.String()), nil
+}
+
+func (o *OptUint) UnmarshalText(data []byte) error {
+	value, err := NewOptUintFromString(string(data))
+	if err == nil {
+		*o = value
+	}
+	return err
+}
+
+// Set is the same as UnmarshalText. It allows OptUint to be used as a flag.Value.
+func (o *OptUint) Set(s string) error {
+	return o.UnmarshalText([]byte(s))
+}
+
+// Get returns the value as a uint, or nil if it is not defined. It allows OptUint to be used as a
+// flag.Getter.
+func (o OptUint) Get() interface{} {
+	if !o.hasValue {
+		return nil
+	}
+	return o.value
+}
+
+// AsLDValue converts the value to an ldvalue.Value, with the same rules as MarshalJSON. Since
+// ldvalue.Value stores numbers as float64, a value beyond 2^53 may not be exact.
+func (o OptUint) AsLDValue() ldvalue.Value {
+	if !o.hasValue {
+		return ldvalue.Null()
+	}
+	return ldvalue.Float64(float64(o.value))
+}
+
+func (o OptUint) MarshalJSON() ([]byte, error) {
+	if o.hasValue {
+		return json.Marshal(o.value)
+	}
+	return json.Marshal(nil)
+}
+
+func (o *OptUint) UnmarshalJSON(data []byte) error {
+	opt, err := optUintFromIntegerText(parseJSONInteger(data))
+	if err == nil {
+		*o = opt
+	}
+	return err
+}
+
+// MarshalYAML converts this type to a YAML value, with the same rules as MarshalJSON.
+func (o OptUint) MarshalYAML() (interface{}, error) {
+	if o.hasValue {
+		return o.value, nil
+	}
+	return nil, nil //nolint:nilnil
+}
+
+// UnmarshalYAML converts a YAML value to this type, with the same rules as UnmarshalJSON.
+func (o *OptUint) UnmarshalYAML(node *yaml.Node) error {
+	return unmarshalYAMLAsJSON(node, o)
+}
+
+// Value implements driver.Valuer, so that the value can be written to a database with database/sql.
+// An empty value is NULL. The rules are the same as for OptUint64.
+func (o OptUint) Value() (driver.Value, error) {
+	return sqlUintValue(o.hasValue, uint64(o.value))
+}
+
+// Scan implements sql.Scanner, so that the value can be read from a database with database/sql. NULL
+// becomes an empty value; a non-negative integer, or a string in the same format as UnmarshalText, is
+// also allowed.
+func (o *OptUint) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*o = OptUint{}
+		return nil
+	case int64:
+		value, err := int64ToUint64(v, math.MaxUint)
+		if err == nil {
+			*o = NewOptUint(uint(value))
+		}
+		return err
+	}
+	return scanSQLText(src, o)
+}
+
+// MarshalBinary converts the value to the binary format described in the package documentation.
+func (o OptUint) MarshalBinary() ([]byte, error) {
+	return marshalOptBinaryUvarint(o.hasValue, uint64(o.value)), nil
+}
+
+// UnmarshalBinary converts data produced by MarshalBinary, with the same validation as UnmarshalText.
+func (o *OptUint) UnmarshalBinary(data []byte) error {
+	value, defined, err := unmarshalOptBinaryUvarint(data)
+	switch {
+	case err != nil:
+		return err
+	case value > math.MaxUint:
+		return errIntOutOfRange(0, math.MaxUint)
+	}
+	*o = OptUint{hasValue: defined, value: uint(value)}
+	return nil
+}
+
+// GobEncode is the same as MarshalBinary. It allows OptUint to be used with encoding/gob.
+func (o OptUint) GobEncode() ([]byte, error) {
+	return o.MarshalBinary()
+}
+
+// GobDecode is the same as UnmarshalBinary. It allows OptUint to be used with encoding/gob.
+func (o *OptUint) GobDecode(data []byte) error {
+	return o.UnmarshalBinary(data)
+}
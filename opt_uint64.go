@@ -0,0 +1,189 @@
+package configtypes
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"math"
+	"strconv"
+
+	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"gopkg.in/yaml.v3"
+)
+
+// OptUint64 represents an optional uint64 parameter.
+//
+// Parsing is exact for the whole uint64 range. A value that is an integer but is negative or too large
+// for a uint64 causes an "out of range" error rather than a format error.
+//
+// When converting from JSON, the value must be either a JSON null, a JSON number that is an integer, or
+// a JSON string in the same format as UnmarshalText. A JSON number is never converted to float64, so
+// integers beyond 2^53 are exact. When converting to JSON, the value is always a JSON number.
+//
+// See the package documentation for the general contract for methods that have no specific documentation
+// here.
+type OptUint64 struct {
+	hasValue bool
+	value    uint64
+}
+
+func NewOptUint64(value uint64) OptUint64 {
+	return OptUint64{hasValue: true, value: value}
+}
+
+func NewOptUint64FromString(s string) (OptUint64, error) {
+	if s == "" {
+		return OptUint64{}, nil
+	}
+	return optUint64FromIntegerText(s, true, nil)
+}
+
+// NewOptUint64FromLDValue converts an ldvalue.Value to OptUint64, with the same rules as UnmarshalJSON.
+// Since ldvalue.Value stores numbers as float64, a number beyond 2^53 may not be exact; use a string
+// value if that is a concern.
+func NewOptUint64FromLDValue(v ldvalue.Value) (OptUint64, error) {
+	return optUint64FromIntegerText(ldValueInteger(v))
+}
+
+// optUint64FromIntegerText converts decimal text, or the results of parseJSONInteger or
+// ldValueInteger, to OptUint64.
+func optUint64FromIntegerText(text string, defined bool, err error) (OptUint64, error) {
+	if err != nil || !defined {
+		return OptUint64{}, err
+	}
+	value, err := parseUint64(text, math.MaxUint64)
+	if err != nil {
+		return OptUint64{}, err
+	}
+	return NewOptUint64(value), nil
+}
+
+func (o OptUint64) IsDefined() bool {
+	return o.hasValue
+}
+
+func (o OptUint64) GetOrElse(orElseValue uint64) uint64 {
+	if !o.hasValue {
+		return orElseValue
+	}
+	return o.value
+}
+
+func (o OptUint64) String() string {
+	if !o.hasValue {
+		return ""
+	}
+	return strconv.FormatUint(o.value, 10)
+}
+
+func (o OptUint64) MarshalText() ([]byte, error) {
+	return []byte(o.String()), nil
+}
+
+func (o *OptUint64) UnmarshalText(data []byte) error {
+	value, err := NewOptUint64FromString(string(data))
+	if err == nil {
+		*o = value
+	}
+	return err
+}
+
+// Set is the same as UnmarshalText. It allows OptUint64 to be used as a flag.Value.
+func (o *OptUint64) Set(s string) error {
+	return o.UnmarshalText([]byte(s))
+}
+
+// Get returns the value as a uint64, or nil if it is not defined. It allows OptUint64 to be used as a
+// flag.Getter.
+func (o OptUint64) Get() interface{} {
+	if !o.hasValue {
+		return nil
+	}
+	return o.value
+}
+
+// AsLDValue converts the value to an ldvalue.Value, with the same rules as MarshalJSON. Since
+// ldvalue.Value stores numbers as float64, a value beyond 2^53 may not be exact.
+func (o OptUint64) AsLDValue() ldvalue.Value {
+	if !o.hasValue {
+		return ldvalue.Null()
+	}
+	return ldvalue.Float64(float64(o.value))
+}
+
+func (o OptUint64) MarshalJSON() ([]byte, error) {
+	if o.hasValue {
+		return json.Marshal(o.value)
+	}
+	return json.Marshal(nil)
+}
+
+func (o *OptUint64) UnmarshalJSON(data []byte) error {
+	opt, err := optUint64FromIntegerText(parseJSONInteger(data))
+	if err == nil {
+		*o = opt
+	}
+	return err
+}
+
+// MarshalYAML converts this type to a YAML value, with the same rules as MarshalJSON.
+func (o OptUint64) MarshalYAML() (interface{}, error) {
+	if o.hasValue {
+		return o.value, nil
+	}
+	return nil, nil //nolint:nilnil
+}
+
+// UnmarshalYAML converts a YAML value to this type, with the same rules as UnmarshalJSON.
+func (o *OptUint64) UnmarshalYAML(node *yaml.Node) error {
+	return unmarshalYAMLAsJSON(node, o)
+}
+
+// Value implements driver.Valuer, so that the value can be written to a database with database/sql.
+// An empty value is NULL. Since database/sql does not support uint64 values, a value that fits in an
+// int64 is an int64, and a larger value is a string in the same format as MarshalText.
+func (o OptUint64) Value() (driver.Value, error) {
+	return sqlUintValue(o.hasValue, o.value)
+}
+
+// Scan implements sql.Scanner, so that the value can be read from a database with database/sql. NULL
+// becomes an empty value; a non-negative integer, or a string in the same format as UnmarshalText, is
+// also allowed.
+func (o *OptUint64) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		*o = OptUint64{}
+		return nil
+	case int64:
+		value, err := int64ToUint64(v, math.MaxUint64)
+		if err == nil {
+			*o = NewOptUint64(value)
+		}
+		return err
+	}
+	return scanSQLText(src, o)
+}
+
+// MarshalBinary converts the value to the binary format described in the package documentation.
+func (o OptUint64) MarshalBinary() ([]byte, error) {
+	return marshalOptBinaryUvarint(o.hasValue, o.value), nil
+}
+
+// UnmarshalBinary converts data produced by MarshalBinary, with the same validation as UnmarshalText.
+func (o *OptUint64) UnmarshalBinary(data []byte) error {
+	value, defined, err := unmarshalOptBinaryUvarint(data)
+	if err != nil {
+		return err
+	}
+	*o = OptUint64{hasValue: defined, value: value}
+	return nil
+}
+
+// GobEncode is the same as MarshalBinary. It allows OptUint64 to be used with encoding/gob.
+func (o OptUint64) GobEncode() ([]byte, error) {
+	return o.MarshalBinary()
+}
+
+// GobDecode is the same as UnmarshalBinary. It allows OptUint64 to be used with encoding/gob.
+func (o *OptUint64) GobDecode(data []byte) error {
+	return o.UnmarshalBinary(data)
+}
@@ -0,0 +1,169 @@
+package configtypes
+
+import (
+	"database/sql/driver"
+
+	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"gopkg.in/yaml.v3"
+)
+
+// OptUint64GreaterThanZero represents an optional uint64 parameter which, if defined, must be greater
+// than zero.
+//
+// This is the same as OptUint64, but with additional validation for the constructor and unmarshalers. It
+// is impossible (except with reflection) for code outside this package to construct an instance of this
+// type with a defined value that is zero.
+//
+// See the package documentation for the general contract for methods that have no specific documentation
+// here.
+type OptUint64GreaterThanZero struct {
+	opt OptUint64
+}
+
+func NewOptUint64GreaterThanZero(value uint64) (OptUint64GreaterThanZero, error) {
+	return optUint64GreaterThanZeroFromOptUint64(NewOptUint64(value))
+}
+
+func NewOptUint64GreaterThanZeroFromString(s string) (OptUint64GreaterThanZero, error) {
+	o, err := NewOptUint64FromString(s)
+	if err != nil {
+		return OptUint64GreaterThanZero{}, err
+	}
+	return optUint64GreaterThanZeroFromOptUint64(o)
+}
+
+// NewOptUint64GreaterThanZeroFromLDValue converts an ldvalue.Value to OptUint64GreaterThanZero, with the
+// same rules as UnmarshalJSON.
+func NewOptUint64GreaterThanZeroFromLDValue(v ldvalue.Value) (OptUint64GreaterThanZero, error) {
+	opt, err := NewOptUint64FromLDValue(v)
+	if err != nil {
+		return OptUint64GreaterThanZero{}, err
+	}
+	return optUint64GreaterThanZeroFromOptUint64(opt)
+}
+
+func optUint64GreaterThanZeroFromOptUint64(o OptUint64) (OptUint64GreaterThanZero, error) {
+	if !o.IsDefined() || o.GetOrElse(0) > 0 {
+		return OptUint64GreaterThanZero{o}, nil
+	}
+	return OptUint64GreaterThanZero{}, errMustBeGreaterThanZero()
+}
+
+func (o OptUint64GreaterThanZero) IsDefined() bool {
+	return o.opt.IsDefined()
+}
+
+func (o OptUint64GreaterThanZero) GetOrElse(orElseValue uint64) uint64 {
+	return o.opt.GetOrElse(orElseValue)
+}
+
+func (o *OptUint64GreaterThanZero) UnmarshalText(data []byte) error {
+	var opt OptUint64
+	if err := opt.UnmarshalText(data); err != nil {
+		return err
+	}
+	value, err := optUint64GreaterThanZeroFromOptUint64(opt)
+	if err == nil {
+		*o = value
+	}
+	return err
+}
+
+// Set is the same as UnmarshalText. It allows OptUint64GreaterThanZero to be used as a flag.Value.
+func (o *OptUint64GreaterThanZero) Set(s string) error {
+	return o.UnmarshalText([]byte(s))
+}
+
+// Get returns the value as a uint64, or nil if it is not defined. It allows OptUint64GreaterThanZero to
+// be used as a flag.Getter.
+func (o OptUint64GreaterThanZero) Get() interface{} {
+	if !o.IsDefined() {
+		return nil
+	}
+	return o.GetOrElse(0)
+}
+
+func (o OptUint64GreaterThanZero) String() string {
+	return o.opt.String()
+}
+
+func (o OptUint64GreaterThanZero) MarshalText() ([]byte, error) {
+	return o.opt.MarshalText()
+}
+
+func (o *OptUint64GreaterThanZero) UnmarshalJSON(data []byte) error {
+	var opt OptUint64
+	if err := opt.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	value, err := optUint64GreaterThanZeroFromOptUint64(opt)
+	if err == nil {
+		*o = value
+	}
+	return err
+}
+
+// AsLDValue converts the value to an ldvalue.Value, with the same rules as MarshalJSON.
+func (o OptUint64GreaterThanZero) AsLDValue() ldvalue.Value {
+	return o.opt.AsLDValue()
+}
+
+func (o OptUint64GreaterThanZero) MarshalJSON() ([]byte, error) {
+	return o.opt.MarshalJSON()
+}
+
+// MarshalYAML converts this type to a YAML value, with the same rules as MarshalJSON.
+func (o OptUint64GreaterThanZero) MarshalYAML() (interface{}, error) {
+	return o.opt.MarshalYAML()
+}
+
+// UnmarshalYAML converts a YAML value to this type, with the same rules as UnmarshalJSON.
+func (o *OptUint64GreaterThanZero) UnmarshalYAML(node *yaml.Node) error {
+	return unmarshalYAMLAsJSON(node, o)
+}
+
+// Value implements driver.Valuer, with the same rules as OptUint64.
+func (o OptUint64GreaterThanZero) Value() (driver.Value, error) {
+	return o.opt.Value()
+}
+
+// Scan implements sql.Scanner, with the same rules as OptUint64.
+func (o *OptUint64GreaterThanZero) Scan(src interface{}) error {
+	var opt OptUint64
+	if err := opt.Scan(src); err != nil {
+		return err
+	}
+	value, err := optUint64GreaterThanZeroFromOptUint64(opt)
+	if err == nil {
+		*o = value
+	}
+	return err
+}
+
+// MarshalBinary converts the value to the binary format described in the package documentation.
+func (o OptUint64GreaterThanZero) MarshalBinary() ([]byte, error) {
+	return o.opt.MarshalBinary()
+}
+
+// UnmarshalBinary converts data produced by MarshalBinary, with the same validation as UnmarshalText.
+func (o *OptUint64GreaterThanZero) UnmarshalBinary(data []byte) error {
+	var opt OptUint64
+	if err := opt.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	value, err := optUint64GreaterThanZeroFromOptUint64(opt)
+	if err == nil {
+		*o = value
+	}
+	return err
+}
+
+// GobEncode is the same as MarshalBinary. It allows OptUint64GreaterThanZero to be used with encoding/gob.
+func (o OptUint64GreaterThanZero) GobEncode() ([]byte, error) {
+	return o.MarshalBinary()
+}
+
+// GobDecode is the same as UnmarshalBinary. It allows OptUint64GreaterThanZero to be used with encoding/gob.
+func (o *OptUint64GreaterThanZero) GobDecode(data []byte) error {
+	return o.UnmarshalBinary(data)
+}
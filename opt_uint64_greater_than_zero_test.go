@@ -0,0 +1,74 @@
+package configtypes
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func mustOptUint64GreaterThanZero(n uint64) OptUint64GreaterThanZero {
+	o, err := NewOptUint64GreaterThanZero(n)
+	if err != nil {
+		panic(err)
+	}
+	return o
+}
+
+func TestOptUint64GreaterThanZero(t *testing.T) {
+	t.Run("empty value", func(t *testing.T) {
+		unsetValue := OptUint64GreaterThanZero{}
+		assertIsDefined(t, false, unsetValue)
+		assert.Equal(t, uint64(999), unsetValue.GetOrElse(999))
+	})
+
+	t.Run("defined value", func(t *testing.T) {
+		oneValue, err := NewOptUint64GreaterThanZero(1)
+		assert.NoError(t, err)
+		assertIsDefined(t, true, oneValue)
+		assert.Equal(t, uint64(1), oneValue.GetOrElse(0))
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		zeroValue, err := NewOptUint64GreaterThanZero(0)
+		assert.Equal(t, errMustBeGreaterThanZero(), err)
+		assert.Equal(t, OptUint64GreaterThanZero{}, zeroValue)
+	})
+
+	stringCtor := func(input string) (interface{}, error) {
+		o, err := NewOptUint64GreaterThanZeroFromString(input)
+		return o, err
+	}
+
+	assertConvertToText(t, map[string]textMarshalerAndStringer{
+		"": OptUint64GreaterThanZero{}, "100": mustOptUint64GreaterThanZero(100),
+	})
+
+	assertConvertFromText(t, &OptUint64GreaterThanZero{}, stringCtor, map[string]interface{}{
+		"": OptUint64GreaterThanZero{}, "100": mustOptUint64GreaterThanZero(100),
+	})
+
+	assertConvertFromTextFails(t, &OptUint64GreaterThanZero{}, stringCtor, errIntFormat(),
+		"-", "0.5", "x",
+	)
+
+	assertConvertFromTextFails(t, &OptUint64GreaterThanZero{}, stringCtor, errMustBeGreaterThanZero(),
+		"0", "-0",
+	)
+
+	assertConvertFromTextFails(t, &OptUint64GreaterThanZero{}, stringCtor, errIntOutOfRange(0, math.MaxUint64),
+		"-1",
+	)
+
+	assertConvertToJSON(t, map[string]SingleValue{
+		`null`: OptUint64GreaterThanZero{}, `100`: mustOptUint64GreaterThanZero(100),
+	})
+
+	assertConvertFromJSON(t, &OptUint64GreaterThanZero{}, map[string]interface{}{
+		`null`: OptUint64GreaterThanZero{}, `100`: mustOptUint64GreaterThanZero(100),
+		`"100"`: mustOptUint64GreaterThanZero(100),
+	})
+
+	assertConvertFromJSONFails(t, &OptUint64GreaterThanZero{},
+		`true`, `0`, `-1`, `"0"`, `0.5`, `"x"`, `[]`, `{}`)
+}
@@ -0,0 +1,96 @@
+package configtypes
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptUint64(t *testing.T) {
+	outOfRange := errIntOutOfRange(0, math.MaxUint64)
+
+	t.Run("empty value", func(t *testing.T) {
+		unsetValue := OptUint64{}
+		assertIsDefined(t, false, unsetValue)
+		assert.Equal(t, uint64(999), unsetValue.GetOrElse(999))
+		assert.Nil(t, unsetValue.Get())
+	})
+
+	t.Run("defined value", func(t *testing.T) {
+		zeroValue := NewOptUint64(0)
+		assertIsDefined(t, true, zeroValue)
+		assert.Equal(t, uint64(0), zeroValue.GetOrElse(999))
+
+		maxValue := NewOptUint64(math.MaxUint64)
+		assertIsDefined(t, true, maxValue)
+		assert.Equal(t, uint64(math.MaxUint64), maxValue.GetOrElse(0))
+		assert.Equal(t, uint64(math.MaxUint64), maxValue.Get())
+	})
+
+	stringCtor := func(input string) (interface{}, error) {
+		o, err := NewOptUint64FromString(input)
+		return o, err
+	}
+
+	assertConvertToText(t, map[string]textMarshalerAndStringer{
+		"":                     OptUint64{},
+		"0":                    NewOptUint64(0),
+		"100":                  NewOptUint64(100),
+		"18446744073709551615": NewOptUint64(math.MaxUint64),
+	})
+
+	assertConvertFromText(t, &OptUint64{}, stringCtor, map[string]interface{}{
+		"":                     OptUint64{},
+		"0":                    NewOptUint64(0),
+		"-0":                   NewOptUint64(0),
+		"+100":                 NewOptUint64(100),
+		"18446744073709551615": NewOptUint64(math.MaxUint64),
+	})
+
+	assertConvertFromTextFails(t, &OptUint64{}, stringCtor, errIntFormat(),
+		"-", "0.5", "1e3", "x",
+	)
+
+	assertConvertFromTextFails(t, &OptUint64{}, stringCtor, outOfRange,
+		"-1", "18446744073709551616",
+	)
+
+	assertConvertToJSON(t, map[string]SingleValue{
+		`null`: OptUint64{}, `0`: NewOptUint64(0), `100`: NewOptUint64(100),
+	})
+
+	assertConvertFromJSON(t, &OptUint64{}, map[string]interface{}{
+		`null`:  OptUint64{},
+		`0`:     NewOptUint64(0),
+		`100`:   NewOptUint64(100),
+		`1e3`:   NewOptUint64(1000),
+		`"100"`: NewOptUint64(100),
+	})
+
+	assertConvertFromJSONFails(t, &OptUint64{},
+		`true`, `-1`, `0.5`, `""`, `"x"`, `"-1"`, `[]`, `{}`, `1e100`, `"18446744073709551616"`)
+
+	t.Run("JSON is exact beyond 2^53", func(t *testing.T) {
+		for input, expected := range map[string]uint64{
+			`9007199254740993`:         9007199254740993,
+			`18446744073709551615`:     math.MaxUint64,
+			`"18446744073709551615"`:   math.MaxUint64,
+			`1.8446744073709551615e19`: math.MaxUint64,
+		} {
+			var o OptUint64
+			assert.NoError(t, o.UnmarshalJSON([]byte(input)), input)
+			assert.Equal(t, NewOptUint64(expected), o, input)
+		}
+
+		data, err := NewOptUint64(math.MaxUint64).MarshalJSON()
+		assert.NoError(t, err)
+		assert.Equal(t, "18446744073709551615", string(data))
+	})
+
+	t.Run("JSON out of range", func(t *testing.T) {
+		var o OptUint64
+		assert.Equal(t, outOfRange, o.UnmarshalJSON([]byte(`18446744073709551616`)))
+		assert.Equal(t, outOfRange, o.UnmarshalJSON([]byte(`-1`)))
+	})
+}
@@ -0,0 +1,169 @@
+package configtypes
+
+import (
+	"database/sql/driver"
+
+	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"gopkg.in/yaml.v3"
+)
+
+// OptUintGreaterThanZero represents an optional uint parameter which, if defined, must be greater than
+// zero.
+//
+// This is the same as OptUint, but with additional validation for the constructor and unmarshalers. It
+// is impossible (except with reflection) for code outside this package to construct an instance of this
+// type with a defined value that is zero.
+//
+// See the package documentation for the general contract for methods that have no specific documentation
+// here.
+type OptUintGreaterThanZero struct {
+	opt OptUint
+}
+
+func NewOptUintGreaterThanZero(value uint) (OptUintGreaterThanZero, error) {
+	return optUintGreaterThanZeroFromOptUint(NewOptUint(value))
+}
+
+func NewOptUintGreaterThanZeroFromString(s string) (OptUintGreaterThanZero, error) {
+	o, err := NewOptUintFromString(s)
+	if err != nil {
+		return OptUintGreaterThanZero{}, err
+	}
+	return optUintGreaterThanZeroFromOptUint(o)
+}
+
+// NewOptUintGreaterThanZeroFromLDValue converts an ldvalue.Value to OptUintGreaterThanZero, with the
+// same rules as UnmarshalJSON.
+func NewOptUintGreaterThanZeroFromLDValue(v ldvalue.Value) (OptUintGreaterThanZero, error) {
+	opt, err := NewOptUintFromLDValue(v)
+	if err != nil {
+		return OptUintGreaterThanZero{}, err
+	}
+	return optUintGreaterThanZeroFromOptUint(opt)
+}
+
+func optUintGreaterThanZeroFromOptUint(o OptUint) (OptUintGreaterThanZero, error) {
+	if !o.IsDefined() || o.GetOrElse(0) > 0 {
+		return OptUintGreaterThanZero{o}, nil
+	}
+	return OptUintGreaterThanZero{}, errMustBeGreaterThanZero()
+}
+
+func (o OptUintGreaterThanZero) IsDefined() bool {
+	return o.opt.IsDefined()
+}
+
+func (o OptUintGreaterThanZero) GetOrElse(orElseValue uint) uint {
+	return o.opt.GetOrElse(orElseValue)
+}
+
+func (o *OptUintGreaterThanZero) UnmarshalText(data []byte) error {
+	var opt OptUint
+	if err := opt.UnmarshalText(data); err != nil {
+		return err
+	}
+	value, err := optUintGreaterThanZeroFromOptUint(opt)
+	if err == nil {
+		*o = value
+	}
+	return err
+}
+
+// Set is the same as UnmarshalText. It allows OptUintGreaterThanZero to be used as a flag.Value.
+func (o *OptUintGreaterThanZero) Set(s string) error {
+	return o.UnmarshalText([]byte(s))
+}
+
+// Get returns the value as a uint, or nil if it is not defined. It allows OptUintGreaterThanZero to be
+// used as a flag.Getter.
+func (o OptUintGreaterThanZero) Get() interface{} {
+	if !o.IsDefined() {
+		return nil
+	}
+	return o.GetOrElse(0)
+}
+
+func (o OptUintGreaterThanZero) String() string {
+	return o.opt.String()
+}
+
+func (o OptUintGreaterThanZero) MarshalText() ([]byte, error) {
+	return o.opt.MarshalText()
+}
+
+func (o *OptUintGreaterThanZero) UnmarshalJSON(data []byte) error {
+	var opt OptUint
+	if err := opt.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	value, err := optUintGreaterThanZeroFromOptUint(opt)
+	if err == nil {
+		*o = value
+	}
+	return err
+}
+
+// AsLDValue converts the value to an ldvalue.Value, with the same rules as MarshalJSON.
+func (o OptUintGreaterThanZero) AsLDValue() ldvalue.Value {
+	return o.opt.AsLDValue()
+}
+
+func (o OptUintGreaterThanZero) MarshalJSON() ([]byte, error) {
+	return o.opt.MarshalJSON()
+}
+
+// MarshalYAML converts this type to a YAML value, with the same rules as MarshalJSON.
+func (o OptUintGreaterThanZero) MarshalYAML() (interface{}, error) {
+	return o.opt.MarshalYAML()
+}
+
+// UnmarshalYAML converts a YAML value to this type, with the same rules as UnmarshalJSON.
+func (o *OptUintGreaterThanZero) UnmarshalYAML(node *yaml.Node) error {
+	return unmarshalYAMLAsJSON(node, o)
+}
+
+// Value implements driver.Valuer, with the same rules as OptUint.
+func (o OptUintGreaterThanZero) Value() (driver.Value, error) {
+	return o.opt.Value()
+}
+
+// Scan implements sql.Scanner, with the same rules as OptUint.
+func (o *OptUintGreaterThanZero) Scan(src interface{}) error {
+	var opt OptUint
+	if err := opt.Scan(src); err != nil {
+		return err
+	}
+	value, err := optUintGreaterThanZeroFromOptUint(opt)
+	if err == nil {
+		*o = value
+	}
+	return err
+}
+
+// MarshalBinary converts the value to the binary format described in the package documentation.
+func (o OptUintGreaterThanZero) MarshalBinary() ([]byte, error) {
+	return o.opt.MarshalBinary()
+}
+
+// UnmarshalBinary converts data produced by MarshalBinary, with the same validation as UnmarshalText.
+func (o *OptUintGreaterThanZero) UnmarshalBinary(data []byte) error {
+	var opt OptUint
+	if err := opt.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	value, err := optUintGreaterThanZeroFromOptUint(opt)
+	if err == nil {
+		*o = value
+	}
+	return err
+}
+
+// GobEncode is the same as MarshalBinary. It allows OptUintGreaterThanZero to be used with encoding/gob.
+func (o OptUintGreaterThanZero) GobEncode() ([]byte, error) {
+	return o.MarshalBinary()
+}
+
+// GobDecode is the same as UnmarshalBinary. It allows OptUintGreaterThanZero to be used with encoding/gob.
+func (o *OptUintGreaterThanZero) GobDecode(data []byte) error {
+	return o.UnmarshalBinary(data)
+}
@@ -0,0 +1,74 @@
+package configtypes
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func mustOptUintGreaterThanZero(n uint) OptUintGreaterThanZero {
+	o, err := NewOptUintGreaterThanZero(n)
+	if err != nil {
+		panic(err)
+	}
+	return o
+}
+
+func TestOptUintGreaterThanZero(t *testing.T) {
+	t.Run("empty value", func(t *testing.T) {
+		unsetValue := OptUintGreaterThanZero{}
+		assertIsDefined(t, false, unsetValue)
+		assert.Equal(t, uint(999), unsetValue.GetOrElse(999))
+	})
+
+	t.Run("defined value", func(t *testing.T) {
+		oneValue, err := NewOptUintGreaterThanZero(1)
+		assert.NoError(t, err)
+		assertIsDefined(t, true, oneValue)
+		assert.Equal(t, uint(1), oneValue.GetOrElse(0))
+	})
+
+	t.Run("invalid value", func(t *testing.T) {
+		zeroValue, err := NewOptUintGreaterThanZero(0)
+		assert.Equal(t, errMustBeGreaterThanZero(), err)
+		assert.Equal(t, OptUintGreaterThanZero{}, zeroValue)
+	})
+
+	stringCtor := func(input string) (interface{}, error) {
+		o, err := NewOptUintGreaterThanZeroFromString(input)
+		return o, err
+	}
+
+	assertConvertToText(t, map[string]textMarshalerAndStringer{
+		"": OptUintGreaterThanZero{}, "100": mustOptUintGreaterThanZero(100),
+	})
+
+	assertConvertFromText(t, &OptUintGreaterThanZero{}, stringCtor, map[string]interface{}{
+		"": OptUintGreaterThanZero{}, "100": mustOptUintGreaterThanZero(100),
+	})
+
+	assertConvertFromTextFails(t, &OptUintGreaterThanZero{}, stringCtor, errIntFormat(),
+		"-", "0.5", "x",
+	)
+
+	assertConvertFromTextFails(t, &OptUintGreaterThanZero{}, stringCtor, errMustBeGreaterThanZero(),
+		"0", "-0",
+	)
+
+	assertConvertFromTextFails(t, &OptUintGreaterThanZero{}, stringCtor, errIntOutOfRange(0, math.MaxUint),
+		"-1",
+	)
+
+	assertConvertToJSON(t, map[string]SingleValue{
+		`null`: OptUintGreaterThanZero{}, `100`: mustOptUintGreaterThanZero(100),
+	})
+
+	assertConvertFromJSON(t, &OptUintGreaterThanZero{}, map[string]interface{}{
+		`null`: OptUintGreaterThanZero{}, `100`: mustOptUintGreaterThanZero(100),
+		`"100"`: mustOptUintGreaterThanZero(100),
+	})
+
+	assertConvertFromJSONFails(t, &OptUintGreaterThanZero{},
+		`true`, `0`, `-1`, `"0"`, `0.5`, `"x"`, `[]`, `{}`)
+}
@@ -0,0 +1,78 @@
+package configtypes
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptUint(t *testing.T) {
+	outOfRange := errIntOutOfRange(0, math.MaxUint)
+	maxString := strconv.FormatUint(math.MaxUint, 10)
+	tooLargeString := new(big.Int).Add(new(big.Int).SetUint64(math.MaxUint), big.NewInt(1)).String()
+
+	t.Run("empty value", func(t *testing.T) {
+		unsetValue := OptUint{}
+		assertIsDefined(t, false, unsetValue)
+		assert.Equal(t, uint(999), unsetValue.GetOrElse(999))
+		assert.Nil(t, unsetValue.Get())
+	})
+
+	t.Run("defined value", func(t *testing.T) {
+		zeroValue := NewOptUint(0)
+		assertIsDefined(t, true, zeroValue)
+		assert.Equal(t, uint(0), zeroValue.GetOrElse(999))
+
+		maxValue := NewOptUint(math.MaxUint)
+		assertIsDefined(t, true, maxValue)
+		assert.Equal(t, uint(math.MaxUint), maxValue.GetOrElse(0))
+		assert.Equal(t, uint(math.MaxUint), maxValue.Get())
+	})
+
+	stringCtor := func(input string) (interface{}, error) {
+		o, err := NewOptUintFromString(input)
+		return o, err
+	}
+
+	assertConvertToText(t, map[string]textMarshalerAndStringer{
+		"": OptUint{}, "0": NewOptUint(0), "100": NewOptUint(100), maxString: NewOptUint(math.MaxUint),
+	})
+
+	assertConvertFromText(t, &OptUint{}, stringCtor, map[string]interface{}{
+		"": OptUint{}, "0": NewOptUint(0), "+100": NewOptUint(100), maxString: NewOptUint(math.MaxUint),
+	})
+
+	assertConvertFromTextFails(t, &OptUint{}, stringCtor, errIntFormat(),
+		"-", "0.5", "1e3", "x",
+	)
+
+	assertConvertFromTextFails(t, &OptUint{}, stringCtor, outOfRange,
+		"-1", tooLargeString,
+	)
+
+	assertConvertToJSON(t, map[string]SingleValue{
+		`null`: OptUint{}, `0`: NewOptUint(0), `100`: NewOptUint(100),
+	})
+
+	assertConvertFromJSON(t, &OptUint{}, map[string]interface{}{
+		`null`: OptUint{}, `0`: NewOptUint(0), `1e3`: NewOptUint(1000), `"100"`: NewOptUint(100),
+	})
+
+	assertConvertFromJSONFails(t, &OptUint{},
+		`true`, `-1`, `0.5`, `""`, `"x"`, `"-1"`, `[]`, `{}`, `1e100`, `"`+tooLargeString+`"`)
+
+	t.Run("JSON is exact for the whole range", func(t *testing.T) {
+		var o OptUint
+		assert.NoError(t, o.UnmarshalJSON([]byte(maxString)))
+		assert.Equal(t, NewOptUint(math.MaxUint), o)
+
+		data, err := o.MarshalJSON()
+		assert.NoError(t, err)
+		assert.Equal(t, maxString, string(data))
+
+		assert.Equal(t, outOfRange, o.UnmarshalJSON([]byte(tooLargeString)))
+	})
+}
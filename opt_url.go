@@ -1,10 +1,12 @@
 package configtypes
 
 import (
+	"database/sql/driver"
 	"encoding/json"
 	"net/url"
 
 	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"gopkg.in/yaml.v3"
 )
 
 // OptURL represents an optional parameter which, if present, must be a valid URL.
@@ -42,6 +44,18 @@ func NewOptURLFromString(urlString string) (OptURL, error) {
 	return OptURL{}, errURLFormat()
 }
 
+// NewOptURLFromLDValue converts an ldvalue.Value to OptURL, with the same rules as UnmarshalJSON.
+func NewOptURLFromLDValue(v ldvalue.Value) (OptURL, error) {
+	switch {
+	case v.IsNull():
+		return OptURL{}, nil
+	case v.IsString():
+		return NewOptURLFromString(v.StringValue())
+	default:
+		return OptURL{}, errURLFormat()
+	}
+}
+
 func (o OptURL) IsDefined() bool {
 	return o.url != nil
 }
@@ -76,6 +90,21 @@ func (o *OptURL) UnmarshalText(data []byte) error {
 	return err
 }
 
+// Set is the same as UnmarshalText. It allows OptURL to be used as a flag.Value. It cannot be used as
+// a flag.Getter, because its Get method returns a *url.URL; a flag registered by BindFlags is a
+// flag.Getter for any field type.
+func (o *OptURL) Set(s string) error {
+	return o.UnmarshalText([]byte(s))
+}
+
+// AsLDValue converts the value to an ldvalue.Value, with the same rules as MarshalJSON.
+func (o OptURL) AsLDValue() ldvalue.Value {
+	if o.url == nil {
+		return ldvalue.Null()
+	}
+	return ldvalue.String(o.url.String())
+}
+
 func (o OptURL) MarshalJSON() ([]byte, error) {
 	if o.url != nil {
 		return json.Marshal(o.url.String())
@@ -85,18 +114,73 @@ func (o OptURL) MarshalJSON() ([]byte, error) {
 
 func (o *OptURL) UnmarshalJSON(data []byte) error {
 	var v ldvalue.Value
-	var err error
-	if err = v.UnmarshalJSON(data); err != nil {
+	if err := v.UnmarshalJSON(data); err != nil {
 		return err
 	}
-	switch {
-	case v.IsNull():
+	opt, err := NewOptURLFromLDValue(v)
+	if err == nil || v.IsString() {
+		*o = opt // a string that cannot be parsed leaves the value empty
+	}
+	return err
+}
+
+// MarshalYAML converts this type to a YAML value, with the same rules as MarshalJSON.
+func (o OptURL) MarshalYAML() (interface{}, error) {
+	if o.url != nil {
+		return o.url.String(), nil
+	}
+	return nil, nil //nolint:nilnil
+}
+
+// UnmarshalYAML converts a YAML value to this type, with the same rules as UnmarshalJSON.
+func (o *OptURL) UnmarshalYAML(node *yaml.Node) error {
+	return unmarshalYAMLAsJSON(node, o)
+}
+
+// Value implements driver.Valuer, so that the value can be written to a database with database/sql.
+// An empty value is NULL; otherwise it is a string.
+func (o OptURL) Value() (driver.Value, error) {
+	if !o.IsDefined() {
+		return nil, nil //nolint:nilnil
+	}
+	return o.String(), nil
+}
+
+// Scan implements sql.Scanner, so that the value can be read from a database with database/sql. NULL
+// becomes an empty value; a string in the same format as UnmarshalText is also
+// allowed.
+func (o *OptURL) Scan(src interface{}) error {
+	if src == nil {
 		*o = OptURL{}
 		return nil
-	case v.IsString():
-		*o, err = NewOptURLFromString(v.StringValue())
+	}
+	return scanSQLText(src, o)
+}
+
+// MarshalBinary converts the value to the binary format described in the package documentation.
+func (o OptURL) MarshalBinary() ([]byte, error) {
+	return marshalOptBinary(o.IsDefined(), []byte(o.String())), nil
+}
+
+// UnmarshalBinary converts data produced by MarshalBinary, with the same validation as UnmarshalText.
+func (o *OptURL) UnmarshalBinary(data []byte) error {
+	payload, _, err := unmarshalOptBinary(data)
+	if err != nil {
 		return err
-	default:
-		return errURLFormat()
 	}
+	opt, err := NewOptURLFromString(string(payload))
+	if err == nil {
+		*o = opt
+	}
+	return err
+}
+
+// GobEncode is the same as MarshalBinary. It allows OptURL to be used with encoding/gob.
+func (o OptURL) GobEncode() ([]byte, error) {
+	return o.MarshalBinary()
+}
+
+// GobDecode is the same as UnmarshalBinary. It allows OptURL to be used with encoding/gob.
+func (o *OptURL) GobDecode(data []byte) error {
+	return o.UnmarshalBinary(data)
 }
@@ -1,9 +1,11 @@
 package configtypes
 
 import (
+	"database/sql/driver"
 	"net/url"
 
 	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"gopkg.in/yaml.v3"
 )
 
 // OptURLAbsolute represents an optional URL parameter which, if defined, must be an absolute URL.
@@ -33,6 +35,18 @@ func NewOptURLAbsoluteFromString(urlString string) (OptURLAbsolute, error) {
 	return NewOptURLAbsolute(opt.Get())
 }
 
+// NewOptURLAbsoluteFromLDValue converts an ldvalue.Value to OptURLAbsolute, with the same rules as UnmarshalJSON.
+func NewOptURLAbsoluteFromLDValue(v ldvalue.Value) (OptURLAbsolute, error) {
+	switch {
+	case v.IsNull():
+		return OptURLAbsolute{}, nil
+	case v.IsString():
+		return NewOptURLAbsoluteFromString(v.StringValue())
+	default:
+		return OptURLAbsolute{}, errURLFormat()
+	}
+}
+
 func (o OptURLAbsolute) IsDefined() bool {
 	return o.opt.IsDefined()
 }
@@ -57,24 +71,82 @@ func (o *OptURLAbsolute) UnmarshalText(data []byte) error {
 	return err
 }
 
+// Set is the same as UnmarshalText. It allows OptURLAbsolute to be used as a flag.Value. It cannot be
+// used as a flag.Getter, because its Get method returns a *url.URL; a flag registered by BindFlags is
+// a flag.Getter for any field type.
+func (o *OptURLAbsolute) Set(s string) error {
+	return o.UnmarshalText([]byte(s))
+}
+
+// AsLDValue converts the value to an ldvalue.Value, with the same rules as MarshalJSON.
+func (o OptURLAbsolute) AsLDValue() ldvalue.Value {
+	return o.opt.AsLDValue()
+}
+
 func (o OptURLAbsolute) MarshalJSON() ([]byte, error) {
 	return o.opt.MarshalJSON()
 }
 
 func (o *OptURLAbsolute) UnmarshalJSON(data []byte) error {
 	var v ldvalue.Value
-	var err error
-	if err = v.UnmarshalJSON(data); err != nil {
+	if err := v.UnmarshalJSON(data); err != nil {
 		return err
 	}
-	switch {
-	case v.IsNull():
+	opt, err := NewOptURLAbsoluteFromLDValue(v)
+	if err == nil || v.IsString() {
+		*o = opt // a string that cannot be parsed leaves the value empty
+	}
+	return err
+}
+
+// MarshalYAML converts this type to a YAML value, with the same rules as MarshalJSON.
+func (o OptURLAbsolute) MarshalYAML() (interface{}, error) {
+	return o.opt.MarshalYAML()
+}
+
+// UnmarshalYAML converts a YAML value to this type, with the same rules as UnmarshalJSON.
+func (o *OptURLAbsolute) UnmarshalYAML(node *yaml.Node) error {
+	return unmarshalYAMLAsJSON(node, o)
+}
+
+// Value implements driver.Valuer, with the same rules as OptURL.
+func (o OptURLAbsolute) Value() (driver.Value, error) {
+	return o.opt.Value()
+}
+
+// Scan implements sql.Scanner, with the same rules as OptURL.
+func (o *OptURLAbsolute) Scan(src interface{}) error {
+	if src == nil {
 		*o = OptURLAbsolute{}
 		return nil
-	case v.IsString():
-		*o, err = NewOptURLAbsoluteFromString(v.StringValue())
+	}
+	return scanSQLText(src, o)
+}
+
+// MarshalBinary converts the value to the binary format described in the package documentation.
+func (o OptURLAbsolute) MarshalBinary() ([]byte, error) {
+	return o.opt.MarshalBinary()
+}
+
+// UnmarshalBinary converts data produced by MarshalBinary, with the same validation as UnmarshalText.
+func (o *OptURLAbsolute) UnmarshalBinary(data []byte) error {
+	payload, _, err := unmarshalOptBinary(data)
+	if err != nil {
 		return err
-	default:
-		return errURLFormat()
 	}
+	opt, err := NewOptURLAbsoluteFromString(string(payload))
+	if err == nil {
+		*o = opt
+	}
+	return err
+}
+
+// GobEncode is the same as MarshalBinary. It allows OptURLAbsolute to be used with encoding/gob.
+func (o OptURLAbsolute) GobEncode() ([]byte, error) {
+	return o.MarshalBinary()
+}
+
+// GobDecode is the same as UnmarshalBinary. It allows OptURLAbsolute to be used with encoding/gob.
+func (o *OptURLAbsolute) GobDecode(data []byte) error {
+	return o.UnmarshalBinary(data)
 }
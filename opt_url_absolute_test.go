@@ -70,4 +70,7 @@ func TestOptURLAbsolute(t *testing.T) {
 
 	assertConvertFromJSONFails(t, &OptURLAbsolute{},
 		`true`, `0.5`, quoteJSONString(relativeURLString), quoteJSONString(malformedURLString), `[]`, `{}`)
+
+	assertUnmarshalJSONClearsForInvalidString(t, &OptURLAbsolute{}, mustOptURLAbsolute(absoluteURL),
+		quoteJSONString(relativeURLString))
 }
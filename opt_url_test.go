@@ -67,4 +67,6 @@ func TestOptURL(t *testing.T) {
 
 	assertConvertFromJSONFails(t, &OptURL{},
 		`true`, `0.5`, quoteJSONString(malformedURLString), `[]`, `{}`)
+
+	assertUnmarshalJSONClearsForInvalidString(t, &OptURL{}, NewOptURL(absoluteURL), quoteJSONString(malformedURLString))
 }
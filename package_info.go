@@ -13,6 +13,16 @@ The "Opt" types represent optional values of some wrapped type. Some of them all
 Others have additional validation, indicated by extra words after the name of the type: for
 instance, OptIntGreaterThanZero is like OptInt except the value must be greater than zero.
 
+For numeric ranges that are not covered by a predefined type, the generic types OptIntBetween,
+OptInt64Between, OptFloat64Between, and OptDurationBetween take a type parameter that implements
+Bounds, whose Range method returns the allowed NumericRange:
+
+	type PortBounds struct{}
+
+	func (PortBounds) Range() configtypes.NumericRange[int] { return configtypes.RangeBetween(1, 65535) }
+
+	var port configtypes.OptIntBetween[PortBounds]
+
 Any instance can be in either a "defined" or an "empty" state. Defined means that it contains a
 value of the wrapped type. Empty means no value was specified. The IsDefined() method tests this
 state.
@@ -66,11 +76,55 @@ behavior for these types.
 The String and MarshalText methods do the reverse, returning an empty string if empty or else
 a string that is in the same format used by the parsing methods.
 
+The Set method does the same thing as UnmarshalText, so that Opt types can be used as command-line
+flags with the flag package (flag.Value). Most of them also implement flag.Getter; the exceptions
+are OptBase2Bytes, OptURL, and OptURLAbsolute, whose Get methods predate flag support and return a
+specific type. The flags that BindFlags registers are always flag.Getters, whose Get method returns
+the field's value.
+
 # Converting Opt types to or from JSON
 
 These types also implement the json.Marshaler and json.Unmarshaler interfaces. An empty value
 always corresponds to a JSON null; otherwise, the JSON mapping depends on the type, so for
-instance a non-empty OptBool is always a JSON boolean.
+instance a non-empty OptBool is always a JSON boolean. The JSONSchema function describes these
+mappings for all fields of a struct as a JSON Schema.
+
+OptInt64, OptUint, and OptUint64 (and their GreaterThanZero variants) parse JSON numbers exactly,
+without converting them to float64, so integers beyond 2^53 do not lose precision. They also accept
+a JSON string containing a decimal integer, and report a value that is too large for the type as out
+of range rather than silently truncating it.
+
+The same mappings are available without going through JSON bytes, for use with LaunchDarkly SDKs:
+the AsLDValue method converts a value to an ldvalue.Value, and the NewOptFooFromLDValue constructor
+converts an ldvalue.Value to the type with the same rules as UnmarshalJSON. As with MarshalJSON, the
+AsLDValue method of a secret type returns the redacted form.
+
+# Converting Opt types to or from a binary format
+
+These types also implement encoding.BinaryMarshaler and encoding.BinaryUnmarshaler, and the
+equivalent GobEncoder and GobDecoder interfaces of encoding/gob, so that a configuration struct can
+be sent to another process without losing any information. Unlike the text format, the binary format
+always distinguishes an empty value from a defined one, and does not redact secrets. Decoding a value
+applies the same validation as the other unmarshalers, so for instance decoding a negative duration
+into an OptDurationNonNegative is an error.
+
+# Storing Opt types in a database
+
+All of the Opt types also implement sql.Scanner and driver.Valuer, so they can be used directly as
+query parameters and as destinations for Rows.Scan with database/sql. An empty value is always NULL.
+SQLDurationAsNanoseconds and SQLStringListAsJSON select an alternate column format for OptDuration
+and OptStringList.
+
+# Secrets
+
+OptSecret is for sensitive values such as passwords or API keys. All of its methods that produce
+text or JSON, including its implementations of fmt.Formatter and slog.LogValuer, produce a redacted
+form such as "****abcd", so that the value is not exposed if a configuration struct is logged; the
+Reveal method returns the actual value. ReqSecret is the same except that it always requires a
+value. A field tag can also have a "secret" option, which causes VarReader to redact the value of
+that field in error messages and in VarReader.Provenance. The Dump function, which describes the
+effective values of all fields of a struct for logging, redacts secrets in the same way, as does the
+Diff function, which describes what changed between two versions of a configuration struct.
 
 # Opt types with multiple values
 
@@ -90,6 +144,14 @@ The former can be done with any package that can interact with types via the enc
 interface, such as https://github.com/launchdarkly/gcfg. Attempting to set a field's string value
 with this interface causes parsing to fail if the string format is not valid for the field's type,
 for example if a string that is not an absolute URL is specified for a field of type OptURLAbsolute.
+LoadINI reads the same INI format as gcfg without needing another package, mapping sections to
+nested structs and reporting every invalid value with its line in the file. LoadTOML does the same
+for TOML files, in which date-time values can be used for fields of type OptTime.
+
+LoadJSON reads a JSON file into a struct. Unlike encoding/json, it reports every invalid value, with
+its line and column in the file, instead of stopping at the first one; it can also report properties
+that do not correspond to any field. See LoadOptions. LoadYAML does the same for YAML files, and all
+of the Opt types also implement the Marshaler and Unmarshaler interfaces of gopkg.in/yaml.v3.
 
 The VarReader type adapts the same functionality, but reads values from environment variables (or
 from a name-value map). You can read values one at a time, specifying each variable name, or you can
@@ -99,9 +161,38 @@ Since field names that do not appear in the parsed file or in the environment va
 modified, you can use both of these methods together: that is, read a configuration file that sets
 some fields in a struct, and then allow environment variables to override other fields.
 
+BindFlags registers a command-line flag for each field that VarReader would read, so that flags can
+override both of those.
+
+NewVarReaderFromSources combines several sources of variables, such as environment variables, a
+.env file, a directory in which each file is a variable, a JSON file, command-line arguments
+(CommandLineVarSource), or LaunchDarkly feature flags (LDFlagVarSource), with later sources taking
+precedence; see VarSource. Watcher uses the same sources to keep a configuration struct up to date,
+re-reading them periodically and publishing each new configuration to subscribers only if it is
+valid. Holder stores a validated configuration struct so that it can be read and updated safely
+from any number of goroutines.
+
+VarWriter does the reverse of VarReader, producing variables from the fields of a struct in a form
+that VarReader will read back as the same values. This can be used to pass configuration to a child
+process, or to generate a .env file.
+
+DescribeVars and WriteVarDocs produce a table describing all of the variables that would be read
+from a struct, which can be used to keep documentation consistent with the code. The confdoc
+command (github.com/launchdarkly/go-configtypes/v2/cmd/confdoc) does the same from the command line.
+
+Errors from validation and from loading configuration are reported as ValidationError values, each
+with a ValidationPath identifying the field. A path is a series of PathSegment values: a field name
+(PathField), or an index or map key (PathIndex, PathKey) for an element of a collection. In version
+1 of this module a ValidationPath was a slice of strings; a path of field names only can be written
+as NewValidationPath("A", "B"). ValidationError also has fields describing where a value came from
+(VarName, Source, Line, and Column) that did not exist in version 1, so a ValidationError literal
+must now use field names, as in ValidationError{Path: path, Err: err}. See CHANGELOG.md for the
+other changes in version 2.
+
 There is a limited ability to enforce that a field must have a value. Go has no way to prevent a
 field or variable from being declared with a zero value for its type, so a struct with a required
 field could always exist in an invalid state, but the Validate() function and VarReader will both
-raise errors if a field that has a ",required" field tag was not set.
+raise errors if a field that has a ",required" field tag was not set, or if the field's type is
+one that always requires a value, such as ReqSecret.
 */
 package configtypes
@@ -1,42 +1,188 @@
 package configtypes
 
 import (
-	"fmt"
 	"reflect"
-	"strings"
+	"sync"
+
+	"github.com/launchdarkly/go-configtypes/v2/internal/conftag"
 )
 
 // This file contains internal helpers for reflection-based functionality.
 
 type fieldTagInfo struct {
-	varName  string
-	required bool
+	varName     string
+	required    bool
+	secret      bool
+	description string
 }
 
-func getFieldTagInfo(field reflect.StructField) (fieldTagInfo, error) {
-	ret := fieldTagInfo{}
-	tagStr := strings.TrimSpace(field.Tag.Get("conf"))
-	if tagStr == "" {
-		return ret, nil
+// structPlan describes the exported fields of a struct type, with their field tags already parsed.
+// Plans are computed only once for each type; see getStructPlan.
+type structPlan struct {
+	fields []fieldPlan
+}
+
+type fieldPlan struct {
+	name    string
+	index   int
+	tagInfo fieldTagInfo
+	tagErr  error
+	kind    fieldKind
+}
+
+type fieldKind int
+
+const (
+	// fieldKindValue is a field that can never refer to a nested struct.
+	fieldKindValue fieldKind = iota
+	// fieldKindStruct is a field that is a struct, or a pointer or interface that might refer to one.
+	fieldKindStruct
+	// fieldKindCollection is a slice, array, or map whose elements are structs or struct pointers.
+	fieldKindCollection
+)
+
+// structPlans is a cache of *structPlan values, keyed by reflect.Type.
+var structPlans sync.Map //nolint:gochecknoglobals
+
+func getStructPlan(structType reflect.Type) *structPlan {
+	if p, ok := structPlans.Load(structType); ok {
+		return p.(*structPlan)
+	}
+	p, _ := structPlans.LoadOrStore(structType, newStructPlan(structType))
+	return p.(*structPlan)
+}
+
+// newStructPlan computes a plan for a struct type. It does not look at the types of nested structs,
+// which get their own plans when they are visited, so it is safe to use with self-referential types.
+func newStructPlan(structType reflect.Type) *structPlan {
+	p := &structPlan{}
+	for i := 0; i < structType.NumField(); i++ {
+		fieldInType := structType.Field(i)
+		if !isFieldExported(fieldInType) {
+			continue
+		}
+		tagInfo, err := getFieldTagInfo(fieldInType)
+		p.fields = append(p.fields, fieldPlan{
+			name:    fieldInType.Name,
+			index:   i,
+			tagInfo: tagInfo,
+			tagErr:  err,
+			kind:    getFieldKind(fieldInType.Type),
+		})
 	}
-	parts := strings.Split(tagStr, ",")
-	ret.varName = strings.TrimSpace(parts[0])
-	for i := 1; i < len(parts); i++ {
-		p := strings.TrimSpace(parts[i])
-		switch p {
-		case "required":
-			ret.required = true
-		default:
-			return ret, fmt.Errorf("unrecognized field tag option %q", p)
+	return p
+}
+
+func getFieldKind(t reflect.Type) fieldKind {
+	switch t.Kind() {
+	case reflect.Struct:
+		if isStructType(t) {
+			return fieldKindStruct
+		}
+	case reflect.Ptr, reflect.Interface:
+		return fieldKindStruct
+	case reflect.Slice, reflect.Array, reflect.Map:
+		if isStructOrStructPtrType(t.Elem()) {
+			return fieldKindCollection
 		}
 	}
-	return ret, nil
+	return fieldKindValue
+}
+
+// visitSet tracks which pointers are currently being traversed, so that a reference cycle in a
+// struct does not cause infinite recursion. The zero value is an empty set.
+type visitSet struct {
+	visiting map[visitKey]bool
+}
+
+type visitKey struct {
+	ptr uintptr
+	t   reflect.Type
+}
+
+// enter marks a non-nil pointer as being traversed, and returns false if it already was.
+func (s *visitSet) enter(refPtr reflect.Value) bool {
+	key := visitKey{refPtr.Pointer(), refPtr.Type()}
+	if s.visiting[key] {
+		return false
+	}
+	if s.visiting == nil {
+		s.visiting = make(map[visitKey]bool)
+	}
+	s.visiting[key] = true
+	return true
+}
+
+func (s *visitSet) leave(refPtr reflect.Value) {
+	delete(s.visiting, visitKey{refPtr.Pointer(), refPtr.Type()})
+}
+
+// walkNestedStruct calls fn for the struct that a field refers to, if the field is a struct or a
+// non-nil struct pointer. A pointer that refers to a struct that is already being traversed further up
+// in the tree is skipped. This is how every function that reads or describes the variables of a
+// struct, such as VarReader.ReadStruct with recursive set to true, traverses nested structs.
+func walkNestedStruct(fieldInInstance reflect.Value, visited *visitSet, fn func(refStruct reflect.Value) error) error {
+	switch fieldInInstance.Kind() {
+	case reflect.Struct:
+		return fn(fieldInInstance)
+	case reflect.Ptr:
+		if fieldInInstance.IsNil() || !isStructType(fieldInInstance.Type().Elem()) || !visited.enter(fieldInInstance) {
+			return nil
+		}
+		defer visited.leave(fieldInInstance)
+		return fn(fieldInInstance.Elem())
+	}
+	return nil
+}
+
+// getFieldTagInfo parses a field tag. A field whose type implements RequiredValue or SecretValue is
+// treated as if the tag had the "required" or "secret" option.
+func getFieldTagInfo(field reflect.StructField) (fieldTagInfo, error) {
+	info, err := conftag.Parse(field.Tag.Get(conftag.Name))
+	return fieldTagInfo{
+		varName:     info.VarName,
+		required:    info.Required || isRequiredValueType(field.Type),
+		secret:      info.Secret || isSecretValueType(field.Type),
+		description: info.Description,
+	}, err
+}
+
+func isRequiredValueType(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.Interface {
+		return false
+	}
+	if rv, ok := reflect.New(t).Interface().(RequiredValue); ok {
+		return rv.IsRequired()
+	}
+	return false
+}
+
+func isSecretValueType(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() != reflect.Interface && reflect.PointerTo(t).Implements(reflect.TypeOf((*SecretValue)(nil)).Elem())
 }
 
 func isFieldExported(field reflect.StructField) bool {
 	return field.PkgPath == ""
 }
 
+// isStructType returns true if the type is a struct that is not one of our own Opt types.
+func isStructType(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && !t.Implements(reflect.TypeOf((*SingleValue)(nil)).Elem())
+}
+
+func isStructOrStructPtrType(t reflect.Type) bool {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return isStructType(t)
+}
+
 func getReflectValueForStruct(value interface{}) (reflect.Value, bool) {
 	refValue := reflect.ValueOf(value)
 	if refValue.Kind() == reflect.Struct {
@@ -47,6 +193,9 @@ func getReflectValueForStruct(value interface{}) (reflect.Value, bool) {
 		return refValue, true
 	}
 	if refValue.Kind() == reflect.Ptr {
+		if refValue.IsNil() {
+			return reflect.Value{}, false
+		}
 		return getReflectValueForStruct(refValue.Elem().Interface())
 	}
 	return reflect.Value{}, false
@@ -63,3 +212,84 @@ func getReflectValueForStructPtr(value interface{}) (reflect.Value, bool) {
 	}
 	return refValue.Elem(), true
 }
+
+// deepCopy returns a copy of a value in which every pointer, slice, and map that can be reached
+// through exported fields refers to new storage, so that the copy can be modified without affecting
+// the original. Unexported fields, such as the contents of our own Opt types, are copied with an
+// ordinary assignment. A pointer or map that is reached more than once is copied only once, which
+// preserves sharing within the value and prevents infinite recursion on a reference cycle.
+func deepCopy[T any](value T) T {
+	var ret T
+	c := deepCopier{copies: make(map[visitKey]reflect.Value)}
+	c.copyInto(reflect.ValueOf(&ret).Elem(), reflect.ValueOf(&value).Elem())
+	return ret
+}
+
+type deepCopier struct {
+	copies map[visitKey]reflect.Value
+}
+
+func (c *deepCopier) copyInto(dst, src reflect.Value) {
+	switch src.Kind() {
+	case reflect.Ptr:
+		if src.IsNil() {
+			return
+		}
+		key := visitKey{src.Pointer(), src.Type()}
+		if existing, ok := c.copies[key]; ok {
+			dst.Set(existing)
+			return
+		}
+		ptr := reflect.New(src.Type().Elem())
+		c.copies[key] = ptr
+		c.copyInto(ptr.Elem(), src.Elem())
+		dst.Set(ptr)
+	case reflect.Interface:
+		if src.IsNil() {
+			return
+		}
+		elem := reflect.New(src.Elem().Type()).Elem()
+		c.copyInto(elem, src.Elem())
+		dst.Set(elem)
+	case reflect.Struct:
+		dst.Set(src)
+		for i := 0; i < src.NumField(); i++ {
+			if isFieldExported(src.Type().Field(i)) {
+				c.copyInto(dst.Field(i), src.Field(i))
+			}
+		}
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+		slice := reflect.MakeSlice(src.Type(), src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			c.copyInto(slice.Index(i), src.Index(i))
+		}
+		dst.Set(slice)
+	case reflect.Array:
+		for i := 0; i < src.Len(); i++ {
+			c.copyInto(dst.Index(i), src.Index(i))
+		}
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+		key := visitKey{src.Pointer(), src.Type()}
+		if existing, ok := c.copies[key]; ok {
+			dst.Set(existing)
+			return
+		}
+		m := reflect.MakeMapWithSize(src.Type(), src.Len())
+		c.copies[key] = m
+		iter := src.MapRange()
+		for iter.Next() {
+			elem := reflect.New(src.Type().Elem()).Elem()
+			c.copyInto(elem, iter.Value())
+			m.SetMapIndex(iter.Key(), elem)
+		}
+		dst.Set(m)
+	default:
+		dst.Set(src)
+	}
+}
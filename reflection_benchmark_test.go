@@ -0,0 +1,62 @@
+package configtypes
+
+import (
+	"fmt"
+	"testing"
+)
+
+type benchmarkLeafConfig struct {
+	Name    OptString   `conf:"NAME,required"`
+	Enabled OptBool     `conf:"ENABLED"`
+	Port    OptInt      `conf:"PORT"`
+	Timeout OptDuration `conf:"TIMEOUT"`
+	Ratio   float64     `conf:"RATIO"`
+	Tags    OptStringList
+	URL     OptURL `conf:"URL"`
+	Comment string
+}
+
+type benchmarkLargeConfig struct {
+	A, B, C, D, E, F, G, H benchmarkLeafConfig
+	Upstreams              []benchmarkLeafConfig
+	Named                  map[string]*benchmarkLeafConfig
+	Top1                   OptString `conf:"TOP1,required"`
+	Top2                   OptInt    `conf:"TOP2"`
+	Top3                   bool      `conf:"TOP3"`
+	Top4                   string    `conf:"TOP4"`
+}
+
+func makeBenchmarkLargeConfig() benchmarkLargeConfig {
+	leaf := benchmarkLeafConfig{Name: NewOptString("x"), Port: NewOptInt(1)}
+	c := benchmarkLargeConfig{Top1: NewOptString("x")}
+	for i := 0; i < 50; i++ {
+		c.Upstreams = append(c.Upstreams, leaf)
+	}
+	c.Named = make(map[string]*benchmarkLeafConfig)
+	for i := 0; i < 20; i++ {
+		l := leaf
+		c.Named[fmt.Sprint(i)] = &l
+	}
+	return c
+}
+
+func BenchmarkValidateStruct(b *testing.B) {
+	c := makeBenchmarkLargeConfig()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = ValidateStruct(&c, true)
+	}
+}
+
+func BenchmarkReadStruct(b *testing.B) {
+	values := map[string]string{
+		"NAME": "x", "ENABLED": "true", "PORT": "8080", "TIMEOUT": "1s", "TOP1": "y",
+	}
+	c := makeBenchmarkLargeConfig()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// A new VarReader for each call, so that its provenance and errors do not accumulate
+		r := NewVarReaderFromValues(values)
+		r.ReadStruct(&c, true)
+	}
+}
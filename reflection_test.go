@@ -0,0 +1,139 @@
+package configtypes
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStructPlan(t *testing.T) {
+	t.Run("plan is cached for each type", func(t *testing.T) {
+		structType := reflect.TypeOf(testStructWithTags1{})
+		assert.Same(t, getStructPlan(structType), getStructPlan(structType))
+	})
+
+	t.Run("plan includes only exported fields, with parsed tags", func(t *testing.T) {
+		p := getStructPlan(reflect.TypeOf(structWithStructPointers{}))
+		assert.Equal(t, []fieldPlan{
+			{name: "Optional", index: 0, kind: fieldKindStruct},
+			{name: "Required", index: 1, kind: fieldKindStruct,
+				tagInfo: fieldTagInfo{varName: "REQUIRED_VAR", required: true}},
+		}, p.fields)
+
+		p = getStructPlan(reflect.TypeOf(testStructWithTags1{}))
+		assert.Len(t, p.fields, 5)
+		assert.Equal(t, "FieldWithNoTag", p.fields[0].name)
+		assert.Equal(t, 2, p.fields[1].index)
+	})
+
+	t.Run("plan records invalid tag", func(t *testing.T) {
+		p := getStructPlan(reflect.TypeOf(testStructWithBadTag{}))
+		assert.Error(t, p.fields[0].tagErr)
+	})
+
+	t.Run("plan for self-referential type", func(t *testing.T) {
+		p := getStructPlan(reflect.TypeOf(structWithSelfReference{}))
+		assert.Equal(t, fieldKindStruct, p.fields[1].kind)
+	})
+
+	t.Run("field kinds", func(t *testing.T) {
+		assert.Equal(t, fieldKindValue, getFieldKind(reflect.TypeOf(0)))
+		assert.Equal(t, fieldKindValue, getFieldKind(reflect.TypeOf(OptBool{})))
+		assert.Equal(t, fieldKindValue, getFieldKind(reflect.TypeOf([]OptBool{})))
+		assert.Equal(t, fieldKindStruct, getFieldKind(reflect.TypeOf(testStructWithTags1{})))
+		assert.Equal(t, fieldKindStruct, getFieldKind(reflect.TypeOf(&testStructWithTags1{})))
+		assert.Equal(t, fieldKindCollection, getFieldKind(reflect.TypeOf([]testStructWithTags1{})))
+		assert.Equal(t, fieldKindCollection, getFieldKind(reflect.TypeOf(map[string]*testStructWithTags1{})))
+	})
+}
+
+func TestDeepCopy(t *testing.T) {
+	type inner struct {
+		Name OptString
+	}
+	type outer struct {
+		Ptr       *inner
+		Same      *inner
+		Any       interface{}
+		Slice     []*inner
+		Map       map[string]*inner
+		Array     [1]*inner
+		Self      *outer
+		unexposed *inner
+	}
+	original := &outer{
+		Ptr:   &inner{Name: NewOptString("a")},
+		Any:   &inner{Name: NewOptString("b")},
+		Slice: []*inner{{Name: NewOptString("c")}},
+		Map:   map[string]*inner{"x": {Name: NewOptString("d")}},
+		Array: [1]*inner{{Name: NewOptString("e")}},
+	}
+	original.Same = original.Ptr
+	original.Self = original
+	original.unexposed = original.Ptr
+
+	copied := deepCopy(original)
+	assert.Equal(t, original.Ptr, copied.Ptr)
+	assert.NotSame(t, original, copied)
+	assert.NotSame(t, original.Ptr, copied.Ptr)
+	assert.Same(t, copied.Ptr, copied.Same)
+	assert.Same(t, copied, copied.Self)
+	assert.NotSame(t, original.Any, copied.Any)
+	assert.NotSame(t, original.Slice[0], copied.Slice[0])
+	assert.NotSame(t, original.Map["x"], copied.Map["x"])
+	assert.NotSame(t, original.Array[0], copied.Array[0])
+	assert.Same(t, original.Ptr, copied.unexposed)
+
+	copied.Ptr.Name = NewOptString("changed")
+	copied.Any.(*inner).Name = NewOptString("changed")
+	assert.Equal(t, NewOptString("a"), original.Ptr.Name)
+	assert.Equal(t, NewOptString("b"), original.Any.(*inner).Name)
+
+	var nilInterface interface{}
+	assert.Nil(t, deepCopy(nilInterface))
+}
+
+func TestWalkNestedStruct(t *testing.T) {
+	type node struct {
+		Name string
+		Next *node
+	}
+	walk := func(field interface{}, visited *visitSet) []string {
+		var names []string
+		var visit func(refStruct reflect.Value) error
+		visit = func(refStruct reflect.Value) error {
+			names = append(names, refStruct.Field(0).String())
+			return walkNestedStruct(refStruct.Field(1), visited, visit)
+		}
+		_ = walkNestedStruct(reflect.ValueOf(field).Elem(), visited, visit)
+		return names
+	}
+
+	t.Run("struct and struct pointer", func(t *testing.T) {
+		value := node{Name: "a", Next: &node{Name: "b"}}
+		assert.Equal(t, []string{"a", "b"}, walk(&value, &visitSet{}))
+	})
+
+	t.Run("nil pointer and non-struct values are skipped", func(t *testing.T) {
+		var nilNode *node
+		assert.Nil(t, walk(&nilNode, &visitSet{}))
+		n := 3
+		assert.Nil(t, walk(&n, &visitSet{}))
+	})
+
+	t.Run("pointer cycle is only followed once", func(t *testing.T) {
+		first := &node{Name: "a"}
+		first.Next = &node{Name: "b", Next: first}
+		visited := &visitSet{}
+		assert.Equal(t, []string{"a", "b"}, walk(&first, visited))
+		assert.Empty(t, visited.visiting)
+	})
+
+	t.Run("error from fn is returned", func(t *testing.T) {
+		err := walkNestedStruct(reflect.ValueOf(node{}), &visitSet{}, func(reflect.Value) error {
+			return errRequired()
+		})
+		assert.Equal(t, errRequired(), err)
+	})
+}
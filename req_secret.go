@@ -0,0 +1,183 @@
+package configtypes
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"log/slog"
+
+	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"gopkg.in/yaml.v3"
+)
+
+// ReqSecret is the same as OptSecret, except that it represents a required value.
+//
+// This type is always treated as required: ValidateStruct reports an error for a ReqSecret field that
+// is empty, and VarReader.ReadStruct reports an error if the variable for a ReqSecret field is not
+// set, even if the field tag does not have the "required" option. Converting an empty string or a
+// JSON null to a ReqSecret is an error.
+//
+// See the package documentation for the general contract for methods that have no specific documentation
+// here.
+type ReqSecret struct {
+	opt OptSecret
+}
+
+// NewReqSecret returns a ReqSecret with the specified value, or an error if the value is "".
+func NewReqSecret(value string) (ReqSecret, error) {
+	if value == "" {
+		return ReqSecret{}, errRequired()
+	}
+	return ReqSecret{NewOptSecret(value)}, nil
+}
+
+// NewReqSecretFromLDValue converts an ldvalue.Value to ReqSecret, with the same rules as UnmarshalJSON.
+func NewReqSecretFromLDValue(v ldvalue.Value) (ReqSecret, error) {
+	opt, err := NewOptSecretFromLDValue(v)
+	if err != nil {
+		return ReqSecret{}, err
+	}
+	if !opt.IsDefined() {
+		return ReqSecret{}, errRequired()
+	}
+	return ReqSecret{opt}, nil
+}
+
+func (o ReqSecret) IsDefined() bool {
+	return o.opt.IsDefined()
+}
+
+// IsRequired always returns true. See RequiredValue.
+func (o ReqSecret) IsRequired() bool {
+	return true
+}
+
+// Reveal returns the actual value of the secret, or "" if it is empty.
+func (o ReqSecret) Reveal() string {
+	return o.opt.Reveal()
+}
+
+// String returns the redacted form of the secret, or "" if it is empty.
+func (o ReqSecret) String() string {
+	return o.opt.String()
+}
+
+// GoString returns the redacted form of the secret in a format that is used by the %#v verb.
+func (o ReqSecret) GoString() string {
+	return secretGoString("ReqSecret", o.opt.value)
+}
+
+// Format implements fmt.Formatter so that the secret is redacted regardless of the formatting verb.
+func (o ReqSecret) Format(f fmt.State, verb rune) {
+	formatSecret(f, verb, o)
+}
+
+// LogValue implements slog.LogValuer so that the secret is redacted in structured logs.
+func (o ReqSecret) LogValue() slog.Value {
+	return o.opt.LogValue()
+}
+
+// MarshalText returns the redacted form of the secret, or an empty value if it is empty.
+func (o ReqSecret) MarshalText() ([]byte, error) {
+	return o.opt.MarshalText()
+}
+
+func (o *ReqSecret) UnmarshalText(data []byte) error {
+	value, err := NewReqSecret(string(data))
+	if err == nil {
+		*o = value
+	}
+	return err
+}
+
+// Set is the same as UnmarshalText. It allows ReqSecret to be used as a flag.Value.
+func (o *ReqSecret) Set(s string) error {
+	return o.UnmarshalText([]byte(s))
+}
+
+// Get returns the ReqSecret itself, rather than the actual value, so that the value stays redacted
+// if it is printed. It allows ReqSecret to be used as a flag.Getter.
+func (o ReqSecret) Get() interface{} {
+	return o
+}
+
+// AsLDValue converts the value to an ldvalue.Value, with the same rules as OptSecret.AsLDValue.
+func (o ReqSecret) AsLDValue() ldvalue.Value {
+	return o.opt.AsLDValue()
+}
+
+// MarshalJSON returns the redacted form of the secret as a JSON string, or a JSON null if it is
+// empty.
+func (o ReqSecret) MarshalJSON() ([]byte, error) {
+	return o.opt.MarshalJSON()
+}
+
+func (o *ReqSecret) UnmarshalJSON(data []byte) error {
+	var opt OptSecret
+	if err := opt.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	if !opt.IsDefined() {
+		return errRequired()
+	}
+	*o = ReqSecret{opt}
+	return nil
+}
+
+// MarshalYAML converts this type to a YAML value, with the same rules as MarshalJSON.
+func (o ReqSecret) MarshalYAML() (interface{}, error) {
+	return o.opt.MarshalYAML()
+}
+
+// UnmarshalYAML converts a YAML value to this type, with the same rules as UnmarshalJSON.
+func (o *ReqSecret) UnmarshalYAML(node *yaml.Node) error {
+	return unmarshalYAMLAsJSON(node, o)
+}
+
+// Value implements driver.Valuer, with the same rules as OptSecret: it uses the actual value rather
+// than the redacted form.
+func (o ReqSecret) Value() (driver.Value, error) {
+	return o.opt.Value()
+}
+
+// Scan implements sql.Scanner, with the same rules as OptSecret, except that NULL or an empty string is
+// an error.
+func (o *ReqSecret) Scan(src interface{}) error {
+	var opt OptSecret
+	if err := opt.Scan(src); err != nil {
+		return err
+	}
+	if !opt.IsDefined() {
+		return errRequired()
+	}
+	*o = ReqSecret{opt}
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler, with the same rules as OptSecret: it uses the
+// actual value rather than the redacted form.
+func (o ReqSecret) MarshalBinary() ([]byte, error) {
+	return o.opt.MarshalBinary()
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. An empty value is an error.
+func (o *ReqSecret) UnmarshalBinary(data []byte) error {
+	var opt OptSecret
+	if err := opt.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	if !opt.IsDefined() {
+		return errRequired()
+	}
+	*o = ReqSecret{opt}
+	return nil
+}
+
+// GobEncode is the same as MarshalBinary. It allows ReqSecret to be used with encoding/gob.
+func (o ReqSecret) GobEncode() ([]byte, error) {
+	return o.MarshalBinary()
+}
+
+// GobDecode is the same as UnmarshalBinary. It allows ReqSecret to be used with encoding/gob.
+func (o *ReqSecret) GobDecode(data []byte) error {
+	return o.UnmarshalBinary(data)
+}
@@ -0,0 +1,70 @@
+package configtypes
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustReqSecret(value string) ReqSecret {
+	o, err := NewReqSecret(value)
+	if err != nil {
+		panic(err)
+	}
+	return o
+}
+
+func TestReqSecret(t *testing.T) {
+	t.Run("empty value", func(t *testing.T) {
+		unsetValue := ReqSecret{}
+		assertIsDefined(t, false, unsetValue)
+		assert.Equal(t, "", unsetValue.Reveal())
+		assert.True(t, unsetValue.IsRequired())
+
+		_, err := NewReqSecret("")
+		assert.Equal(t, errRequired(), err)
+	})
+
+	t.Run("defined value", func(t *testing.T) {
+		o, err := NewReqSecret(testLongSecret)
+		require.NoError(t, err)
+		assertIsDefined(t, true, o)
+		assert.Equal(t, testLongSecret, o.Reveal())
+	})
+
+	t.Run("formatting", func(t *testing.T) {
+		o := mustReqSecret(testLongSecret)
+		assert.Equal(t, "****abcd", fmt.Sprintf("%v", o))
+		assert.Equal(t, "****abcd", fmt.Sprintf("%+v", o))
+		assert.Equal(t, `"****abcd"`, fmt.Sprintf("%q", o))
+		assert.Equal(t, "configtypes.ReqSecret{****abcd}", fmt.Sprintf("%#v", o))
+		assert.Equal(t, "****abcd", o.LogValue().String())
+	})
+
+	stringCtor := func(input string) (interface{}, error) {
+		return NewReqSecret(input)
+	}
+
+	assertConvertToText(t, map[string]textMarshalerAndStringer{
+		"": ReqSecret{}, "****abcd": mustReqSecret(testLongSecret),
+	})
+
+	assertConvertFromText(t, &ReqSecret{}, stringCtor, map[string]interface{}{
+		testLongSecret: mustReqSecret(testLongSecret),
+	})
+
+	assertConvertFromTextFails(t, &ReqSecret{}, stringCtor, errRequired(), "")
+
+	assertConvertToJSON(t, map[string]SingleValue{
+		`null`: ReqSecret{}, `"****abcd"`: mustReqSecret(testLongSecret),
+	})
+
+	assertConvertFromJSON(t, &ReqSecret{}, map[string]interface{}{
+		`"a"`: mustReqSecret("a"),
+	})
+
+	assertConvertFromJSONFails(t, &ReqSecret{},
+		`null`, `""`, `true`, `1`, `[]`, `{}`)
+}
@@ -0,0 +1,103 @@
+package configtypes
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"math"
+	"strconv"
+)
+
+// SQLColumn is a value that can be both written to and read from a database with database/sql.
+//
+// All of the Opt types implement this interface directly. The functions SQLDurationAsNanoseconds and
+// SQLStringListAsJSON return implementations that use a different column format for a specific type.
+type SQLColumn interface {
+	sql.Scanner
+	driver.Valuer
+}
+
+type sqlDurationAsNanoseconds struct {
+	target *OptDuration
+}
+
+type sqlStringListAsJSON struct {
+	target *OptStringList
+}
+
+// SQLDurationAsNanoseconds returns an SQLColumn that stores an OptDuration as an int64 count of
+// nanoseconds, rather than as a string.
+//
+//	err := db.QueryRow("SELECT timeout FROM config").Scan(SQLDurationAsNanoseconds(&c.Timeout))
+//	_, err = db.Exec("UPDATE config SET timeout = ?", SQLDurationAsNanoseconds(&c.Timeout))
+//
+// Reading a value works the same as OptDuration.Scan, which accepts either format.
+func SQLDurationAsNanoseconds(target *OptDuration) SQLColumn {
+	return sqlDurationAsNanoseconds{target}
+}
+
+// SQLStringListAsJSON returns an SQLColumn that stores an OptStringList as a JSON array of strings,
+// rather than as a comma-delimited string. This should be used if any of the values might contain a
+// comma.
+//
+// Reading a value works the same as OptStringList.Scan, which accepts either format.
+func SQLStringListAsJSON(target *OptStringList) SQLColumn {
+	return sqlStringListAsJSON{target}
+}
+
+func (c sqlDurationAsNanoseconds) Value() (driver.Value, error) {
+	if !c.target.IsDefined() {
+		return nil, nil //nolint:nilnil
+	}
+	return int64(c.target.GetOrElse(0)), nil
+}
+
+func (c sqlDurationAsNanoseconds) Scan(src interface{}) error {
+	return c.target.Scan(src)
+}
+
+func (c sqlStringListAsJSON) Value() (driver.Value, error) {
+	if !c.target.IsDefined() {
+		return nil, nil //nolint:nilnil
+	}
+	values := c.target.Values()
+	if values == nil {
+		values = []string{}
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (c sqlStringListAsJSON) Scan(src interface{}) error {
+	return c.target.Scan(src)
+}
+
+// scanSQLText implements sql.Scanner for a database value that should be a string, by passing it to
+// the target's UnmarshalText method.
+func scanSQLText(src interface{}, target encoding.TextUnmarshaler) error {
+	switch v := src.(type) {
+	case string:
+		return target.UnmarshalText([]byte(v))
+	case []byte:
+		return target.UnmarshalText(v)
+	default:
+		return errSQLScanType(src, target)
+	}
+}
+
+// sqlUintValue implements driver.Valuer for OptUint64 and OptUint. database/sql only supports
+// int64 values, so a larger value is written as a string.
+func sqlUintValue(defined bool, value uint64) (driver.Value, error) {
+	switch {
+	case !defined:
+		return nil, nil //nolint:nilnil
+	case value > math.MaxInt64:
+		return strconv.FormatUint(value, 10), nil
+	default:
+		return int64(value), nil
+	}
+}
@@ -0,0 +1,393 @@
+package configtypes
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/units"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLValue(t *testing.T) {
+	someTime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	someURL, _ := NewOptURLAbsoluteFromString("http://a/b")
+	list := NewOptStringList([]string{"a", "b,c"})
+	duration := NewOptDuration(3 * time.Second)
+
+	for _, p := range []struct {
+		name     string
+		value    driver.Valuer
+		expected driver.Value
+	}{
+		{"OptBool", NewOptBool(true), true},
+		{"OptInt", NewOptInt(3), int64(3)},
+		{"OptIntGreaterThanZero", mustOptIntGreaterThanZero(3), int64(3)},
+		{"OptInt64", NewOptInt64(math.MinInt64), int64(math.MinInt64)},
+		{"OptInt64GreaterThanZero", mustOptInt64GreaterThanZero(3), int64(3)},
+		{"OptUint", NewOptUint(3), int64(3)},
+		{"OptUintGreaterThanZero", mustOptUintGreaterThanZero(3), int64(3)},
+		{"OptUint64", NewOptUint64(math.MaxInt64), int64(math.MaxInt64)},
+		{"OptUint64 beyond int64", NewOptUint64(math.MaxUint64), "18446744073709551615"},
+		{"OptUint64GreaterThanZero", mustOptUint64GreaterThanZero(3), int64(3)},
+		{"OptFloat64", NewOptFloat64(1.5), 1.5},
+		{"OptDuration", duration, "3s"},
+		{"OptDurationNonNegative", mustOptDurationNonNegative(3 * time.Second), "3s"},
+		{"SQLDurationAsNanoseconds", SQLDurationAsNanoseconds(&duration), int64(3 * time.Second)},
+		{"OptString", NewOptString(""), ""},
+		{"OptStringNonEmpty", NewOptStringNonEmpty("a"), "a"},
+		{"OptSecret", NewOptSecret(testLongSecret), testLongSecret},
+		{"ReqSecret", mustReqSecret(testLongSecret), testLongSecret},
+		{"OptStringList", NewOptStringList([]string{"a", "b"}), "a,b"},
+		{"OptStringList with a comma in a value", list, `["a","b,c"]`},
+		{"OptStringList with a value that looks like JSON", NewOptStringList([]string{`["x"]`}), `["[\"x\"]"]`},
+		{"OptStringList with no values", NewOptStringList([]string{}), "[]"},
+		{"OptStringList with an empty string", NewOptStringList([]string{""}), `[""]`},
+		{"SQLStringListAsJSON", SQLStringListAsJSON(&list), `["a","b,c"]`},
+		{"OptURL", someURL.opt, "http://a/b"},
+		{"OptURLAbsolute", someURL, "http://a/b"},
+		{"OptBase2Bytes", NewOptBase2Bytes(2 * units.KiB), int64(2048)},
+		{"OptTime", NewOptTime(someTime), someTime},
+	} {
+		t.Run(p.name, func(t *testing.T) {
+			value, err := p.value.Value()
+			require.NoError(t, err)
+			assert.Equal(t, p.expected, value)
+		})
+	}
+
+	t.Run("empty values are NULL", func(t *testing.T) {
+		var emptyDuration OptDuration
+		var emptyList OptStringList
+		for _, v := range []driver.Valuer{
+			OptBool{}, OptInt{}, OptIntGreaterThanZero{}, OptFloat64{}, OptDuration{}, OptDurationNonNegative{},
+			SQLDurationAsNanoseconds(&emptyDuration), OptString{}, OptStringNonEmpty{}, OptSecret{}, ReqSecret{},
+			OptStringList{}, SQLStringListAsJSON(&emptyList), OptURL{}, OptURLAbsolute{}, OptBase2Bytes{},
+			OptTime{}, OptInt64{}, OptInt64GreaterThanZero{}, OptUint{}, OptUintGreaterThanZero{}, OptUint64{},
+			OptUint64GreaterThanZero{},
+		} {
+			value, err := v.Value()
+			assert.NoError(t, err)
+			assert.Nil(t, value, "%T", v)
+		}
+	})
+}
+
+func TestSQLScan(t *testing.T) {
+	someTime := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	someURL, _ := NewOptURLAbsoluteFromString("http://a/b")
+
+	for _, p := range []struct {
+		name     string
+		target   sql.Scanner
+		src      interface{}
+		expected interface{}
+	}{
+		{"OptBool from bool", &OptBool{}, false, NewOptBool(false)},
+		{"OptBool from integer", &OptBool{}, int64(1), NewOptBool(true)},
+		{"OptBool from string", &OptBool{}, "yes", NewOptBool(true)},
+		{"OptInt from integer", &OptInt{}, int64(3), NewOptInt(3)},
+		{"OptInt from bytes", &OptInt{}, []byte("3"), NewOptInt(3)},
+		{"OptIntGreaterThanZero", &OptIntGreaterThanZero{}, int64(3), mustOptIntGreaterThanZero(3)},
+		{"OptInt64 from integer", &OptInt64{}, int64(math.MinInt64), NewOptInt64(math.MinInt64)},
+		{"OptInt64 from bytes", &OptInt64{}, []byte("3"), NewOptInt64(3)},
+		{"OptInt64GreaterThanZero", &OptInt64GreaterThanZero{}, int64(3), mustOptInt64GreaterThanZero(3)},
+		{"OptUint from integer", &OptUint{}, int64(3), NewOptUint(3)},
+		{"OptUintGreaterThanZero", &OptUintGreaterThanZero{}, int64(3), mustOptUintGreaterThanZero(3)},
+		{"OptUint64 from integer", &OptUint64{}, int64(math.MaxInt64), NewOptUint64(math.MaxInt64)},
+		{"OptUint64 from string", &OptUint64{}, "18446744073709551615", NewOptUint64(math.MaxUint64)},
+		{"OptUint64GreaterThanZero", &OptUint64GreaterThanZero{}, int64(3), mustOptUint64GreaterThanZero(3)},
+		{"OptFloat64 from float", &OptFloat64{}, 1.5, NewOptFloat64(1.5)},
+		{"OptFloat64 from integer", &OptFloat64{}, int64(2), NewOptFloat64(2)},
+		{"OptDuration from string", &OptDuration{}, "3s", NewOptDuration(3 * time.Second)},
+		{"OptDuration from integer", &OptDuration{}, int64(time.Second), NewOptDuration(time.Second)},
+		{
+			"OptDurationNonNegative", &OptDurationNonNegative{}, "3s",
+			mustOptDurationNonNegative(3 * time.Second),
+		},
+		{"OptString", &OptString{}, "", NewOptString("")},
+		{"OptStringNonEmpty", &OptStringNonEmpty{}, "", OptStringNonEmpty{}},
+		{"OptSecret", &OptSecret{}, []byte(testLongSecret), NewOptSecret(testLongSecret)},
+		{"ReqSecret", &ReqSecret{}, testLongSecret, mustReqSecret(testLongSecret)},
+		{"OptStringList from string", &OptStringList{}, "a,b", NewOptStringList([]string{"a", "b"})},
+		{"OptStringList from JSON", &OptStringList{}, `["a","b,c"]`, NewOptStringList([]string{"a", "b,c"})},
+		{"OptStringList from bad JSON", &OptStringList{}, `[a,b]`, NewOptStringList([]string{"[a", "b]"})},
+		{"OptURL", &OptURL{}, "http://a/b", someURL.opt},
+		{"OptURLAbsolute", &OptURLAbsolute{}, "http://a/b", someURL},
+		{"OptBase2Bytes from integer", &OptBase2Bytes{}, int64(2048), NewOptBase2Bytes(2 * units.KiB)},
+		{"OptBase2Bytes from string", &OptBase2Bytes{}, "2KB", NewOptBase2Bytes(2 * units.KiB)},
+		{"OptTime from time", &OptTime{}, someTime, NewOptTime(someTime)},
+		{"OptTime from string", &OptTime{}, "2020-01-02T03:04:05Z", NewOptTime(someTime)},
+	} {
+		t.Run(p.name, func(t *testing.T) {
+			require.NoError(t, p.target.Scan(p.src))
+			assert.Equal(t, p.expected, derefScanner(p.target))
+		})
+	}
+
+	t.Run("NULL is an empty value", func(t *testing.T) {
+		for _, p := range []struct {
+			target   sql.Scanner
+			expected interface{}
+		}{
+			{&OptBool{}, OptBool{}},
+			{&OptInt{}, OptInt{}},
+			{&OptIntGreaterThanZero{}, OptIntGreaterThanZero{}},
+			{&OptFloat64{}, OptFloat64{}},
+			{&OptDuration{}, OptDuration{}},
+			{&OptDurationNonNegative{}, OptDurationNonNegative{}},
+			{&OptString{}, OptString{}},
+			{&OptStringNonEmpty{}, OptStringNonEmpty{}},
+			{&OptSecret{}, OptSecret{}},
+			{&OptStringList{}, OptStringList{}},
+			{&OptURL{}, OptURL{}},
+			{&OptURLAbsolute{}, OptURLAbsolute{}},
+			{&OptBase2Bytes{}, OptBase2Bytes{}},
+			{&OptTime{}, OptTime{}},
+			{&OptInt64{}, OptInt64{}},
+			{&OptUint{}, OptUint{}},
+			{&OptUint64{}, OptUint64{}},
+		} {
+			require.NoError(t, p.target.Scan(nil))
+			assert.Equal(t, p.expected, derefScanner(p.target))
+		}
+	})
+
+	t.Run("Scan replaces the previous value", func(t *testing.T) {
+		list := NewOptStringList([]string{"a"})
+		require.NoError(t, list.Scan("b"))
+		assert.Equal(t, NewOptStringList([]string{"b"}), list)
+
+		n := NewOptInt(1)
+		require.NoError(t, n.Scan(nil))
+		assert.Equal(t, OptInt{}, n)
+	})
+
+	for _, p := range []struct {
+		name   string
+		target sql.Scanner
+		src    interface{}
+		err    error
+	}{
+		{"OptBool from bad integer", &OptBool{}, int64(2), errBoolFormat()},
+		{"OptBool from bad string", &OptBool{}, "x", errBoolFormat()},
+		{"OptInt from bad string", &OptInt{}, "x", errIntFormat()},
+		{"OptInt from float", &OptInt{}, 1.5, errSQLScanType(1.5, &OptInt{})},
+		{"OptIntGreaterThanZero", &OptIntGreaterThanZero{}, int64(0), errMustBeGreaterThanZero()},
+		{
+			"OptInt64 from too large string", &OptInt64{}, "9223372036854775808",
+			errIntOutOfRange(math.MinInt64, math.MaxInt64),
+		},
+		{"OptUint from negative integer", &OptUint{}, int64(-1), errIntOutOfRange(0, math.MaxUint)},
+		{"OptUint64 from negative integer", &OptUint64{}, int64(-1), errIntOutOfRange(0, math.MaxUint64)},
+		{"OptUint64GreaterThanZero", &OptUint64GreaterThanZero{}, int64(0), errMustBeGreaterThanZero()},
+		{"OptDuration from bad string", &OptDuration{}, "x", errDurationFormat()},
+		{"OptDurationNonNegative", &OptDurationNonNegative{}, int64(-1), errMustBeNonNegative()},
+		{"OptString from integer", &OptString{}, int64(1), errSQLScanType(int64(1), &OptString{})},
+		{"ReqSecret from NULL", &ReqSecret{}, nil, errRequired()},
+		{"ReqSecret from empty string", &ReqSecret{}, "", errRequired()},
+		{"OptStringList from integer", &OptStringList{}, int64(1), errSQLScanType(int64(1), &OptStringList{})},
+		{"OptURLAbsolute", &OptURLAbsolute{}, "/a", errURLNotAbsolute()},
+		{"OptTime from bad string", &OptTime{}, "x", errTimeFormat()},
+	} {
+		t.Run(p.name+" fails", func(t *testing.T) {
+			assert.Equal(t, p.err, p.target.Scan(p.src))
+		})
+	}
+
+	t.Run("OptInt out of range", func(t *testing.T) {
+		if math.MaxInt == math.MaxInt64 {
+			t.Skip("int is 64 bits")
+		}
+		var n OptInt
+		assert.Equal(t, errIntFormat(), n.Scan(int64(math.MaxInt64)))
+	})
+}
+
+func derefScanner(s sql.Scanner) interface{} {
+	switch v := s.(type) {
+	case *OptBool:
+		return *v
+	case *OptInt:
+		return *v
+	case *OptIntGreaterThanZero:
+		return *v
+	case *OptFloat64:
+		return *v
+	case *OptDuration:
+		return *v
+	case *OptDurationNonNegative:
+		return *v
+	case *OptString:
+		return *v
+	case *OptStringNonEmpty:
+		return *v
+	case *OptSecret:
+		return *v
+	case *ReqSecret:
+		return *v
+	case *OptStringList:
+		return *v
+	case *OptURL:
+		return *v
+	case *OptURLAbsolute:
+		return *v
+	case *OptBase2Bytes:
+		return *v
+	case *OptTime:
+		return *v
+	case *OptInt64:
+		return *v
+	case *OptInt64GreaterThanZero:
+		return *v
+	case *OptUint:
+		return *v
+	case *OptUintGreaterThanZero:
+		return *v
+	case *OptUint64:
+		return *v
+	case *OptUint64GreaterThanZero:
+		return *v
+	}
+	return nil
+}
+
+type testSQLRow struct {
+	Enabled     OptBool
+	Count       OptIntGreaterThanZero
+	Ratio       OptFloat64
+	Timeout     OptDuration
+	ReadTimeout OptDurationNonNegative
+	Name        OptStringNonEmpty
+	Password    OptSecret
+	Hosts       OptStringList
+	Tags        OptStringList
+	URL         OptURLAbsolute
+	Size        OptBase2Bytes
+	Expires     OptTime
+}
+
+func (r *testSQLRow) columns() []interface{} {
+	return []interface{}{
+		&r.Enabled, &r.Count, &r.Ratio, SQLDurationAsNanoseconds(&r.Timeout), &r.ReadTimeout, &r.Name,
+		&r.Password, &r.Hosts, SQLStringListAsJSON(&r.Tags), &r.URL, &r.Size, &r.Expires,
+	}
+}
+
+func TestSQLRoundTrip(t *testing.T) {
+	someURL, _ := NewOptURLAbsoluteFromString("http://a/b")
+
+	for name, row := range map[string]testSQLRow{
+		"defined values": {
+			Enabled:     NewOptBool(true),
+			Count:       mustOptIntGreaterThanZero(3),
+			Ratio:       NewOptFloat64(1.5),
+			Timeout:     NewOptDuration(3 * time.Second),
+			ReadTimeout: mustOptDurationNonNegative(time.Minute),
+			Name:        NewOptStringNonEmpty("n"),
+			Password:    NewOptSecret(testLongSecret),
+			Hosts:       NewOptStringList([]string{"a", "b"}),
+			Tags:        NewOptStringList([]string{"x,y", "z"}),
+			URL:         someURL,
+			Size:        NewOptBase2Bytes(units.MiB),
+			Expires:     NewOptTime(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)),
+		},
+		"empty values":                      {},
+		"defined list with no values":       {Hosts: NewOptStringList([]string{})},
+		"defined list with an empty string": {Hosts: NewOptStringList([]string{""})},
+		"defined list with commas":          {Hosts: NewOptStringList([]string{"a,b", "c"})},
+		"defined list that looks like JSON": {Hosts: NewOptStringList([]string{`["x"]`})},
+	} {
+		t.Run(name, func(t *testing.T) {
+			db := sql.OpenDB(&testSQLConnector{})
+			defer db.Close()
+
+			_, err := db.Exec("INSERT", row.columns()...)
+			require.NoError(t, err)
+
+			var result testSQLRow
+			require.NoError(t, db.QueryRow("SELECT").Scan(result.columns()...))
+			assert.Equal(t, row, result)
+		})
+	}
+}
+
+// testSQLConnector is a minimal database/sql driver that stores the arguments of the last Exec as a
+// single row, and returns that row from any query.
+type testSQLConnector struct {
+	row []driver.Value
+}
+
+type testSQLConn struct {
+	connector *testSQLConnector
+}
+
+type testSQLStmt struct {
+	connector *testSQLConnector
+}
+
+type testSQLRows struct {
+	row  []driver.Value
+	done bool
+}
+
+func (c *testSQLConnector) Connect(context.Context) (driver.Conn, error) {
+	return testSQLConn{c}, nil
+}
+
+func (c *testSQLConnector) Driver() driver.Driver {
+	return nil
+}
+
+func (c testSQLConn) Prepare(string) (driver.Stmt, error) {
+	return testSQLStmt(c), nil
+}
+
+func (c testSQLConn) Close() error {
+	return nil
+}
+
+func (c testSQLConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions are not supported")
+}
+
+func (s testSQLStmt) Close() error {
+	return nil
+}
+
+func (s testSQLStmt) NumInput() int {
+	return -1
+}
+
+func (s testSQLStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.connector.row = args
+	return driver.RowsAffected(1), nil
+}
+
+func (s testSQLStmt) Query([]driver.Value) (driver.Rows, error) {
+	return &testSQLRows{row: s.connector.row}, nil
+}
+
+func (r *testSQLRows) Columns() []string {
+	return make([]string, len(r.row))
+}
+
+func (r *testSQLRows) Close() error {
+	return nil
+}
+
+func (r *testSQLRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	copy(dest, r.row)
+	return nil
+}
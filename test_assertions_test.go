@@ -3,13 +3,16 @@ package configtypes
 import (
 	"encoding"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/launchdarkly/go-sdk-common/v3/ldvalue"
+	"gopkg.in/yaml.v3"
 )
 
 type textMarshalerAndStringer interface {
@@ -57,6 +60,11 @@ func assertConvertFromText(
 				err = zeroValue.UnmarshalText([]byte(input))
 				assert.NoError(t, err)
 				assert.Equal(t, expected, dereferenceIfPointer(zeroValue))
+
+				if flagValue, ok := newZeroValue(zeroValue).(flag.Value); ok {
+					assert.NoError(t, flagValue.Set(input))
+					assert.Equal(t, expected, dereferenceIfPointer(flagValue))
+				}
 			})
 		}
 	})
@@ -77,6 +85,10 @@ func assertConvertFromTextFails(
 
 				err = zeroValue.UnmarshalText([]byte(input))
 				assert.Equal(t, expectedError, err)
+
+				if flagValue, ok := newZeroValue(zeroValue).(flag.Value); ok {
+					assert.Equal(t, expectedError, flagValue.Set(input))
+				}
 			})
 		}
 	})
@@ -94,10 +106,76 @@ func assertConvertToJSON(
 			}
 
 			assert.JSONEq(t, expectedJSONString, string(bytes))
+
+			if _, ok := value.(yaml.Marshaler); ok {
+				assertYAMLEqualsJSON(t, expectedJSONString, value)
+			}
+
+			if v, ok := value.(interface{ AsLDValue() ldvalue.Value }); ok {
+				assert.JSONEq(t, expectedJSONString, v.AsLDValue().JSONString())
+			}
 		}
 	})
 }
 
+// assertYAMLEqualsJSON checks that a value is marshaled to YAML as the equivalent of a JSON value.
+func assertYAMLEqualsJSON(t *testing.T, expectedJSONString string, value interface{}) {
+	yamlBytes, err := yaml.Marshal(value)
+	if !assert.NoError(t, err) {
+		return
+	}
+	var decoded interface{}
+	if assert.NoError(t, yaml.Unmarshal(yamlBytes, &decoded)) {
+		jsonBytes, err := json.Marshal(decoded)
+		if assert.NoError(t, err) {
+			assert.JSONEq(t, expectedJSONString, string(jsonBytes), "YAML was: %s", yamlBytes)
+		}
+	}
+}
+
+// ldValueConstructors maps each Opt type to its NewOptFooFromLDValue constructor, so that
+// assertConvertFromJSON and assertConvertFromJSONFails can check that the constructor has the same
+// rules as UnmarshalJSON.
+var ldValueConstructors = map[reflect.Type]func(ldvalue.Value) (interface{}, error){ //nolint:gochecknoglobals
+	reflect.TypeOf(OptBool{}):                ldValueConstructor(NewOptBoolFromLDValue),
+	reflect.TypeOf(OptInt{}):                 ldValueConstructor(NewOptIntFromLDValue),
+	reflect.TypeOf(OptIntGreaterThanZero{}):  ldValueConstructor(NewOptIntGreaterThanZeroFromLDValue),
+	reflect.TypeOf(OptFloat64{}):             ldValueConstructor(NewOptFloat64FromLDValue),
+	reflect.TypeOf(OptDuration{}):            ldValueConstructor(NewOptDurationFromLDValue),
+	reflect.TypeOf(OptDurationNonNegative{}): ldValueConstructor(NewOptDurationNonNegativeFromLDValue),
+	reflect.TypeOf(OptString{}):              ldValueConstructor(NewOptStringFromLDValue),
+	reflect.TypeOf(OptStringNonEmpty{}):      ldValueConstructor(NewOptStringNonEmptyFromLDValue),
+	reflect.TypeOf(OptSecret{}):              ldValueConstructor(NewOptSecretFromLDValue),
+	reflect.TypeOf(ReqSecret{}):              ldValueConstructor(NewReqSecretFromLDValue),
+	reflect.TypeOf(OptStringList{}):          ldValueConstructor(NewOptStringListFromLDValue),
+	reflect.TypeOf(OptURL{}):                 ldValueConstructor(NewOptURLFromLDValue),
+	reflect.TypeOf(OptURLAbsolute{}):         ldValueConstructor(NewOptURLAbsoluteFromLDValue),
+	reflect.TypeOf(OptBase2Bytes{}):          ldValueConstructor(NewOptBase2BytesFromLDValue),
+	reflect.TypeOf(OptTime{}):                ldValueConstructor(NewOptTimeFromLDValue),
+
+	reflect.TypeOf(OptInt64{}):                ldValueConstructor(NewOptInt64FromLDValue),
+	reflect.TypeOf(OptInt64GreaterThanZero{}): ldValueConstructor(NewOptInt64GreaterThanZeroFromLDValue),
+	reflect.TypeOf(OptUint{}):                 ldValueConstructor(NewOptUintFromLDValue),
+	reflect.TypeOf(OptUintGreaterThanZero{}):  ldValueConstructor(NewOptUintGreaterThanZeroFromLDValue),
+	reflect.TypeOf(OptUint64{}):               ldValueConstructor(NewOptUint64FromLDValue),
+	reflect.TypeOf(OptUint64GreaterThanZero{}): ldValueConstructor(
+		NewOptUint64GreaterThanZeroFromLDValue),
+
+	reflect.TypeOf(OptIntBetween[testIntBounds]{}): ldValueConstructor(NewOptIntBetweenFromLDValue[testIntBounds]),
+	reflect.TypeOf(OptInt64Between[testInt64Bounds]{}): ldValueConstructor(
+		NewOptInt64BetweenFromLDValue[testInt64Bounds]),
+	reflect.TypeOf(OptFloat64Between[testFloat64Bounds]{}): ldValueConstructor(
+		NewOptFloat64BetweenFromLDValue[testFloat64Bounds]),
+	reflect.TypeOf(OptDurationBetween[testDurationBounds]{}): ldValueConstructor(
+		NewOptDurationBetweenFromLDValue[testDurationBounds]),
+}
+
+func ldValueConstructor[T any](ctor func(ldvalue.Value) (T, error)) func(ldvalue.Value) (interface{}, error) {
+	return func(v ldvalue.Value) (interface{}, error) {
+		return ctor(v)
+	}
+}
+
 func assertConvertFromJSON(
 	t *testing.T,
 	zeroValue json.Unmarshaler,
@@ -109,6 +187,31 @@ func assertConvertFromJSON(
 				err := zeroValue.UnmarshalJSON([]byte(input))
 				assert.NoError(t, err)
 				assert.Equal(t, expected, dereferenceIfPointer(zeroValue))
+
+				// since JSON is valid YAML, UnmarshalYAML should produce the same result
+				if yamlValue, ok := newZeroValue(zeroValue).(yaml.Unmarshaler); ok {
+					assert.NoError(t, yaml.Unmarshal([]byte(input), yamlValue))
+					assert.Equal(t, expected, dereferenceIfPointer(yamlValue))
+				}
+
+				// and so should LoadYAML, which also handles null, starting from a different value
+				var initial interface{}
+				for _, other := range jsonValues {
+					if !reflect.DeepEqual(other, expected) {
+						initial = other
+						break
+					}
+				}
+				loaded, result := loadYAMLField(zeroValue, initial, input)
+				assert.True(t, result.OK(), "%s", result.GetError())
+				assert.Equal(t, expected, loaded)
+
+				// the FromLDValue constructor should also produce the same result
+				if ctor, ok := ldValueConstructors[reflect.TypeOf(expected)]; ok {
+					value, err := ctor(ldvalue.Parse([]byte(input)))
+					assert.NoError(t, err)
+					assert.Equal(t, expected, value)
+				}
 			})
 		}
 	})
@@ -119,14 +222,66 @@ func assertConvertFromJSONFails(
 	zeroValue json.Unmarshaler,
 	values ...string,
 ) {
-	values = append(values, `some invalid JSON`)
 	t.Run("convert from JSON with UnmarshalJSON - invalid values", func(t *testing.T) {
-		for _, input := range values {
+		for _, input := range append(values, `some invalid JSON`) {
 			t.Run(input, func(t *testing.T) {
 				assert.Error(t, zeroValue.UnmarshalJSON([]byte(input)))
 			})
 		}
 	})
+	if ctor, ok := ldValueConstructors[reflect.TypeOf(dereferenceIfPointer(zeroValue))]; ok {
+		t.Run("convert from ldvalue.Value with FromLDValue constructor - invalid values", func(t *testing.T) {
+			for _, input := range values {
+				_, err := ctor(ldvalue.Parse([]byte(input)))
+				assert.Error(t, err, input)
+			}
+		})
+	}
+	t.Run("convert from YAML with UnmarshalYAML - invalid values", func(t *testing.T) {
+		for _, input := range values {
+			// yaml.v3 does not call UnmarshalYAML for a null value, but LoadYAML handles it
+			if yamlValue, ok := newZeroValue(zeroValue).(yaml.Unmarshaler); ok && input != "null" {
+				assert.Error(t, yaml.Unmarshal([]byte(input), yamlValue), input)
+			}
+			_, result := loadYAMLField(zeroValue, nil, input)
+			assert.False(t, result.OK(), input)
+		}
+	})
+}
+
+// assertUnmarshalJSONClearsForInvalidString verifies that, for a type that is parsed from a JSON string,
+// UnmarshalJSON leaves the value empty if the string cannot be parsed, but does not modify it if the JSON
+// value is not a string at all.
+func assertUnmarshalJSONClearsForInvalidString(
+	t *testing.T,
+	target json.Unmarshaler,
+	initial interface{},
+	input string,
+) {
+	t.Run("UnmarshalJSON with an invalid string clears the value", func(t *testing.T) {
+		value := reflect.ValueOf(target).Elem()
+		value.Set(reflect.ValueOf(initial))
+		assert.Error(t, target.UnmarshalJSON([]byte(input)))
+		assert.True(t, value.IsZero())
+
+		value.Set(reflect.ValueOf(initial))
+		assert.Error(t, target.UnmarshalJSON([]byte(`true`)))
+		assert.Equal(t, initial, value.Interface())
+	})
+}
+
+// loadYAMLField uses LoadYAML to decode a value as a struct field of the type that the pointer refers
+// to. The field is first set to the initial value, unless that is nil.
+func loadYAMLField(pointer interface{}, initial interface{}, input string) (interface{}, ValidationResult) {
+	structType := reflect.StructOf([]reflect.StructField{
+		{Name: "V", Type: reflect.TypeOf(pointer).Elem(), Tag: `yaml:"v"`},
+	})
+	target := reflect.New(structType)
+	if initial != nil {
+		target.Elem().Field(0).Set(reflect.ValueOf(initial))
+	}
+	result := LoadYAML(strings.NewReader("v: "+input), target.Interface(), LoadOptions{})
+	return target.Elem().Field(0).Interface(), result
 }
 
 func dereferenceIfPointer(value interface{}) interface{} {
@@ -136,3 +291,8 @@ func dereferenceIfPointer(value interface{}) interface{} {
 	}
 	return value
 }
+
+// newZeroValue returns a pointer to a new zero value of the type that a pointer refers to.
+func newZeroValue(pointer interface{}) interface{} {
+	return reflect.New(reflect.TypeOf(pointer).Elem()).Interface()
+}
@@ -0,0 +1,792 @@
+package configtypes
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"io"
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// LoadTOML reads a TOML configuration file into a struct, and then checks the struct with
+// ValidateStruct. The target must be a struct pointer.
+//
+//	port = 8080
+//	timeout = "5s"
+//	expires = 2030-01-01T00:00:00Z
+//
+//	[server]
+//	host = "https://example.com"
+//	hosts = ["a", "b"]
+//
+//	[[replica]]
+//	host = "https://east.example.com"
+//
+// Tables correspond to fields whose type is a struct, a struct pointer, or a map with string keys, and
+// arrays of tables correspond to slices. Keys are matched to fields in the same way as for LoadINI:
+// either by the field name or by the variable name in its "conf:" tag, ignoring case and treating "-"
+// and "_" as the same.
+//
+// A TOML string is converted with the field type's UnmarshalText method if it has one, as all of the
+// Opt types do, so a string has the same format as in an environment variable. Other TOML values are
+// converted with UnmarshalJSON if the type has that method, as all of the Opt types do, so they have
+// the same rules as in JSON: for instance, an OptInt can be a TOML integer, an OptBool can be a TOML
+// boolean, and an OptStringList can be an array of strings. A TOML date-time or date can be used for
+// a field of type OptTime or time.Time; a date-time with no offset, or a date, is interpreted as UTC.
+// Fields of other types must have the corresponding TOML type, so for instance a string field cannot
+// be set from a TOML integer.
+//
+// LoadTOML reports each value that could not be converted, and each unknown key if
+// options.DisallowUnknownKeys is set, as a separate ValidationError whose Path is the path of TOML keys
+// and array indexes, and whose Line and Column are the location of the value in the file. Errors from
+// ValidateStruct, which is called with recursive set to true, are added to the same result; their
+// paths use Go field names. If the data is not valid TOML, the result contains only one error and the
+// struct is not validated. Fields that do not appear in the file are not modified, so you can set
+// default values in the struct before calling LoadTOML.
+func LoadTOML(r io.Reader, target interface{}, options LoadOptions) ValidationResult {
+	return loadFile(r, target, options, func(data []byte, refStruct reflect.Value, result *ValidationResult) bool {
+		l := tomlLoader{data: data, options: options, result: result}
+		root, offset, err := parseTOML(data)
+		if err != nil {
+			l.addError(nil, offset, err)
+			return false
+		}
+		l.decodeTable(root, refStruct, nil)
+		return true
+	})
+}
+
+type tomlKind int
+
+const (
+	tomlString tomlKind = iota
+	tomlInteger
+	tomlFloat
+	tomlBool
+	tomlDateTime
+	tomlLocalTime
+	tomlArray
+	tomlTable
+)
+
+func (k tomlKind) String() string {
+	switch k {
+	case tomlString:
+		return "string"
+	case tomlInteger:
+		return "integer"
+	case tomlFloat:
+		return "float"
+	case tomlBool:
+		return "boolean"
+	case tomlDateTime:
+		return "date-time"
+	case tomlLocalTime:
+		return "local time"
+	case tomlArray:
+		return "array"
+	default:
+		return "table"
+	}
+}
+
+// tomlValue is a parsed TOML value, with its location in the data.
+type tomlValue struct {
+	kind       tomlKind
+	offset     int
+	str        string // for a string, or the original text of a date-time or local time
+	intValue   int64
+	floatValue float64
+	boolValue  bool
+	timeValue  time.Time
+	elements   []*tomlValue // for an array
+
+	// for a table
+	keys       []string // in the order they were defined
+	fields     map[string]*tomlValue
+	keyOffsets map[string]int
+
+	// These track how a table or array was defined, since TOML does not allow some redefinitions.
+	headerDefined bool // a table defined by a [header]
+	dottedDefined bool // a table defined by a dotted key such as a.b = 1
+	frozen        bool // an inline table or array, which cannot be added to
+	tableArray    bool // an array defined by [[header]]
+}
+
+func newTOMLTable(offset int) *tomlValue {
+	return &tomlValue{
+		kind:       tomlTable,
+		offset:     offset,
+		fields:     make(map[string]*tomlValue),
+		keyOffsets: make(map[string]int),
+	}
+}
+
+func (v *tomlValue) set(key string, offset int, value *tomlValue) {
+	v.keys = append(v.keys, key)
+	v.fields[key] = value
+	v.keyOffsets[key] = offset
+}
+
+// toInterface converts the value to the same types that encoding/json uses, except that a date-time
+// is a time.Time.
+func (v *tomlValue) toInterface() interface{} {
+	switch v.kind {
+	case tomlInteger:
+		return v.intValue
+	case tomlFloat:
+		return v.floatValue
+	case tomlBool:
+		return v.boolValue
+	case tomlDateTime:
+		return v.timeValue
+	case tomlArray:
+		ret := make([]interface{}, 0, len(v.elements))
+		for _, e := range v.elements {
+			ret = append(ret, e.toInterface())
+		}
+		return ret
+	case tomlTable:
+		ret := make(map[string]interface{}, len(v.keys))
+		for _, k := range v.keys {
+			ret[k] = v.fields[k].toInterface()
+		}
+		return ret
+	default:
+		return v.str
+	}
+}
+
+type tomlParser struct {
+	data      []byte
+	pos       int
+	errOffset int
+}
+
+// parseTOML parses a TOML document into a table. If it fails, it returns the offset of the error.
+func parseTOML(data []byte) (*tomlValue, int, error) {
+	if offset, err := checkTOMLCharacters(data); err != nil {
+		return nil, offset, err
+	}
+	p := tomlParser{data: data}
+	root := newTOMLTable(0)
+	if err := p.parseDocument(root); err != nil {
+		return nil, p.errOffset, err
+	}
+	return root, 0, nil
+}
+
+// checkTOMLCharacters checks that the data is valid UTF-8 and has no control characters other than tab
+// and line breaks. TOML does not allow them anywhere, including in strings and comments, so this is
+// simpler than checking for them in each place.
+func checkTOMLCharacters(data []byte) (int, error) {
+	for i := 0; i < len(data); {
+		c, size := utf8.DecodeRune(data[i:])
+		switch {
+		case c == utf8.RuneError && size == 1:
+			return i, errTOMLInvalidUTF8()
+		case c == '\r' && bytes.HasPrefix(data[i:], []byte("\r\n")), c == '\t', c == '\n':
+		case c < 0x20 || c == 0x7f:
+			return i, errTOMLInvalidCharacter(c)
+		}
+		i += size
+	}
+	return 0, nil
+}
+
+func (p *tomlParser) fail(offset int, err error) error {
+	p.errOffset = offset
+	return err
+}
+
+func (p *tomlParser) peek(s string) bool {
+	return bytes.HasPrefix(p.data[p.pos:], []byte(s))
+}
+
+func (p *tomlParser) consume(s string) bool {
+	if p.peek(s) {
+		p.pos += len(s)
+		return true
+	}
+	return false
+}
+
+func (p *tomlParser) skipSpaces() {
+	for p.pos < len(p.data) && (p.data[p.pos] == ' ' || p.data[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+func (p *tomlParser) skipComment() {
+	if p.peek("#") {
+		for p.pos < len(p.data) && p.data[p.pos] != '\n' {
+			p.pos++
+		}
+	}
+}
+
+// skipBlankLines skips whitespace, comments, and line breaks.
+func (p *tomlParser) skipBlankLines() {
+	for {
+		p.skipSpaces()
+		p.skipComment()
+		if !p.consume("\n") && !p.consume("\r\n") {
+			return
+		}
+	}
+}
+
+func (p *tomlParser) expectEndOfLine() error {
+	p.skipSpaces()
+	p.skipComment()
+	if p.pos == len(p.data) || p.consume("\n") || p.consume("\r\n") {
+		return nil
+	}
+	return p.fail(p.pos, errTOMLExpected("the end of the line"))
+}
+
+func (p *tomlParser) parseDocument(root *tomlValue) error {
+	p.consume("\ufeff")
+	current := root
+	for {
+		p.skipBlankLines()
+		if p.pos == len(p.data) {
+			return nil
+		}
+		var err error
+		if start := p.pos; p.consume("[") {
+			tableArray := p.consume("[")
+			var keys []string
+			if keys, _, err = p.parseKey(); err != nil {
+				return err
+			}
+			closing := "]"
+			if tableArray {
+				closing = "]]"
+			}
+			if !p.consume(closing) {
+				return p.fail(p.pos, errTOMLExpected(strconv.Quote(closing)))
+			}
+			if current, err = p.defineTable(root, keys, start, tableArray); err != nil {
+				return err
+			}
+		} else if err = p.parseKeyValue(current); err != nil {
+			return err
+		}
+		if err = p.expectEndOfLine(); err != nil {
+			return err
+		}
+	}
+}
+
+// parseKey parses a key that may be dotted, and returns each part of the key and its offset.
+func (p *tomlParser) parseKey() ([]string, []int, error) {
+	var keys []string
+	var offsets []int
+	for {
+		p.skipSpaces()
+		start := p.pos
+		var key string
+		var err error
+		switch {
+		case p.peek(`"""`) || p.peek("'''"):
+			return nil, nil, p.fail(start, errTOMLExpected("a key"))
+		case p.peek(`"`):
+			key, err = p.parseBasicString()
+		case p.peek("'"):
+			key, err = p.parseLiteralString()
+		default:
+			for p.pos < len(p.data) && isTOMLBareKeyChar(p.data[p.pos]) {
+				p.pos++
+			}
+			if p.pos == start {
+				return nil, nil, p.fail(start, errTOMLExpected("a key"))
+			}
+			key = string(p.data[start:p.pos])
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		keys = append(keys, key)
+		offsets = append(offsets, start)
+		p.skipSpaces()
+		if !p.consume(".") {
+			return keys, offsets, nil
+		}
+	}
+}
+
+func isTOMLBareKeyChar(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c == '_' || c == '-'
+}
+
+func (p *tomlParser) parseKeyValue(table *tomlValue) error {
+	keys, offsets, err := p.parseKey()
+	if err != nil {
+		return err
+	}
+	if !p.consume("=") {
+		return p.fail(p.pos, errTOMLExpected(`"=" after the key`))
+	}
+	p.skipSpaces()
+	value, err := p.parseValue()
+	if err != nil {
+		return err
+	}
+	t := table
+	for i, key := range keys[:len(keys)-1] {
+		existing := t.fields[key]
+		switch {
+		case existing == nil:
+			child := newTOMLTable(offsets[i])
+			child.dottedDefined = true
+			t.set(key, offsets[i], child)
+			t = child
+		case existing.kind == tomlTable && existing.dottedDefined:
+			t = existing
+		default:
+			return p.fail(offsets[i], errTOMLAlreadyDefined(strings.Join(keys[:i+1], ".")))
+		}
+	}
+	last := len(keys) - 1
+	if t.fields[keys[last]] != nil {
+		return p.fail(offsets[last], errTOMLAlreadyDefined(strings.Join(keys, ".")))
+	}
+	t.set(keys[last], offsets[last], value)
+	return nil
+}
+
+// defineTable handles a [header] or [[header]], and returns the table that the following keys go
+// into.
+func (p *tomlParser) defineTable(root *tomlValue, keys []string, start int, tableArray bool) (*tomlValue, error) {
+	t := root
+	for i, key := range keys[:len(keys)-1] {
+		existing := t.fields[key]
+		switch {
+		case existing == nil:
+			child := newTOMLTable(start)
+			t.set(key, start, child)
+			t = child
+		case existing.kind == tomlTable && !existing.frozen:
+			t = existing
+		case existing.kind == tomlArray && existing.tableArray:
+			t = existing.elements[len(existing.elements)-1]
+		default:
+			return nil, p.fail(start, errTOMLAlreadyDefined(strings.Join(keys[:i+1], ".")))
+		}
+	}
+	key := keys[len(keys)-1]
+	existing := t.fields[key]
+	table := newTOMLTable(start)
+	table.headerDefined = true
+	switch {
+	case tableArray && existing == nil:
+		t.set(key, start, &tomlValue{kind: tomlArray, offset: start, elements: []*tomlValue{table}, tableArray: true})
+	case tableArray && existing.kind == tomlArray && existing.tableArray:
+		existing.elements = append(existing.elements, table)
+	case tableArray:
+		return nil, p.fail(start, errTOMLAlreadyDefined(strings.Join(keys, ".")))
+	case existing == nil:
+		t.set(key, start, table)
+	case existing.kind == tomlTable && !existing.headerDefined && !existing.dottedDefined && !existing.frozen:
+		existing.headerDefined = true // it was created implicitly by a header such as [a.b]
+		table = existing
+	default:
+		return nil, p.fail(start, errTOMLAlreadyDefined(strings.Join(keys, ".")))
+	}
+	return table, nil
+}
+
+func (p *tomlParser) parseValue() (*tomlValue, error) {
+	start := p.pos
+	var s string
+	var err error
+	switch {
+	case p.peek(`"""`):
+		s, err = p.parseMultiLineString(`"""`)
+	case p.peek(`"`):
+		s, err = p.parseBasicString()
+	case p.peek("'''"):
+		s, err = p.parseMultiLineString("'''")
+	case p.peek("'"):
+		s, err = p.parseLiteralString()
+	case p.peek("["):
+		return p.parseArray()
+	case p.peek("{"):
+		return p.parseInlineTable()
+	default:
+		return p.parseScalar()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &tomlValue{kind: tomlString, offset: start, str: s}, nil
+}
+
+func (p *tomlParser) parseArray() (*tomlValue, error) {
+	array := &tomlValue{kind: tomlArray, offset: p.pos, frozen: true}
+	p.pos++
+	for {
+		p.skipBlankLines()
+		if p.consume("]") {
+			return array, nil
+		}
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		array.elements = append(array.elements, value)
+		p.skipBlankLines()
+		if !p.consume(",") {
+			if p.consume("]") {
+				return array, nil
+			}
+			return nil, p.fail(p.pos, errTOMLExpected(`"," or "]"`))
+		}
+	}
+}
+
+func (p *tomlParser) parseInlineTable() (*tomlValue, error) {
+	table := newTOMLTable(p.pos)
+	table.frozen = true
+	p.pos++
+	p.skipSpaces()
+	if p.consume("}") {
+		return table, nil
+	}
+	for {
+		if err := p.parseKeyValue(table); err != nil {
+			return nil, err
+		}
+		p.skipSpaces()
+		if !p.consume(",") {
+			if p.consume("}") {
+				return table, nil
+			}
+			return nil, p.fail(p.pos, errTOMLExpected(`"," or "}"`))
+		}
+	}
+}
+
+//nolint:gochecknoglobals
+var (
+	tomlDateRegex      = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+	tomlLocalTimeRegex = regexp.MustCompile(`^\d{2}:\d{2}:\d{2}(\.\d+)?$`)
+	tomlDateTimeRegex  = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}([Tt ]\d{2}:\d{2}:\d{2}.*)?$`)
+	tomlIntegerRegex   = regexp.MustCompile(`^[+-]?(0|[1-9](_?\d)*)$`)
+	tomlFloatRegex     = regexp.MustCompile(
+		`^[+-]?(0|[1-9](_?\d)*)((\.\d(_?\d)*)([eE][+-]?\d(_?\d)*)?|[eE][+-]?\d(_?\d)*)$`)
+	tomlPrefixedIntegerRegexes = map[string]*regexp.Regexp{
+		"0x": regexp.MustCompile(`^[0-9A-Fa-f](_?[0-9A-Fa-f])*$`),
+		"0o": regexp.MustCompile(`^[0-7](_?[0-7])*$`),
+		"0b": regexp.MustCompile(`^[01](_?[01])*$`),
+	}
+)
+
+// parseScalar parses a number, boolean, date-time, or local time.
+func (p *tomlParser) parseScalar() (*tomlValue, error) {
+	start := p.pos
+	isScalarChar := func(c byte) bool { return isTOMLBareKeyChar(c) || c == '+' || c == '.' || c == ':' }
+	for p.pos < len(p.data) && isScalarChar(p.data[p.pos]) {
+		p.pos++
+	}
+	if tomlDateRegex.Match(p.data[start:p.pos]) && p.peek(" ") && p.pos+1 < len(p.data) &&
+		p.data[p.pos+1] >= '0' && p.data[p.pos+1] <= '9' { // a date and time separated by a space
+		p.pos++
+		for p.pos < len(p.data) && isScalarChar(p.data[p.pos]) {
+			p.pos++
+		}
+	}
+	text := string(p.data[start:p.pos])
+	if text == "" {
+		return nil, p.fail(start, errTOMLExpected("a value"))
+	}
+	value, ok := parseTOMLScalar(text)
+	if !ok {
+		return nil, p.fail(start, errTOMLInvalidValue(text))
+	}
+	value.offset = start
+	return value, nil
+}
+
+func parseTOMLScalar(text string) (*tomlValue, bool) {
+	switch text {
+	case "true", "false":
+		return &tomlValue{kind: tomlBool, boolValue: text == "true"}, true
+	case "inf", "+inf":
+		return &tomlValue{kind: tomlFloat, floatValue: math.Inf(1)}, true
+	case "-inf":
+		return &tomlValue{kind: tomlFloat, floatValue: math.Inf(-1)}, true
+	case "nan", "+nan", "-nan":
+		return &tomlValue{kind: tomlFloat, floatValue: math.NaN()}, true
+	}
+	switch {
+	case tomlLocalTimeRegex.MatchString(text):
+		if _, err := time.Parse("15:04:05", text); err == nil {
+			return &tomlValue{kind: tomlLocalTime, str: text}, true
+		}
+	case tomlDateTimeRegex.MatchString(text):
+		if t, err := NewOptTimeFromString(text); err == nil {
+			return &tomlValue{kind: tomlDateTime, str: text, timeValue: t.GetOrElse(time.Time{})}, true
+		}
+	case tomlIntegerRegex.MatchString(text):
+		if n, err := strconv.ParseInt(strings.ReplaceAll(text, "_", ""), 10, 64); err == nil {
+			return &tomlValue{kind: tomlInteger, intValue: n}, true
+		}
+	case tomlFloatRegex.MatchString(text):
+		if f, err := strconv.ParseFloat(strings.ReplaceAll(text, "_", ""), 64); err == nil {
+			return &tomlValue{kind: tomlFloat, floatValue: f}, true
+		}
+	case len(text) > 2 && tomlPrefixedIntegerRegexes[text[:2]] != nil:
+		if tomlPrefixedIntegerRegexes[text[:2]].MatchString(text[2:]) {
+			base := map[byte]int{'x': 16, 'o': 8, 'b': 2}[text[1]]
+			if n, err := strconv.ParseInt(strings.ReplaceAll(text[2:], "_", ""), base, 64); err == nil {
+				return &tomlValue{kind: tomlInteger, intValue: n}, true
+			}
+		}
+	}
+	return nil, false
+}
+
+func (p *tomlParser) parseBasicString() (string, error) {
+	start := p.pos
+	p.pos++
+	var b strings.Builder
+	for p.pos < len(p.data) && p.data[p.pos] != '\n' {
+		switch c := p.data[p.pos]; c {
+		case '"':
+			p.pos++
+			return b.String(), nil
+		case '\\':
+			if err := p.parseEscape(&b); err != nil {
+				return "", err
+			}
+		default:
+			b.WriteByte(c)
+			p.pos++
+		}
+	}
+	return "", p.fail(start, errTOMLUnterminatedString())
+}
+
+func (p *tomlParser) parseLiteralString() (string, error) {
+	start := p.pos
+	p.pos++
+	for end := p.pos; end < len(p.data) && p.data[end] != '\n'; end++ {
+		if p.data[end] == '\'' {
+			s := string(p.data[p.pos:end])
+			p.pos = end + 1
+			return s, nil
+		}
+	}
+	return "", p.fail(start, errTOMLUnterminatedString())
+}
+
+// parseMultiLineString parses a string delimited by """ or ”', which can contain line breaks. Only
+// the first kind can contain escape sequences.
+func (p *tomlParser) parseMultiLineString(delimiter string) (string, error) {
+	start := p.pos
+	p.pos += len(delimiter)
+	if !p.consume("\n") {
+		p.consume("\r\n") // a line break right after the opening delimiter is not part of the string
+	}
+	var b strings.Builder
+	for p.pos < len(p.data) {
+		c := p.data[p.pos]
+		switch {
+		case p.peek(delimiter):
+			quotes := len(delimiter)
+			for quotes < 5 && p.pos+quotes < len(p.data) && p.data[p.pos+quotes] == delimiter[0] {
+				quotes++ // up to two quote characters can come right before the closing delimiter
+			}
+			b.WriteString(delimiter[:quotes-len(delimiter)])
+			p.pos += quotes
+			return b.String(), nil
+		case c == '\r' && p.peek("\r\n"):
+			p.pos++
+		case c == '\\' && delimiter == `"""`:
+			if p.skipLineEndingBackslash() {
+				continue
+			}
+			if err := p.parseEscape(&b); err != nil {
+				return "", err
+			}
+		default:
+			b.WriteByte(c)
+			p.pos++
+		}
+	}
+	return "", p.fail(start, errTOMLUnterminatedString())
+}
+
+// skipLineEndingBackslash checks for a backslash at the end of a line in a multi-line string. If
+// there is one, it skips it and all whitespace and line breaks after it, and returns true.
+func (p *tomlParser) skipLineEndingBackslash() bool {
+	i := p.pos + 1
+	for i < len(p.data) && (p.data[i] == ' ' || p.data[i] == '\t') {
+		i++
+	}
+	if i == len(p.data) || (p.data[i] != '\n' && p.data[i] != '\r') {
+		return false
+	}
+	for i < len(p.data) && strings.IndexByte(" \t\r\n", p.data[i]) >= 0 {
+		i++
+	}
+	p.pos = i
+	return true
+}
+
+func (p *tomlParser) parseEscape(b *strings.Builder) error {
+	start := p.pos
+	if p.pos+1 == len(p.data) {
+		return p.fail(start, errTOMLUnterminatedString())
+	}
+	c := p.data[p.pos+1]
+	p.pos += 2
+	if r, ok := map[byte]rune{'b': '\b', 't': '\t', 'n': '\n', 'f': '\f', 'r': '\r', '"': '"', '\\': '\\'}[c]; ok {
+		b.WriteRune(r)
+		return nil
+	}
+	digits := map[byte]int{'u': 4, 'U': 8}[c]
+	if digits == 0 || p.pos+digits > len(p.data) {
+		return p.fail(start, errTOMLInvalidEscape(string(p.data[start:p.pos])))
+	}
+	n, err := strconv.ParseUint(string(p.data[p.pos:p.pos+digits]), 16, 32)
+	p.pos += digits
+	if err != nil || !utf8.ValidRune(rune(n)) {
+		return p.fail(start, errTOMLInvalidEscape(string(p.data[start:p.pos])))
+	}
+	b.WriteRune(rune(n))
+	return nil
+}
+
+type tomlLoader struct {
+	data    []byte
+	options LoadOptions
+	result  *ValidationResult
+}
+
+func (l *tomlLoader) addError(path ValidationPath, offset int, err error) {
+	line, column := lineAndColumn(l.data, offset)
+	l.result.Add(ValidationError{
+		Path:   append(ValidationPath(nil), path...),
+		Err:    err,
+		Source: l.options.Source,
+		Line:   line,
+		Column: column,
+	})
+}
+
+func (l *tomlLoader) decodeTable(table *tomlValue, target reflect.Value, path ValidationPath) {
+	for _, key := range table.keys {
+		keyPath := append(path, PathField(key))
+		field, ok := findFieldForKey(target.Type(), key)
+		if !ok {
+			if l.options.DisallowUnknownKeys {
+				l.addError(keyPath, table.keyOffsets[key], errUnknownKey())
+			}
+			continue
+		}
+		l.decode(table.fields[key], target.Field(field.index), keyPath)
+	}
+}
+
+// decode sets an addressable value from a TOML value.
+func (l *tomlLoader) decode(value *tomlValue, target reflect.Value, path ValidationPath) {
+	t := target.Type()
+	switch {
+	case t.Kind() == reflect.Ptr:
+		if target.IsNil() {
+			target.Set(reflect.New(t.Elem()))
+		}
+		l.decode(value, target.Elem(), path)
+		return
+	case value.kind == tomlTable && isStructType(t):
+		l.decodeTable(value, target, path)
+		return
+	case value.kind == tomlTable && t.Kind() == reflect.Map && t.Key().Kind() == reflect.String:
+		if target.IsNil() {
+			target.Set(reflect.MakeMap(t))
+		}
+		for _, key := range value.keys {
+			elem := reflect.New(t.Elem()).Elem()
+			l.decode(value.fields[key], elem, append(path, PathKey(key)))
+			target.SetMapIndex(reflect.ValueOf(key).Convert(t.Key()), elem)
+		}
+		return
+	case value.kind == tomlArray && t.Kind() == reflect.Slice && !isTOMLUnmarshalerType(t):
+		slice := reflect.MakeSlice(t, len(value.elements), len(value.elements))
+		for i, elem := range value.elements {
+			l.decode(elem, slice.Index(i), append(path, PathIndex(i)))
+		}
+		target.Set(slice)
+		return
+	}
+	newValue := reflect.New(t) // so that the old value is kept if the new one is invalid
+	if err := setTOMLValue(value, newValue.Elem()); err != nil {
+		l.addError(path, value.offset, err)
+		return
+	}
+	target.Set(newValue.Elem())
+}
+
+func isTOMLUnmarshalerType(t reflect.Type) bool {
+	p := reflect.PointerTo(t)
+	return p.Implements(reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()) ||
+		p.Implements(reflect.TypeOf((*json.Unmarshaler)(nil)).Elem())
+}
+
+// setTOMLValue sets a value that is not a struct, map, or slice from a TOML value.
+func setTOMLValue(value *tomlValue, target reflect.Value) error {
+	t := target.Type()
+	switch {
+	case value.kind == tomlDateTime && t == reflect.TypeOf(time.Time{}):
+		target.Set(reflect.ValueOf(value.timeValue))
+		return nil
+	case value.kind == tomlDateTime && t == reflect.TypeOf(OptTime{}):
+		target.Set(reflect.ValueOf(NewOptTime(value.timeValue)))
+		return nil
+	}
+	isText := value.kind == tomlString || value.kind == tomlDateTime || value.kind == tomlLocalTime
+	if tu, ok := target.Addr().Interface().(encoding.TextUnmarshaler); ok && isText {
+		return tu.UnmarshalText([]byte(value.str))
+	}
+	if ju, ok := target.Addr().Interface().(json.Unmarshaler); ok {
+		data, err := json.Marshal(value.toInterface())
+		if err != nil { // for instance, JSON can't represent inf or nan
+			return errTOMLWrongType(value.kind.String(), t)
+		}
+		return ju.UnmarshalJSON(data)
+	}
+	switch {
+	case t.Kind() == reflect.String && value.kind == tomlString:
+		target.SetString(value.str)
+	case t.Kind() == reflect.Bool && value.kind == tomlBool:
+		target.SetBool(value.boolValue)
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Int64 && value.kind == tomlInteger:
+		if target.OverflowInt(value.intValue) {
+			return errTOMLOutOfRange(value.intValue, t)
+		}
+		target.SetInt(value.intValue)
+	case t.Kind() >= reflect.Uint && t.Kind() <= reflect.Uintptr && value.kind == tomlInteger:
+		if value.intValue < 0 || target.OverflowUint(uint64(value.intValue)) {
+			return errTOMLOutOfRange(value.intValue, t)
+		}
+		target.SetUint(uint64(value.intValue))
+	case (t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64) && value.kind == tomlFloat:
+		target.SetFloat(value.floatValue)
+	case (t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64) && value.kind == tomlInteger:
+		target.SetFloat(float64(value.intValue))
+	case t.Kind() == reflect.Interface && t.NumMethod() == 0:
+		target.Set(reflect.ValueOf(value.toInterface()))
+	default:
+		return errTOMLWrongType(value.kind.String(), t)
+	}
+	return nil
+}
@@ -0,0 +1,346 @@
+package configtypes
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testStructForTOML struct {
+	Port     OptIntGreaterThanZero `conf:"PORT"`
+	Timeout  OptDuration
+	Hosts    OptStringList
+	Name     OptString `conf:",required"`
+	Enabled  OptBool
+	Expires  OptTime
+	Created  time.Time
+	Count    int
+	Small    int8
+	Ratio    float64
+	Label    string
+	Tags     []string
+	Server   testStructForTOMLServer
+	Backup   *testStructForTOMLServer
+	Replicas []testStructForTOMLServer `conf:"REPLICA"`
+	Headers  map[string]string
+	Extra    interface{}
+}
+
+type testStructForTOMLServer struct {
+	Host        OptURLAbsolute `conf:"HOST"`
+	ReadTimeout OptDuration    `conf:"READ_TIMEOUT"`
+}
+
+func TestLoadTOML(t *testing.T) {
+	t.Run("valid file", func(t *testing.T) {
+		s := testStructForTOML{Count: 1, Label: "default"}
+		result := LoadTOML(strings.NewReader("\ufeff"+`# comment
+port = 8080 # trailing comment
+timeout = "5s"
+hosts = [
+  "a",  # comment in array
+  "b",
+]
+name = 'n'
+enabled = true
+expires = 2030-01-02 03:04:05Z
+created = 2020-01-02
+ratio = 1_000
+tags = ["x", """
+y"""]
+headers = {X = "y", "Z" = 'w'}
+extra = {a = [1, 2.5, false]}
+
+[server]
+host = "http://a"
+read-timeout = "3s"
+
+[backup]
+
+[[replica]]
+host = "http://b"
+
+[[replica]]
+`), &s, LoadOptions{})
+		assert.True(t, result.OK(), result.Errors())
+		hostA, _ := NewOptURLAbsoluteFromString("http://a")
+		hostB, _ := NewOptURLAbsoluteFromString("http://b")
+		assert.Equal(t, testStructForTOML{
+			Port:     mustOptIntGreaterThanZero(8080),
+			Timeout:  NewOptDuration(5 * time.Second),
+			Hosts:    NewOptStringList([]string{"a", "b"}),
+			Name:     NewOptString("n"),
+			Enabled:  NewOptBool(true),
+			Expires:  NewOptTime(time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)),
+			Created:  time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC),
+			Count:    1,
+			Ratio:    1000,
+			Label:    "default",
+			Tags:     []string{"x", "y"},
+			Server:   testStructForTOMLServer{Host: hostA, ReadTimeout: NewOptDuration(3 * time.Second)},
+			Backup:   &testStructForTOMLServer{},
+			Replicas: []testStructForTOMLServer{{Host: hostB}, {}},
+			Headers:  map[string]string{"X": "y", "Z": "w"},
+			Extra:    map[string]interface{}{"a": []interface{}{int64(1), 2.5, false}},
+		}, s)
+	})
+
+	t.Run("strings are parsed with UnmarshalText", func(t *testing.T) {
+		var s testStructForTOML
+		result := LoadTOML(strings.NewReader(strings.Join([]string{
+			`port = "8080"`, `hosts = "a,b"`, `enabled = "yes"`, `expires = "2030-01-02"`, `name = "n"`,
+		}, "\n")), &s, LoadOptions{})
+		assert.True(t, result.OK(), result.Errors())
+		assert.Equal(t, mustOptIntGreaterThanZero(8080), s.Port)
+		assert.Equal(t, NewOptStringList([]string{"a", "b"}), s.Hosts)
+		assert.Equal(t, NewOptBool(true), s.Enabled)
+		assert.Equal(t, NewOptTime(time.Date(2030, 1, 2, 0, 0, 0, 0, time.UTC)), s.Expires)
+	})
+
+	t.Run("field errors", func(t *testing.T) {
+		s := testStructForTOML{Label: "default"}
+		result := LoadTOML(strings.NewReader(strings.Join([]string{
+			"port = 0",
+			"timeout = 5",
+			"label = 1",
+			"small = 1000",
+			"expires = 03:04:05",
+			"ratio = inf",
+			"name = 'n'",
+			"other = 1",
+			"[server]",
+			`host = "not/absolute"`,
+			"[[replica]]",
+			"[[replica]]",
+			"host = true",
+		}, "\n")), &s, LoadOptions{Source: "config.toml", DisallowUnknownKeys: true})
+		assert.Equal(t, []ValidationError{
+			{
+				Path: NewValidationPath("port"), Err: errMustBeGreaterThanZero(),
+				Source: "config.toml", Line: 1, Column: 8,
+			},
+			{Path: NewValidationPath("timeout"), Err: errDurationFormat(), Source: "config.toml", Line: 2, Column: 11},
+			{
+				Path: NewValidationPath("label"), Err: errTOMLWrongType("integer", reflect.TypeOf("")),
+				Source: "config.toml", Line: 3, Column: 9,
+			},
+			{
+				Path: NewValidationPath("small"), Err: errTOMLOutOfRange(1000, reflect.TypeOf(int8(0))),
+				Source: "config.toml", Line: 4, Column: 9,
+			},
+			{Path: NewValidationPath("expires"), Err: errTimeFormat(), Source: "config.toml", Line: 5, Column: 11},
+			{Path: NewValidationPath("other"), Err: errUnknownKey(), Source: "config.toml", Line: 8, Column: 1},
+			{
+				Path: NewValidationPath("server", "host"), Err: errURLNotAbsolute(),
+				Source: "config.toml", Line: 10, Column: 8,
+			},
+			{
+				Path: ValidationPath{PathField("replica"), PathIndex(1), PathField("host")}, Err: errURLFormat(),
+				Source: "config.toml", Line: 13, Column: 8,
+			},
+		}, result.Errors())
+		assert.Equal(t, "default", s.Label) // an invalid value does not replace the old one
+		assert.True(t, math.IsInf(s.Ratio, 1))
+	})
+
+	t.Run("ValidateStruct errors are added", func(t *testing.T) {
+		var s testStructForTOML
+		result := LoadTOML(strings.NewReader("port = 1"), &s, LoadOptions{})
+		assert.Equal(t, []ValidationError{{Path: NewValidationPath("Name"), Err: errRequired()}}, result.Errors())
+	})
+
+	t.Run("empty file", func(t *testing.T) {
+		s := testStructForTOML{Name: NewOptString("n")}
+		assert.True(t, LoadTOML(strings.NewReader(""), &s, LoadOptions{}).OK())
+	})
+
+	t.Run("syntax errors", func(t *testing.T) {
+		for input, expected := range map[string]ValidationError{
+			"a = 1\nb":                {Err: errTOMLExpected(`"=" after the key`), Line: 2, Column: 2},
+			"a = ":                    {Err: errTOMLExpected("a value"), Line: 1, Column: 5},
+			"a = 1 2":                 {Err: errTOMLExpected("the end of the line"), Line: 1, Column: 7},
+			"a = 01":                  {Err: errTOMLInvalidValue("01"), Line: 1, Column: 5},
+			"a = 1__0":                {Err: errTOMLInvalidValue("1__0"), Line: 1, Column: 5},
+			"a = 1.":                  {Err: errTOMLInvalidValue("1."), Line: 1, Column: 5},
+			"a = 2020-13-01":          {Err: errTOMLInvalidValue("2020-13-01"), Line: 1, Column: 5},
+			"a = yes":                 {Err: errTOMLInvalidValue("yes"), Line: 1, Column: 5},
+			"a = [1 2]":               {Err: errTOMLExpected(`"," or "]"`), Line: 1, Column: 8},
+			"a = {b = 1,}":            {Err: errTOMLExpected("a key"), Line: 1, Column: 12},
+			"a = {b = 1\n}":           {Err: errTOMLExpected(`"," or "}"`), Line: 1, Column: 11},
+			`a = "b`:                  {Err: errTOMLUnterminatedString(), Line: 1, Column: 5},
+			"a = '''b''":              {Err: errTOMLUnterminatedString(), Line: 1, Column: 5},
+			`a = "\x"`:                {Err: errTOMLInvalidEscape(`\x`), Line: 1, Column: 6},
+			`a = "\uD800"`:            {Err: errTOMLInvalidEscape(`\uD800`), Line: 1, Column: 6},
+			"a = 1\na = 2":            {Err: errTOMLAlreadyDefined("a"), Line: 2, Column: 1},
+			"a = 1\na.b = 2":          {Err: errTOMLAlreadyDefined("a"), Line: 2, Column: 1},
+			"[a]\n[a]":                {Err: errTOMLAlreadyDefined("a"), Line: 2, Column: 1},
+			"a.b = 1\n[a]":            {Err: errTOMLAlreadyDefined("a"), Line: 2, Column: 1},
+			"a = {b = 1}\n[a.c]":      {Err: errTOMLAlreadyDefined("a"), Line: 2, Column: 1},
+			"[a]\n[[a]]":              {Err: errTOMLAlreadyDefined("a"), Line: 2, Column: 1},
+			"[a.b.c]\n[a]\nb.d = 1":   {Err: errTOMLAlreadyDefined("b"), Line: 3, Column: 1},
+			"[a":                      {Err: errTOMLExpected(`"]"`), Line: 1, Column: 3},
+			"[[a]":                    {Err: errTOMLExpected(`"]]"`), Line: 1, Column: 4},
+			"[]":                      {Err: errTOMLExpected("a key"), Line: 1, Column: 2},
+			"a = 1\n\n  [b] x":        {Err: errTOMLExpected("the end of the line"), Line: 3, Column: 7},
+			"a = \"\"\"\nb\nc = \"\"": {Err: errTOMLUnterminatedString(), Line: 1, Column: 5},
+		} {
+			t.Run(input, func(t *testing.T) {
+				var s testStructForTOML
+				result := LoadTOML(strings.NewReader(input), &s, LoadOptions{})
+				assert.Equal(t, []ValidationError{expected}, result.Errors())
+			})
+		}
+	})
+
+	t.Run("TOML values", func(t *testing.T) {
+		for input, expected := range map[string]interface{}{
+			`"a\tb\u00e9\U0001F600\"\\"`:        "a\tb\u00e9\U0001F600\"\\",
+			`'C:\path'`:                         `C:\path`,
+			"\"\"\"\na\r\nb \\\n   c\"\"\"\"\"": "a\nb c\"\"",
+			"'''\n'a'\n'''":                     "'a'\n",
+			`0`:                                 int64(0),
+			`-17`:                               int64(-17),
+			`+1_000`:                            int64(1000),
+			`0xDEAD_beef`:                       int64(0xdeadbeef),
+			`0o755`:                             int64(0o755),
+			`0b1010`:                            int64(10),
+			`9223372036854775807`:               int64(math.MaxInt64),
+			`3.5`:                               3.5,
+			`-0.01e2`:                           -1.0,
+			`6E-1_0`:                            6e-10,
+			`-inf`:                              math.Inf(-1),
+			`true`:                              true,
+			`1979-05-27T07:32:00.5-07:00`: time.Date(1979, 5, 27, 7, 32, 0, 500000000,
+				time.FixedZone("", -7*60*60)),
+			`1979-05-27t07:32:00z`: time.Date(1979, 5, 27, 7, 32, 0, 0, time.UTC),
+			`1979-05-27 07:32:00`:  time.Date(1979, 5, 27, 7, 32, 0, 0, time.UTC),
+			`07:32:00.999`:         "07:32:00.999",
+			`[1, [2], {a.b = 3}]`: []interface{}{int64(1), []interface{}{int64(2)},
+				map[string]interface{}{"a": map[string]interface{}{"b": int64(3)}}},
+		} {
+			t.Run(input, func(t *testing.T) {
+				root, _, err := parseTOML([]byte("x = " + input))
+				require.NoError(t, err)
+				assert.Equal(t, expected, root.fields["x"].toInterface())
+			})
+		}
+
+		for _, input := range []string{"nan", "+nan"} {
+			root, _, err := parseTOML([]byte("x = " + input))
+			require.NoError(t, err)
+			assert.True(t, math.IsNaN(root.fields["x"].floatValue))
+		}
+	})
+}
+
+// TestTOMLConformance runs the documents in testdata/toml, which follow the layout of the toml-test
+// suite (github.com/toml-lang/toml-test): each file in "valid" must parse to the tagged JSON in the
+// corresponding .json file, and each file in "invalid" must be rejected.
+func TestTOMLConformance(t *testing.T) {
+	validFiles, err := filepath.Glob(filepath.Join("testdata", "toml", "valid", "*.toml"))
+	require.NoError(t, err)
+	require.NotEmpty(t, validFiles)
+	for _, path := range validFiles {
+		t.Run("valid/"+strings.TrimSuffix(filepath.Base(path), ".toml"), func(t *testing.T) {
+			data, err := os.ReadFile(path)
+			require.NoError(t, err)
+			expectedJSON, err := os.ReadFile(strings.TrimSuffix(path, ".toml") + ".json")
+			require.NoError(t, err)
+			var expected interface{}
+			require.NoError(t, json.Unmarshal(expectedJSON, &expected))
+
+			root, _, err := parseTOML(data)
+			require.NoError(t, err)
+			assert.Equal(t, normalizeTOMLTestFloats(expected), normalizeTOMLTestFloats(tomlTestTaggedValue(root)))
+		})
+	}
+
+	invalidFiles, err := filepath.Glob(filepath.Join("testdata", "toml", "invalid", "*.toml"))
+	require.NoError(t, err)
+	require.NotEmpty(t, invalidFiles)
+	for _, path := range invalidFiles {
+		t.Run("invalid/"+strings.TrimSuffix(filepath.Base(path), ".toml"), func(t *testing.T) {
+			data, err := os.ReadFile(path)
+			require.NoError(t, err)
+			_, _, err = parseTOML(data)
+			assert.Error(t, err)
+		})
+	}
+}
+
+// tomlTestTaggedValue converts a parsed value to the JSON encoding used by toml-test, in which every
+// scalar is an object with a "type" and a string "value".
+func tomlTestTaggedValue(v *tomlValue) interface{} {
+	tagged := func(typeName, value string) interface{} {
+		return map[string]interface{}{"type": typeName, "value": value}
+	}
+	switch v.kind {
+	case tomlString:
+		return tagged("string", v.str)
+	case tomlInteger:
+		return tagged("integer", strconv.FormatInt(v.intValue, 10))
+	case tomlFloat:
+		return tagged("float", strconv.FormatFloat(v.floatValue, 'g', -1, 64))
+	case tomlBool:
+		return tagged("bool", strconv.FormatBool(v.boolValue))
+	case tomlDateTime:
+		text := strings.ToUpper(strings.Replace(v.str, " ", "T", 1))
+		switch {
+		case !strings.Contains(text, "T"):
+			return tagged("date-local", text)
+		case strings.HasSuffix(text, "Z") || strings.ContainsAny(text[strings.Index(text, "T"):], "+-"):
+			return tagged("datetime", text)
+		default:
+			return tagged("datetime-local", text)
+		}
+	case tomlLocalTime:
+		return tagged("time-local", v.str)
+	case tomlArray:
+		ret := make([]interface{}, 0, len(v.elements))
+		for _, e := range v.elements {
+			ret = append(ret, tomlTestTaggedValue(e))
+		}
+		return ret
+	default:
+		ret := make(map[string]interface{}, len(v.keys))
+		for _, k := range v.keys {
+			ret[k] = tomlTestTaggedValue(v.fields[k])
+		}
+		return ret
+	}
+}
+
+// normalizeTOMLTestFloats rewrites the value of each tagged float in a canonical form, since toml-test
+// compares floats by value rather than by their text.
+func normalizeTOMLTestFloats(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if v["type"] == "float" {
+			if f, err := strconv.ParseFloat(v["value"].(string), 64); err == nil {
+				return map[string]interface{}{"type": "float", "value": strconv.FormatFloat(f, 'g', -1, 64)}
+			}
+			return v
+		}
+		ret := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			ret[k] = normalizeTOMLTestFloats(e)
+		}
+		return ret
+	case []interface{}:
+		ret := make([]interface{}, 0, len(v))
+		for _, e := range v {
+			ret = append(ret, normalizeTOMLTestFloats(e))
+		}
+		return ret
+	}
+	return v
+}
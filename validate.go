@@ -2,25 +2,32 @@ package configtypes
 
 import (
 	"reflect"
+	"sort"
 )
 
 // ValidateStruct checks whether all of a struct's exported fields are valid according to the
 // tag-based required field rule. If the recursive parameter is true, then ValidateStruct will be
-// called recursively on any embedded structs in exported fields.
+// called recursively on any embedded structs or non-nil struct pointers in exported fields, and on
+// any structs or non-nil struct pointers that are elements of slices, arrays, or maps in exported
+// fields.
 //
 // The required field rule is that if any field has a "conf:" field tag that includes ",required",
 // it must have a value that is not the zero value for that type. Therefore, any required field
 // that uses an Opt type must be in the "defined" state (since its zero value is the "empty"
-// state); a required int field must be non-zero; a required string field must not be ""; etc.
+// state); a required int field must be non-zero; a required string field must not be ""; a
+// required pointer field, including a pointer to a struct, must not be nil; etc.
 //
-// The returned ValidationResult can contain any number of errors.
+// The returned ValidationResult can contain any number of errors. Errors for elements of a slice,
+// array, or map have paths that include a subscript, such as "Upstreams[2].URL" or
+// `Headers["X-Api-Key"].Value`; see PathIndex and PathKey.
 //
 // Calling ValidateStruct with a parameter that is not a struct or struct pointer returns an error
 // result.
 func ValidateStruct(value interface{}, recursive bool) ValidationResult {
 	refStruct, ok := getReflectValueForStruct(value)
 	if ok {
-		return validateFields(refStruct, recursive)
+		v := structValidator{recursive: recursive}
+		return v.validateFields(refStruct)
 	} else {
 		var result ValidationResult
 		result.AddError(nil, errValidateNonStruct())
@@ -28,32 +35,136 @@ func ValidateStruct(value interface{}, recursive bool) ValidationResult {
 	}
 }
 
-func validateFields(refStruct reflect.Value, recursive bool) ValidationResult {
+type structValidator struct {
+	recursive bool
+	visited   visitSet
+}
+
+func (v *structValidator) validateFields(refStruct reflect.Value) ValidationResult {
 	var result ValidationResult
-	structType := refStruct.Type()
 
-	for i := 0; i < structType.NumField(); i++ {
-		fieldInType := structType.Field(i)
-		if !isFieldExported(fieldInType) {
+	for _, field := range getStructPlan(refStruct.Type()).fields {
+		if field.tagErr != nil { // invalid field tag, log an error for it
+			result.AddError(NewValidationPath(field.name), field.tagErr)
 			continue
 		}
-		fieldInInstance := refStruct.FieldByName(fieldInType.Name)
-		refFieldStruct, fieldIsStruct := getReflectValueForStruct(fieldInInstance.Interface())
-		if fieldIsStruct {
-			if recursive {
-				result.AddAll(ValidationPath{fieldInType.Name}, validateFields(refFieldStruct, true))
-			}
-		} else {
-			tagInfo, err := getFieldTagInfo(fieldInType)
-			if err == nil {
-				if tagInfo.required && fieldInInstance.IsZero() {
-					result.AddError(ValidationPath{fieldInType.Name}, errRequired())
+		fieldInInstance := refStruct.Field(field.index)
+		if field.kind == fieldKindStruct {
+			if nestedResult, isStruct := v.validateNested(fieldInInstance); isStruct {
+				if !nestedResult.OK() {
+					result.AddAll(NewValidationPath(field.name), nestedResult)
 				}
-			} else { // invalid field tag, log an error for it
-				result.AddError(ValidationPath{fieldInType.Name}, err)
+				continue
+			}
+		}
+		// Any other field, including a nil pointer to a struct, is checked against the required rule.
+		if field.tagInfo.required && fieldInInstance.IsZero() {
+			result.Add(ValidationError{
+				Path:    NewValidationPath(field.name),
+				Err:     errRequired(),
+				VarName: field.tagInfo.varName,
+			})
+		}
+		if v.recursive && field.kind == fieldKindCollection {
+			if elemsResult := v.validateElements(fieldInInstance); !elemsResult.OK() {
+				result.AddAll(NewValidationPath(field.name), elemsResult)
 			}
 		}
 	}
 
 	return result
 }
+
+// validateNested checks whether a value is a struct, or a non-nil pointer or interface referring to a
+// struct. If so, it validates the struct if recursive is true, and returns true. If a pointer refers
+// to a struct that is already being validated further up in the tree, the struct is skipped.
+func (v *structValidator) validateNested(refValue reflect.Value) (ValidationResult, bool) {
+	switch refValue.Kind() {
+	case reflect.Struct:
+		if !isStructType(refValue.Type()) {
+			return ValidationResult{}, false
+		}
+		if !v.recursive {
+			return ValidationResult{}, true
+		}
+		return v.validateFields(refValue), true
+	case reflect.Interface:
+		if refValue.IsNil() {
+			return ValidationResult{}, false
+		}
+		return v.validateNested(refValue.Elem())
+	case reflect.Ptr:
+		if refValue.IsNil() {
+			return ValidationResult{}, false
+		}
+		if !v.visited.enter(refValue) {
+			return ValidationResult{}, isStructOrStructPtrType(refValue.Type().Elem())
+		}
+		defer v.visited.leave(refValue)
+		return v.validateNested(refValue.Elem())
+	}
+	return ValidationResult{}, false
+}
+
+// validateElements validates each element of a slice, array, or map whose elements are structs or
+// struct pointers. Nil pointer elements are skipped.
+func (v *structValidator) validateElements(refValue reflect.Value) ValidationResult {
+	var result ValidationResult
+	switch refValue.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < refValue.Len(); i++ {
+			if elemResult, ok := v.validateNested(refValue.Index(i)); ok && !elemResult.OK() {
+				result.AddAll(ValidationPath{PathIndex(i)}, elemResult)
+			}
+		}
+	case reflect.Map:
+		keys, keySegments := sortedMapKeys(refValue)
+		for i, key := range keys {
+			if elemResult, ok := v.validateNested(refValue.MapIndex(key)); ok && !elemResult.OK() {
+				result.AddAll(ValidationPath{keySegments[i]}, elemResult)
+			}
+		}
+	}
+	return result
+}
+
+// sortedMapKeys returns the keys of a map in a consistent order, so that validation results are
+// deterministic, along with the corresponding ValidationPath element for each key. Keys of an ordered
+// type, such as integers or strings, are sorted by value; other keys are sorted by their string form.
+func sortedMapKeys(refMap reflect.Value) ([]reflect.Value, []PathSegment) {
+	keys := refMap.MapKeys()
+	segments := make([]PathSegment, len(keys))
+	for i, key := range keys {
+		segments[i] = PathKey(key.Interface())
+	}
+	sort.Sort(mapKeySorter{keys, segments})
+	return keys, segments
+}
+
+type mapKeySorter struct {
+	keys     []reflect.Value
+	segments []PathSegment
+}
+
+func (s mapKeySorter) Len() int { return len(s.keys) }
+
+func (s mapKeySorter) Less(i, j int) bool {
+	a, b := s.keys[i], s.keys[j]
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() < b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() < b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() < b.Float()
+	case reflect.String:
+		return a.String() < b.String()
+	default:
+		return s.segments[i].String() < s.segments[j].String()
+	}
+}
+
+func (s mapKeySorter) Swap(i, j int) {
+	s.keys[i], s.keys[j] = s.keys[j], s.keys[i]
+	s.segments[i], s.segments[j] = s.segments[j], s.segments[i]
+}
@@ -25,19 +25,38 @@ func TestValidateStruct(t *testing.T) {
 	t.Run("requires required fields to be set", func(t *testing.T) {
 		s1 := structToValidateWithRequirements{}
 		assert.Equal(t, []ValidationError{
-			{Path: ValidationPath{"Int"}, Err: errRequired()},
-			{Path: ValidationPath{"Str"}, Err: errRequired()},
+			{Path: NewValidationPath("Int"), Err: errRequired()},
+			{Path: NewValidationPath("Str"), Err: errRequired()},
 		}, ValidateStruct(&s1, false).Errors())
 
 		s2 := structToValidateWithRequirements{Str: "x"}
 		assert.Equal(t, []ValidationError{
-			{Path: ValidationPath{"Int"}, Err: errRequired()},
+			{Path: NewValidationPath("Int"), Err: errRequired()},
 		}, ValidateStruct(&s2, false).Errors())
 
 		s3 := structToValidateWithRequirements{Int: NewOptInt(3), Str: "x"}
 		assert.NoError(t, ValidateStruct(&s3, false).GetError())
 	})
 
+	t.Run("error for required field includes variable name from tag", func(t *testing.T) {
+		s := structWithVarNames{}
+		assert.Equal(t, []ValidationError{
+			{Path: NewValidationPath("Int"), Err: errRequired(), VarName: "INT_VAR"},
+		}, ValidateStruct(&s, false).Errors())
+	})
+
+	t.Run("field whose type implements RequiredValue is required without tag option", func(t *testing.T) {
+		s := struct {
+			Key ReqSecret `conf:"KEY"`
+		}{}
+		assert.Equal(t, []ValidationError{
+			{Path: NewValidationPath("Key"), Err: errRequired(), VarName: "KEY"},
+		}, ValidateStruct(&s, false).Errors())
+
+		s.Key = mustReqSecret("x")
+		assert.NoError(t, ValidateStruct(&s, false).GetError())
+	})
+
 	t.Run("ignores nested struct when recursive is false", func(t *testing.T) {
 		s := structWithNestedStructWithRequirements{TopLevelInt: NewOptInt(3)}
 		assert.NoError(t, ValidateStruct(&s, false).GetError())
@@ -46,17 +65,17 @@ func TestValidateStruct(t *testing.T) {
 	t.Run("validates nested struct when recursive is true", func(t *testing.T) {
 		s := structWithNestedStructWithRequirements{TopLevelInt: NewOptInt(3)}
 		assert.Equal(t, []ValidationError{
-			{Path: ValidationPath{"Nested", "Int"}, Err: errRequired()},
-			{Path: ValidationPath{"Nested", "Str"}, Err: errRequired()},
+			{Path: NewValidationPath("Nested", "Int"), Err: errRequired()},
+			{Path: NewValidationPath("Nested", "Str"), Err: errRequired()},
 		}, ValidateStruct(&s, true).Errors())
 	})
 
 	t.Run("both top-level and nested fields are validated when recursive is true", func(t *testing.T) {
 		s := structWithNestedStructWithRequirements{}
 		assert.Equal(t, []ValidationError{
-			{Path: ValidationPath{"TopLevelInt"}, Err: errRequired()},
-			{Path: ValidationPath{"Nested", "Int"}, Err: errRequired()},
-			{Path: ValidationPath{"Nested", "Str"}, Err: errRequired()},
+			{Path: NewValidationPath("TopLevelInt"), Err: errRequired()},
+			{Path: NewValidationPath("Nested", "Int"), Err: errRequired()},
+			{Path: NewValidationPath("Nested", "Str"), Err: errRequired()},
 		}, ValidateStruct(&s, true).Errors())
 	})
 
@@ -64,6 +83,118 @@ func TestValidateStruct(t *testing.T) {
 		s := testStructWithBadTag{}
 		assert.Error(t, ValidateStruct(&s, false).GetError())
 	})
+
+	t.Run("nil struct pointer is allowed if not required", func(t *testing.T) {
+		s := structWithStructPointers{Required: &structToValidateNoRequirements{}}
+		assert.NoError(t, ValidateStruct(&s, true).GetError())
+	})
+
+	t.Run("nil struct pointer is an error if required", func(t *testing.T) {
+		s := structWithStructPointers{}
+		assert.Equal(t, []ValidationError{
+			{Path: NewValidationPath("Required"), Err: errRequired(), VarName: "REQUIRED_VAR"},
+		}, ValidateStruct(&s, true).Errors())
+	})
+
+	t.Run("validates non-nil struct pointer when recursive is true", func(t *testing.T) {
+		s := structWithStructPointers{
+			Optional: &structToValidateWithRequirements{Str: "x"},
+			Required: &structToValidateNoRequirements{},
+		}
+		assert.NoError(t, ValidateStruct(&s, false).GetError())
+		assert.Equal(t, []ValidationError{
+			{Path: NewValidationPath("Optional", "Int"), Err: errRequired()},
+		}, ValidateStruct(&s, true).Errors())
+	})
+
+	t.Run("rejects nil struct pointer parameter", func(t *testing.T) {
+		var s *structToValidateNoRequirements
+		assert.Equal(t, []ValidationError{{Err: errValidateNonStruct()}}, ValidateStruct(s, true).Errors())
+	})
+
+	t.Run("validates elements of slices and maps of struct pointers, skipping nil", func(t *testing.T) {
+		s := structWithCollectionsOfStructPointers{
+			Slice: []*structToValidateWithRequirements{nil, {Str: "x"}},
+			Map: map[int]*structToValidateWithRequirements{
+				2: nil,
+				1: {Int: NewOptInt(3)},
+			},
+		}
+		assert.Equal(t, []ValidationError{
+			{Path: ValidationPath{PathField("Slice"), PathIndex(1), PathField("Int")}, Err: errRequired()},
+			{Path: ValidationPath{PathField("Map"), PathKey(1), PathField("Str")}, Err: errRequired()},
+		}, ValidateStruct(&s, true).Errors())
+	})
+
+	t.Run("orders integer map keys by value", func(t *testing.T) {
+		s := structWithCollectionsOfStructPointers{
+			Map: map[int]*structToValidateWithRequirements{
+				10: {Int: NewOptInt(3)},
+				9:  {Int: NewOptInt(3)},
+			},
+		}
+		assert.Equal(t, []ValidationError{
+			{Path: ValidationPath{PathField("Map"), PathKey(9), PathField("Str")}, Err: errRequired()},
+			{Path: ValidationPath{PathField("Map"), PathKey(10), PathField("Str")}, Err: errRequired()},
+		}, ValidateStruct(&s, true).Errors())
+	})
+
+	t.Run("does not loop forever on a reference cycle", func(t *testing.T) {
+		a := &structWithSelfReference{Name: "a"}
+		b := &structWithSelfReference{Next: a}
+		a.Next = b
+		assert.Equal(t, []ValidationError{
+			{Path: NewValidationPath("Next", "Name"), Err: errRequired()},
+		}, ValidateStruct(a, true).Errors())
+	})
+
+	t.Run("validates a struct referenced more than once without a cycle", func(t *testing.T) {
+		shared := &structToValidateWithRequirements{Str: "x"}
+		s := structWithStructPointersToSameType{A: shared, B: shared}
+		assert.Equal(t, []ValidationError{
+			{Path: NewValidationPath("A", "Int"), Err: errRequired()},
+			{Path: NewValidationPath("B", "Int"), Err: errRequired()},
+		}, ValidateStruct(&s, true).Errors())
+	})
+
+	t.Run("ignores elements of slices, arrays, and maps when recursive is false", func(t *testing.T) {
+		s := structWithCollectionsOfStructs{
+			Slice: []structToValidateWithRequirements{{}},
+			Array: [1]structToValidateWithRequirements{{}},
+			Map:   map[string]structToValidateWithRequirements{"a": {}},
+		}
+		assert.NoError(t, ValidateStruct(&s, false).GetError())
+	})
+
+	t.Run("validates elements of slices, arrays, and maps when recursive is true", func(t *testing.T) {
+		valid := structToValidateWithRequirements{Int: NewOptInt(3), Str: "x"}
+		s := structWithCollectionsOfStructs{
+			Slice: []structToValidateWithRequirements{valid, {Str: "x"}},
+			Array: [1]structToValidateWithRequirements{{Int: NewOptInt(3)}},
+			Map: map[string]structToValidateWithRequirements{
+				"b": {Str: "x"},
+				"a": {Int: NewOptInt(3)},
+				"c": valid,
+			},
+			Ints: []int{0},
+		}
+		assert.Equal(t, []ValidationError{
+			{Path: ValidationPath{PathField("Slice"), PathIndex(1), PathField("Int")}, Err: errRequired()},
+			{Path: ValidationPath{PathField("Array"), PathIndex(0), PathField("Str")}, Err: errRequired()},
+			{Path: ValidationPath{PathField("Map"), PathKey("a"), PathField("Str")}, Err: errRequired()},
+			{Path: ValidationPath{PathField("Map"), PathKey("b"), PathField("Int")}, Err: errRequired()},
+		}, ValidateStruct(&s, true).Errors())
+	})
+
+	t.Run("error paths for elements include subscripts", func(t *testing.T) {
+		s := structWithCollectionsOfStructs{
+			Slice: []structToValidateWithRequirements{{Int: NewOptInt(3)}},
+			Array: [1]structToValidateWithRequirements{{Int: NewOptInt(3), Str: "x"}},
+			Map:   map[string]structToValidateWithRequirements{"X-Api-Key": {Int: NewOptInt(3)}},
+		}
+		assert.Equal(t, `Slice[0].Str: value is required, Map["X-Api-Key"].Str: value is required`,
+			ValidateStruct(&s, true).GetError().Error())
+	})
 }
 
 type mockValidation struct {
@@ -89,3 +220,33 @@ type structWithNestedStructWithRequirements struct {
 	TopLevelInt OptInt `conf:",required"`
 	Nested      structToValidateWithRequirements
 }
+
+type structWithCollectionsOfStructs struct {
+	Slice []structToValidateWithRequirements
+	Array [1]structToValidateWithRequirements
+	Map   map[string]structToValidateWithRequirements
+	Ints  []int
+}
+
+type structWithVarNames struct {
+	Int OptInt `conf:"INT_VAR,required"`
+}
+
+type structWithStructPointers struct {
+	Optional *structToValidateWithRequirements
+	Required *structToValidateNoRequirements `conf:"REQUIRED_VAR,required"`
+}
+
+type structWithCollectionsOfStructPointers struct {
+	Slice []*structToValidateWithRequirements
+	Map   map[int]*structToValidateWithRequirements
+}
+
+type structWithSelfReference struct {
+	Name string `conf:",required"`
+	Next *structWithSelfReference
+}
+
+type structWithStructPointersToSameType struct {
+	A, B *structToValidateWithRequirements
+}
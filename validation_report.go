@@ -0,0 +1,201 @@
+package configtypes
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ReportColor specifies whether ValidationResult.WriteReport should use ANSI terminal colors.
+type ReportColor int
+
+const (
+	// ReportColorNever means the report is plain text. This is the default.
+	ReportColorNever ReportColor = iota
+	// ReportColorAlways means the report always uses ANSI terminal colors.
+	ReportColorAlways
+	// ReportColorAuto means the report uses ANSI terminal colors only if it is being written to a
+	// terminal, and the NO_COLOR environment variable is not set.
+	ReportColorAuto
+)
+
+// ReportOptions specifies optional behavior for ValidationResult.Report and
+// ValidationResult.WriteReport.
+type ReportOptions struct {
+	// MaxErrors is the maximum number of errors to show. If there are more, the report ends with a
+	// line saying how many were omitted. Zero means there is no limit.
+	MaxErrors int
+
+	// Color specifies whether to use ANSI terminal colors.
+	Color ReportColor
+}
+
+const (
+	ansiReset  = "\x1b[0m"
+	ansiBold   = "\x1b[1m"
+	ansiRed    = "\x1b[31m"
+	ansiYellow = "\x1b[33m"
+	ansiFaint  = "\x1b[2m"
+)
+
+// Report returns a human-readable, multi-line description of all errors in the result, or an empty
+// string if there were none. It is equivalent to WriteReport, except that ReportColorAuto is treated
+// the same as ReportColorNever.
+func (r ValidationResult) Report(options ReportOptions) string {
+	var b strings.Builder
+	_ = r.WriteReport(&b, options)
+	return b.String()
+}
+
+// WriteReport writes a human-readable, multi-line description of all errors in the result, or
+// nothing if there were none.
+//
+// Errors are grouped by the first element of their path, so that for instance all errors for
+// fields within a nested struct field "Database" appear together. Groups are sorted by name, and
+// errors within a group are sorted by path and then by message, so the output for a given set of
+// errors is always the same regardless of the order they were found in. Errors that have no path
+// appear first. Each error is shown with its variable name and source, if known.
+//
+//	3 configuration errors:
+//
+//	Database:
+//	  Database.Host: value is required (variable DB_HOST)
+//	  Database.Port: not a valid integer (variable DB_PORT, from environment)
+//
+//	HTTP_PORT:
+//	  HTTP_PORT: not a valid integer (from environment)
+func (r ValidationResult) WriteReport(w io.Writer, options ReportOptions) error {
+	if r.OK() {
+		return nil
+	}
+	rw := reportWriter{w: w, color: useReportColor(w, options.Color)}
+
+	errs := r.Errors()
+	sort.SliceStable(errs, func(i, j int) bool {
+		gi, gj := reportGroupName(errs[i].Path), reportGroupName(errs[j].Path)
+		if gi != gj {
+			return gi < gj
+		}
+		pi, pj := errs[i].Path.String(), errs[j].Path.String()
+		if pi != pj {
+			return pi < pj
+		}
+		return errs[i].Err.Error() < errs[j].Err.Error()
+	})
+
+	shown := len(errs)
+	if options.MaxErrors > 0 && shown > options.MaxErrors {
+		shown = options.MaxErrors
+	}
+
+	rw.line(ansiBold, pluralize(len(errs), "configuration error")+":")
+	group := ""
+	for i, e := range errs[:shown] {
+		if g := reportGroupName(e.Path); i == 0 || g != group {
+			group = g
+			rw.line("", "")
+			if group != "" {
+				rw.line(ansiBold, group+":")
+			}
+		}
+		rw.errorLine(e)
+	}
+	if shown < len(errs) {
+		rw.line("", "")
+		rw.line(ansiFaint, "... and "+pluralize(len(errs)-shown, "more error"))
+	}
+	return rw.err
+}
+
+type reportWriter struct {
+	w     io.Writer
+	color bool
+	err   error
+}
+
+func (rw *reportWriter) line(color string, parts ...string) {
+	if rw.err != nil {
+		return
+	}
+	s := strings.Join(parts, "")
+	if rw.color && color != "" && s != "" {
+		s = color + s + ansiReset
+	}
+	_, rw.err = io.WriteString(rw.w, s+"\n")
+}
+
+func (rw *reportWriter) errorLine(e ValidationError) {
+	var details []string
+	if e.VarName != "" && e.VarName != e.Path.String() {
+		details = append(details, "variable "+e.VarName)
+	}
+	switch {
+	case e.Source != "":
+		details = append(details, "from "+e.Source+formatLineAndColumn(e.Line, e.Column))
+	case e.Line != 0:
+		details = append(details, "at line "+strings.TrimPrefix(formatLineAndColumn(e.Line, e.Column), ":"))
+	}
+	path, message, detail := "", e.Err.Error(), ""
+	if len(e.Path) != 0 {
+		path = rw.colored(ansiYellow, e.Path.String()) + ": "
+	}
+	if len(details) != 0 {
+		detail = " " + rw.colored(ansiFaint, "("+strings.Join(details, ", ")+")")
+	}
+	rw.line("", "  ", path, rw.colored(ansiRed, message), detail)
+}
+
+// formatLineAndColumn returns a suffix such as ":3:5" for a line and column, or "" if the line is not
+// known.
+func formatLineAndColumn(line, column int) string {
+	switch {
+	case line == 0:
+		return ""
+	case column == 0:
+		return ":" + strconv.Itoa(line)
+	}
+	return ":" + strconv.Itoa(line) + ":" + strconv.Itoa(column)
+}
+
+func (rw *reportWriter) colored(color, s string) string {
+	if rw.color {
+		return color + s + ansiReset
+	}
+	return s
+}
+
+func reportGroupName(path ValidationPath) string {
+	if len(path) == 0 {
+		return ""
+	}
+	return path[0].String()
+}
+
+func pluralize(count int, noun string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, noun)
+	}
+	return fmt.Sprintf("%d %ss", count, noun)
+}
+
+func useReportColor(w io.Writer, color ReportColor) bool {
+	switch color {
+	case ReportColorAlways:
+		return true
+	case ReportColorAuto:
+		if _, noColor := os.LookupEnv("NO_COLOR"); noColor {
+			return false
+		}
+		f, ok := w.(*os.File)
+		if !ok {
+			return false
+		}
+		info, err := f.Stat()
+		return err == nil && info.Mode()&os.ModeCharDevice != 0
+	default:
+		return false
+	}
+}
@@ -0,0 +1,129 @@
+package configtypes
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidationReport(t *testing.T) {
+	t.Run("empty result produces empty report", func(t *testing.T) {
+		assert.Equal(t, "", ValidationResult{}.Report(ReportOptions{}))
+	})
+
+	t.Run("errors are grouped and sorted", func(t *testing.T) {
+		var r ValidationResult
+		r.Add(ValidationError{Path: NewValidationPath("HTTP_PORT"), Err: errIntFormat(), Source: "environment"})
+		r.Add(ValidationError{Path: NewValidationPath("Database", "Port"), Err: errIntFormat(),
+			VarName: "DB_PORT", Source: "environment"})
+		r.Add(ValidationError{Path: NewValidationPath("Database", "Host"), Err: errRequired(),
+			VarName: "DB_HOST"})
+		r.AddError(nil, errors.New("general problem"))
+
+		assert.Equal(t, strings.Join([]string{
+			"4 configuration errors:",
+			"",
+			"  general problem",
+			"",
+			"Database:",
+			"  Database.Host: value is required (variable DB_HOST)",
+			"  Database.Port: not a valid integer (variable DB_PORT, from environment)",
+			"",
+			"HTTP_PORT:",
+			"  HTTP_PORT: not a valid integer (from environment)",
+			"",
+		}, "\n"), r.Report(ReportOptions{}))
+	})
+
+	t.Run("line and column", func(t *testing.T) {
+		var r ValidationResult
+		r.Add(ValidationError{Path: NewValidationPath("A"), Err: errIntFormat(), Source: "config.json", Line: 3, Column: 5})
+		r.Add(ValidationError{Path: NewValidationPath("B"), Err: errIntFormat(), Source: "config.ini", Line: 4})
+		r.Add(ValidationError{Path: NewValidationPath("C"), Err: errIntFormat(), Line: 2, Column: 1})
+
+		assert.Equal(t, strings.Join([]string{
+			"3 configuration errors:",
+			"",
+			"A:",
+			"  A: not a valid integer (from config.json:3:5)",
+			"",
+			"B:",
+			"  B: not a valid integer (from config.ini:4)",
+			"",
+			"C:",
+			"  C: not a valid integer (at line 2:1)",
+			"",
+		}, "\n"), r.Report(ReportOptions{}))
+	})
+
+	t.Run("errors with the same path are sorted by message", func(t *testing.T) {
+		var r ValidationResult
+		r.AddError(NewValidationPath("A"), errors.New("b"))
+		r.AddError(NewValidationPath("A"), errors.New("a"))
+
+		assert.Equal(t, "2 configuration errors:\n\nA:\n  A: a\n  A: b\n", r.Report(ReportOptions{}))
+	})
+
+	t.Run("output is capped at MaxErrors", func(t *testing.T) {
+		var r ValidationResult
+		for _, name := range []string{"E", "D", "C", "B", "A"} {
+			r.AddError(NewValidationPath(name), errRequired())
+		}
+
+		assert.Equal(t, strings.Join([]string{
+			"5 configuration errors:",
+			"",
+			"A:",
+			"  A: value is required",
+			"",
+			"B:",
+			"  B: value is required",
+			"",
+			"... and 3 more errors",
+			"",
+		}, "\n"), r.Report(ReportOptions{MaxErrors: 2}))
+
+		assert.Contains(t, r.Report(ReportOptions{MaxErrors: 4}), "... and 1 more error\n")
+		assert.NotContains(t, r.Report(ReportOptions{MaxErrors: 5}), "more")
+	})
+
+	t.Run("singular header", func(t *testing.T) {
+		var r ValidationResult
+		r.AddError(NewValidationPath("A"), errRequired())
+		assert.Equal(t, "1 configuration error:\n\nA:\n  A: value is required\n", r.Report(ReportOptions{}))
+	})
+
+	t.Run("color", func(t *testing.T) {
+		var r ValidationResult
+		r.Add(ValidationError{Path: NewValidationPath("A"), Err: errRequired(), Source: "environment"})
+
+		assert.Equal(t, strings.Join([]string{
+			ansiBold + "1 configuration error:" + ansiReset,
+			"",
+			ansiBold + "A:" + ansiReset,
+			"  " + ansiYellow + "A" + ansiReset + ": " + ansiRed + "value is required" + ansiReset +
+				" " + ansiFaint + "(from environment)" + ansiReset,
+			"",
+		}, "\n"), r.Report(ReportOptions{Color: ReportColorAlways}))
+
+		assert.NotContains(t, r.Report(ReportOptions{Color: ReportColorAuto}), ansiReset)
+	})
+
+	t.Run("automatic color is not used for a file that is not a terminal", func(t *testing.T) {
+		var r ValidationResult
+		r.AddError(NewValidationPath("A"), errRequired())
+
+		f, err := os.CreateTemp(t.TempDir(), "report")
+		require.NoError(t, err)
+		defer f.Close()
+		require.NoError(t, r.WriteReport(f, ReportOptions{Color: ReportColorAuto}))
+
+		data, err := os.ReadFile(f.Name())
+		require.NoError(t, err)
+		assert.Equal(t, r.Report(ReportOptions{}), string(data))
+	})
+}
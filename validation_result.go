@@ -2,6 +2,7 @@ package configtypes
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 )
 
@@ -13,21 +14,143 @@ type ValidationResult struct {
 // ValidationPath represents a field name or nested series of field names for a ValidationError.
 //
 // For instance, if you call Validate on a struct whose field A is a struct that has an invalid
-// field B, the path for the error would be ValidationPath{"A", "B"}.
-type ValidationPath []string
+// field B, the path for the error would be NewValidationPath("A", "B").
+//
+// An element of the path can also be a subscript for an element of a slice, array, or map, as
+// created by PathIndex or PathKey. If field A is instead a slice of structs and the invalid field
+// is in the element at index 2, the path would be ValidationPath{PathField("A"), PathIndex(2),
+// PathField("B")}.
+type ValidationPath []PathSegment
+
+// NewValidationPath returns a ValidationPath consisting of the specified field names.
+func NewValidationPath(fieldNames ...string) ValidationPath {
+	if len(fieldNames) == 0 {
+		return nil
+	}
+	path := make(ValidationPath, 0, len(fieldNames))
+	for _, name := range fieldNames {
+		path = append(path, PathField(name))
+	}
+	return path
+}
+
+// PathSegmentKind identifies the kind of a PathSegment.
+type PathSegmentKind int
+
+const (
+	// PathSegmentField is a struct field name, or the name of a variable or property.
+	PathSegmentField PathSegmentKind = iota
+	// PathSegmentIndex is an index in a slice or array.
+	PathSegmentIndex
+	// PathSegmentKey is a key in a map.
+	PathSegmentKey
+)
+
+// PathSegment is an element of a ValidationPath: a field name, an index, or a map key. Use PathField,
+// PathIndex, or PathKey to create one. The zero value is a field with an empty name.
+type PathSegment struct {
+	kind  PathSegmentKind
+	name  string
+	index int
+	key   interface{}
+}
+
+// PathField returns a ValidationPath element representing a struct field, or the name of a variable
+// or property.
+func PathField(name string) PathSegment {
+	return PathSegment{kind: PathSegmentField, name: name}
+}
+
+// PathIndex returns a ValidationPath element representing an index in a slice or array.
+//
+//	ValidationPath{PathField("Upstreams"), PathIndex(2), PathField("URL")}.String() // == "Upstreams[2].URL"
+func PathIndex(index int) PathSegment {
+	return PathSegment{kind: PathSegmentIndex, index: index}
+}
+
+// PathKey returns a ValidationPath element representing a key in a map.
+//
+//	ValidationPath{PathField("Headers"), PathKey("X-Api-Key")}.String() // == `Headers["X-Api-Key"]`
+func PathKey(key interface{}) PathSegment {
+	return PathSegment{kind: PathSegmentKey, key: key}
+}
+
+// Kind returns the kind of the segment.
+func (s PathSegment) Kind() PathSegmentKind {
+	return s.kind
+}
+
+// Name returns the field name, or "" if the segment is not a field.
+func (s PathSegment) Name() string {
+	return s.name
+}
+
+// Index returns the index, or zero if the segment is not an index.
+func (s PathSegment) Index() int {
+	return s.index
+}
+
+// Key returns the map key, or nil if the segment is not a key.
+func (s PathSegment) Key() interface{} {
+	return s.key
+}
+
+// String returns the field name for a field. For an index or a key, it returns a subscript such as
+// [2]; string keys are quoted, and keys of any other type are formatted with fmt.Sprint.
+func (s PathSegment) String() string {
+	switch s.kind {
+	case PathSegmentIndex:
+		return "[" + strconv.Itoa(s.index) + "]"
+	case PathSegmentKey:
+		if k, ok := s.key.(string); ok {
+			return "[" + strconv.Quote(k) + "]"
+		}
+		return "[" + fmt.Sprint(s.key) + "]"
+	default:
+		return s.name
+	}
+}
 
-// String converts the path to a dot-delimited string.
+// String converts the path to a string in which field names are delimited by dots, and subscripts
+// created by PathIndex or PathKey are appended to the preceding element.
 func (p ValidationPath) String() string {
 	if len(p) == 0 {
 		return ""
 	}
-	return strings.Join(p, ".")
+	var b strings.Builder
+	for i, elem := range p {
+		if i > 0 && elem.kind == PathSegmentField {
+			b.WriteByte('.')
+		}
+		b.WriteString(elem.String())
+	}
+	return b.String()
+}
+
+// child returns a new path with a segment added to the end. It does not modify the original path.
+func (p ValidationPath) child(segment PathSegment) ValidationPath {
+	return append(append(ValidationPath(nil), p...), segment)
 }
 
 // ValidationError represents an invalid value condition for a parsed value or a struct field.
 type ValidationError struct {
 	Path ValidationPath
 	Err  error
+
+	// VarName is the name of the variable associated with the field, if it is known and is not
+	// already the same as the Path. For instance, ValidateStruct sets this to the variable name
+	// from the field's "conf:" tag.
+	VarName string
+
+	// Source describes where the value came from, if known, such as "environment".
+	Source string
+
+	// Line is the line number in the source where the value appeared, starting at 1, or zero if not
+	// known. This is set by functions that read files, such as LoadJSON.
+	Line int
+
+	// Column is the column number within Line, starting at 1, or zero if not known.
+	Column int
 }
 
 // Error returns the error description, including the path if specified.
@@ -93,9 +216,23 @@ func (r *ValidationResult) AddError(path ValidationPath, e error) {
 	}
 }
 
+// Add adds a ValidationError to the result, if its Err is not nil.
+func (r *ValidationResult) Add(e ValidationError) {
+	if e.Err != nil {
+		r.errors = append(r.errors, e)
+	}
+}
+
 // AddAll adds all errors from another result, optionally adding a prefix to each path.
 func (r *ValidationResult) AddAll(prefixPath ValidationPath, other ValidationResult) {
 	for _, e := range other.errors {
-		r.errors = append(r.errors, ValidationError{Path: append(prefixPath, e.Path...), Err: e.Err})
+		var path ValidationPath
+		if len(prefixPath)+len(e.Path) > 0 {
+			// always allocate a new slice, so that paths in the result never share storage
+			path = make(ValidationPath, 0, len(prefixPath)+len(e.Path))
+			path = append(append(path, prefixPath...), e.Path...)
+		}
+		e.Path = path
+		r.errors = append(r.errors, e)
 	}
 }
@@ -18,10 +18,22 @@ func TestValidationResult(t *testing.T) {
 
 		var r ValidationResult
 		r.AddError(nil, err1)
-		r.AddError(ValidationPath{"x"}, err2)
+		r.AddError(NewValidationPath("x"), err2)
 
 		assert.False(t, r.OK())
-		assert.Equal(t, []ValidationError{{nil, err1}, {ValidationPath{"x"}, err2}}, r.Errors())
+		assert.Equal(t, []ValidationError{{Err: err1}, {Path: NewValidationPath("x"), Err: err2}}, r.Errors())
+	})
+
+	t.Run("Add", func(t *testing.T) {
+		err1 := errors.New("err1")
+
+		var r ValidationResult
+		r.Add(ValidationError{Path: NewValidationPath("x"), Err: err1, VarName: "X", Source: "environment"})
+		r.Add(ValidationError{Path: NewValidationPath("y")})
+
+		assert.Equal(t, []ValidationError{
+			{Path: NewValidationPath("x"), Err: err1, VarName: "X", Source: "environment"},
+		}, r.Errors())
 	})
 
 	t.Run("AddAll", func(t *testing.T) {
@@ -31,11 +43,29 @@ func TestValidationResult(t *testing.T) {
 		r.AddError(nil, err1)
 
 		var sub ValidationResult
-		sub.AddError(ValidationPath{"b"}, err2)
+		sub.AddError(NewValidationPath("b"), err2)
+
+		r.AddAll(NewValidationPath("a"), sub)
+
+		assert.Equal(t, []ValidationError{{Err: err1}, {Path: NewValidationPath("a", "b"), Err: err2}}, r.Errors())
+	})
+
+	t.Run("AddAll does not share path storage between errors", func(t *testing.T) {
+		err1, err2 := errors.New("err1"), errors.New("err2")
+
+		var sub ValidationResult
+		sub.AddError(NewValidationPath("b"), err1)
+		sub.AddError(NewValidationPath("c"), err2)
 
-		r.AddAll(ValidationPath{"a"}, sub)
+		prefix := make(ValidationPath, 1, 10)
+		prefix[0] = PathField("a")
+		var r ValidationResult
+		r.AddAll(prefix, sub)
 
-		assert.Equal(t, []ValidationError{{nil, err1}, {ValidationPath{"a", "b"}, err2}}, r.Errors())
+		assert.Equal(t, []ValidationError{
+			{Path: NewValidationPath("a", "b"), Err: err1},
+			{Path: NewValidationPath("a", "c"), Err: err2},
+		}, r.Errors())
 	})
 
 	t.Run("aggregate Error", func(t *testing.T) {
@@ -49,14 +79,14 @@ func TestValidationResult(t *testing.T) {
 		assert.Equal(t, ValidationError{Err: err1}, r2.GetError())
 
 		var r3 ValidationResult
-		r3.AddError(ValidationPath{"a"}, err1)
-		assert.Equal(t, ValidationError{Path: ValidationPath{"a"}, Err: err1}, r3.GetError())
+		r3.AddError(NewValidationPath("a"), err1)
+		assert.Equal(t, ValidationError{Path: NewValidationPath("a"), Err: err1}, r3.GetError())
 
 		var r4 ValidationResult
 		r4.AddError(nil, err1)
-		r4.AddError(ValidationPath{"a"}, err2)
+		r4.AddError(NewValidationPath("a"), err2)
 		assert.Equal(t,
-			ValidationAggregateError{{Err: err1}, {Path: ValidationPath{"a"}, Err: err2}},
+			ValidationAggregateError{{Err: err1}, {Path: NewValidationPath("a"), Err: err2}},
 			r4.GetError(),
 		)
 	})
@@ -66,10 +96,10 @@ func TestValidationError(t *testing.T) {
 	e1 := ValidationError{Path: nil, Err: errors.New("message")}
 	assert.Equal(t, "message", e1.String())
 
-	e2 := ValidationError{Path: ValidationPath{"a"}, Err: errors.New("message")}
+	e2 := ValidationError{Path: NewValidationPath("a"), Err: errors.New("message")}
 	assert.Equal(t, "a: message", e2.String())
 
-	e3 := ValidationError{Path: ValidationPath{"a", "b"}, Err: errors.New("message")}
+	e3 := ValidationError{Path: NewValidationPath("a", "b"), Err: errors.New("message")}
 	assert.Equal(t, "a.b: message", e3.String())
 }
 
@@ -80,13 +110,37 @@ func TestValidationAggregateError(t *testing.T) {
 	e2 := ValidationAggregateError{{Err: errors.New("message")}}
 	assert.Equal(t, "message", e2.String())
 
-	e3 := ValidationAggregateError{{Err: errors.New("message1")}, {Path: ValidationPath{"a"}, Err: errors.New("message2")}}
+	e3 := ValidationAggregateError{{Err: errors.New("message1")}, {Path: NewValidationPath("a"), Err: errors.New("message2")}}
 	assert.Equal(t, "message1, a: message2", e3.String())
 }
 
 func TestValidationPath(t *testing.T) {
 	assert.Equal(t, "", ValidationPath(nil).String())
 	assert.Equal(t, "", ValidationPath{}.String())
-	assert.Equal(t, "a", ValidationPath{"a"}.String())
-	assert.Equal(t, "a.b.c", ValidationPath{"a", "b", "c"}.String())
+	assert.Equal(t, "a", NewValidationPath("a").String())
+	assert.Equal(t, "a.b.c", NewValidationPath("a", "b", "c").String())
+	assert.Equal(t, "Upstreams[2].URL", ValidationPath{PathField("Upstreams"), PathIndex(2), PathField("URL")}.String())
+	assert.Equal(t, `Headers["X-Api-Key"]`, ValidationPath{PathField("Headers"), PathKey("X-Api-Key")}.String())
+	assert.Equal(t, "Ports[8080].Name", ValidationPath{PathField("Ports"), PathKey(8080), PathField("Name")}.String())
+	assert.Equal(t, "[0].a", ValidationPath{PathIndex(0), PathField("a")}.String())
+}
+
+func TestPathSegment(t *testing.T) {
+	field := PathField("a")
+	assert.Equal(t, PathSegmentField, field.Kind())
+	assert.Equal(t, "a", field.Name())
+	assert.Equal(t, "a", field.String())
+
+	index := PathIndex(2)
+	assert.Equal(t, PathSegmentIndex, index.Kind())
+	assert.Equal(t, 2, index.Index())
+	assert.Equal(t, "[2]", index.String())
+
+	key := PathKey(8080)
+	assert.Equal(t, PathSegmentKey, key.Kind())
+	assert.Equal(t, 8080, key.Key())
+	assert.Equal(t, "[8080]", key.String())
+	assert.Equal(t, `["x"]`, PathKey("x").String())
+
+	assert.NotEqual(t, PathField("[2]"), PathIndex(2))
 }
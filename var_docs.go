@@ -0,0 +1,265 @@
+package configtypes
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+)
+
+// VarDoc describes a variable that VarReader.ReadStruct would read into a struct field. See
+// DescribeVars.
+type VarDoc struct {
+	// Name is the variable name, including any prefix or suffix from VarDocOptions.
+	Name string
+	// Path is the path of the struct field.
+	Path ValidationPath
+	// Type is the Go type of the field, such as "configtypes.OptDuration".
+	Type string
+	// Format is a human-readable description of the accepted values, such as "duration like 1m30s",
+	// or "" if the format is not known.
+	Format string
+	// Required is true if the field tag has the "required" option, or if the field's type always
+	// requires a value (see RequiredValue).
+	Required bool
+	// Default is the field's current value in text form, or "" if it is empty or a zero value. If the
+	// field is a secret, this is the redacted form of the value.
+	Default string
+	// Description is the text of the field tag's "desc=" option, if any.
+	Description string
+}
+
+// VarDocOptions specifies optional behavior for DescribeVars.
+type VarDocOptions struct {
+	// Prefix is added to all variable names, as if the VarReader had been created with
+	// WithVarNamePrefix.
+	Prefix string
+	// Suffix is added to all variable names, as if the VarReader had been created with
+	// WithVarNameSuffix.
+	Suffix string
+}
+
+// VarDocFormat specifies the output format for WriteVarDocs.
+type VarDocFormat int
+
+const (
+	// VarDocFormatMarkdown is a Markdown table.
+	VarDocFormatMarkdown VarDocFormat = iota
+	// VarDocFormatText is a plain-text table with aligned columns.
+	VarDocFormatText
+)
+
+// DescribeVars returns a description of every variable that VarReader.ReadStruct would read into
+// the fields of a struct, in the order that it would read them. The target can be a struct or a
+// struct pointer.
+//
+// Struct fields are interpreted with the same field tag logic as ReadStruct, so the result is always
+// consistent with what ReadStruct does; if recursive is true, nested struct fields and non-nil struct
+// pointer fields are included. The current field values are reported as defaults, so you can pass a
+// struct that has already been populated with default values. An optional description can be provided
+// with a "desc=" option in the field tag:
+//
+//	type Config struct {
+//	    Port OptIntGreaterThanZero `conf:"PORT,desc=port for the HTTP server"`
+//	}
+//
+// An error is returned if the target is not a struct or if any field tag is invalid.
+func DescribeVars(target interface{}, recursive bool, options VarDocOptions) ([]VarDoc, error) {
+	refStruct, ok := getReflectValueForStruct(target)
+	if !ok {
+		return nil, errors.New("DescribeVars was called on something other than a struct or struct pointer")
+	}
+	d := varDescriber{options: options, recursive: recursive}
+	if err := d.describeFields(refStruct, nil); err != nil {
+		return nil, err
+	}
+	return d.docs, nil
+}
+
+type varDescriber struct {
+	options   VarDocOptions
+	recursive bool
+	visited   visitSet
+	docs      []VarDoc
+}
+
+func (d *varDescriber) describeFields(refStruct reflect.Value, path ValidationPath) error {
+	for _, field := range getStructPlan(refStruct.Type()).fields {
+		fieldPath := path.child(PathField(field.name))
+		if field.tagErr != nil {
+			return ValidationError{Path: fieldPath, Err: field.tagErr}
+		}
+		fieldInInstance := refStruct.Field(field.index)
+		if field.tagInfo.varName == "" {
+			if d.recursive && field.kind == fieldKindStruct {
+				err := walkNestedStruct(fieldInInstance, &d.visited, func(refStruct reflect.Value) error {
+					return d.describeFields(refStruct, fieldPath)
+				})
+				if err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		defaultValue := describeVarDefault(fieldInInstance)
+		if field.tagInfo.secret && !isSecretValueType(fieldInInstance.Type()) { // SecretValue is already redacted
+			defaultValue = redactSecret(defaultValue)
+		}
+		d.docs = append(d.docs, VarDoc{
+			Name:        d.options.Prefix + field.tagInfo.varName + d.options.Suffix,
+			Path:        fieldPath,
+			Type:        fieldInInstance.Type().String(),
+			Format:      describeVarFormat(fieldInInstance.Type()),
+			Required:    field.tagInfo.required,
+			Default:     defaultValue,
+			Description: field.tagInfo.description,
+		})
+	}
+	return nil
+}
+
+func describeVarFormat(t reflect.Type) string {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch v := reflect.Zero(t).Interface().(type) {
+	case boundedValue:
+		return v.describeVarFormat()
+	case OptBool, bool:
+		return "boolean (true/false, yes/no, or 1/0)"
+	case OptInt, int:
+		return "integer"
+	case OptInt64:
+		return "integer"
+	case OptIntGreaterThanZero, OptInt64GreaterThanZero, OptUintGreaterThanZero, OptUint64GreaterThanZero:
+		return "integer greater than zero"
+	case OptUint, OptUint64:
+		return "non-negative integer"
+	case OptFloat64, float64:
+		return "number"
+	case OptDuration:
+		return "duration like 1m30s"
+	case OptDurationNonNegative:
+		return "non-negative duration like 1m30s"
+	case OptTime:
+		return "date/time like 2006-01-02T15:04:05Z"
+	case OptString, string:
+		return "string"
+	case OptStringNonEmpty:
+		return "non-empty string"
+	case OptSecret:
+		return "secret string"
+	case ReqSecret:
+		return "non-empty secret string"
+	case OptStringList:
+		return "comma-delimited list of strings"
+	case OptURL:
+		return "URL"
+	case OptURLAbsolute:
+		return "absolute URL"
+	case OptBase2Bytes:
+		return "size in bytes like 512KB or 10MB"
+	}
+	return ""
+}
+
+func describeVarDefault(value reflect.Value) string {
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return ""
+		}
+		value = value.Elem()
+	}
+	switch v := value.Interface().(type) {
+	case SingleValue:
+		return v.String()
+	case encoding.TextMarshaler:
+		if value.IsZero() {
+			return ""
+		}
+		if text, err := v.MarshalText(); err == nil {
+			return string(text)
+		}
+		return ""
+	}
+	if value.IsZero() {
+		return ""
+	}
+	return fmt.Sprint(value.Interface())
+}
+
+// WriteVarDocs writes a table describing variables, as returned by DescribeVars. It has columns for
+// the variable name, Go type, format, whether the variable is required, the default value, and the
+// description. If no variable has a description, the description column is omitted.
+func WriteVarDocs(w io.Writer, docs []VarDoc, format VarDocFormat) error {
+	headers := []string{"Variable", "Type", "Format", "Required", "Default", "Description"}
+	hasDescriptions := false
+	for _, doc := range docs {
+		hasDescriptions = hasDescriptions || doc.Description != ""
+	}
+	if !hasDescriptions {
+		headers = headers[:len(headers)-1]
+	}
+	rows := make([][]string, 0, len(docs))
+	for _, doc := range docs {
+		required := "no"
+		if doc.Required {
+			required = "yes"
+		}
+		name, typeName, defaultValue := doc.Name, doc.Type, doc.Default
+		if format == VarDocFormatMarkdown {
+			name, typeName, defaultValue = markdownCode(name), markdownCode(typeName), markdownCode(defaultValue)
+		}
+		row := []string{name, typeName, doc.Format, required, defaultValue, doc.Description}
+		rows = append(rows, row[:len(headers)])
+	}
+
+	var lines []string
+	if format == VarDocFormatText {
+		lines = textTableRows(append([][]string{headers}, rows...))
+	} else {
+		lines = []string{markdownTableRow(headers), "|" + strings.Repeat(" --- |", len(headers))}
+		for _, row := range rows {
+			lines = append(lines, markdownTableRow(row))
+		}
+	}
+	_, err := io.WriteString(w, strings.Join(lines, "\n")+"\n")
+	return err
+}
+
+func textTableRows(rows [][]string) []string {
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+	lines := make([]string, 0, len(rows))
+	for _, row := range rows {
+		var b strings.Builder
+		for i, cell := range row {
+			b.WriteString(cell)
+			b.WriteString(strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell)+2))
+		}
+		lines = append(lines, strings.TrimRight(b.String(), " "))
+	}
+	return lines
+}
+
+func markdownCode(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "`" + s + "`"
+}
+
+func markdownTableRow(cells []string) string {
+	escaped := make([]string, len(cells))
+	for i, cell := range cells {
+		escaped[i] = strings.ReplaceAll(cell, "|", `\|`)
+	}
+	return "| " + strings.Join(escaped, " | ") + " |"
+}
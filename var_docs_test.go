@@ -0,0 +1,185 @@
+package configtypes
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testStructForDocs struct {
+	Port     OptIntGreaterThanZero `conf:"PORT,required,desc=port for the HTTP server, if any"`
+	Timeout  OptDuration           `conf:"TIMEOUT"`
+	Debug    bool                  `conf:"DEBUG"`
+	Limit    *int                  `conf:"LIMIT"`
+	Untagged string
+	Nested   testStructForDocsNested
+	Pointer  *testStructForDocsNested
+	NilPtr   *testStructForDocsNested
+}
+
+type testStructForDocsNested struct {
+	URL OptURLAbsolute `conf:"URL|PIPE"`
+}
+
+func TestDescribeVars(t *testing.T) {
+	makeStruct := func() testStructForDocs {
+		limit := 3
+		return testStructForDocs{
+			Timeout: NewOptDuration(90000000000),
+			Limit:   &limit,
+			Pointer: &testStructForDocsNested{},
+		}
+	}
+
+	t.Run("recursive", func(t *testing.T) {
+		s := makeStruct()
+		docs, err := DescribeVars(&s, true, VarDocOptions{Prefix: "APP_", Suffix: "_X"})
+		require.NoError(t, err)
+		assert.Equal(t, []VarDoc{
+			{
+				Name: "APP_PORT_X", Path: NewValidationPath("Port"), Type: "configtypes.OptIntGreaterThanZero",
+				Format: "integer greater than zero", Required: true, Description: "port for the HTTP server, if any",
+			},
+			{
+				Name: "APP_TIMEOUT_X", Path: NewValidationPath("Timeout"), Type: "configtypes.OptDuration",
+				Format: "duration like 1m30s", Default: "1m30s",
+			},
+			{
+				Name: "APP_DEBUG_X", Path: NewValidationPath("Debug"), Type: "bool",
+				Format: "boolean (true/false, yes/no, or 1/0)",
+			},
+			{Name: "APP_LIMIT_X", Path: NewValidationPath("Limit"), Type: "*int", Format: "integer", Default: "3"},
+			{
+				Name: "APP_URL|PIPE_X", Path: NewValidationPath("Nested", "URL"), Type: "configtypes.OptURLAbsolute",
+				Format: "absolute URL",
+			},
+			{
+				Name: "APP_URL|PIPE_X", Path: NewValidationPath("Pointer", "URL"), Type: "configtypes.OptURLAbsolute",
+				Format: "absolute URL",
+			},
+		}, docs)
+	})
+
+	t.Run("non-recursive", func(t *testing.T) {
+		docs, err := DescribeVars(makeStruct(), false, VarDocOptions{})
+		require.NoError(t, err)
+		var names []string
+		for _, d := range docs {
+			names = append(names, d.Name)
+		}
+		assert.Equal(t, []string{"PORT", "TIMEOUT", "DEBUG", "LIMIT"}, names)
+	})
+
+	t.Run("self-reference", func(t *testing.T) {
+		s := testStructWithSelfReference{}
+		s.Next = &s
+		docs, err := DescribeVars(&s, true, VarDocOptions{})
+		require.NoError(t, err)
+		require.Len(t, docs, 2) // same as ReadStruct, which reads into s.F1 and s.Next.F1
+		assert.Equal(t, NewValidationPath("Next", "F1"), docs[1].Path)
+	})
+
+	t.Run("secrets", func(t *testing.T) {
+		s := struct {
+			Key      ReqSecret `conf:"KEY"`
+			Password OptSecret `conf:"PASSWORD"`
+			Token    string    `conf:"TOKEN,secret"`
+		}{Password: NewOptSecret(testLongSecret), Token: testShortSecret}
+		docs, err := DescribeVars(&s, false, VarDocOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, []VarDoc{
+			{
+				Name: "KEY", Path: NewValidationPath("Key"), Type: "configtypes.ReqSecret",
+				Format: "non-empty secret string", Required: true,
+			},
+			{
+				Name: "PASSWORD", Path: NewValidationPath("Password"), Type: "configtypes.OptSecret",
+				Format: "secret string", Default: "****abcd",
+			},
+			{Name: "TOKEN", Path: NewValidationPath("Token"), Type: "string", Format: "string", Default: "****"},
+		}, docs)
+	})
+
+	t.Run("integer types", func(t *testing.T) {
+		var s struct {
+			Count    OptInt64                 `conf:"COUNT"`
+			MaxBytes OptUint64GreaterThanZero `conf:"MAX_BYTES"`
+			Workers  OptUint                  `conf:"WORKERS"`
+		}
+		s.Count = NewOptInt64(-1)
+		docs, err := DescribeVars(s, true, VarDocOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, []VarDoc{
+			{
+				Name: "COUNT", Path: NewValidationPath("Count"), Type: "configtypes.OptInt64",
+				Format: "integer", Default: "-1",
+			},
+			{
+				Name: "MAX_BYTES", Path: NewValidationPath("MaxBytes"), Type: "configtypes.OptUint64GreaterThanZero",
+				Format: "integer greater than zero",
+			},
+			{
+				Name: "WORKERS", Path: NewValidationPath("Workers"), Type: "configtypes.OptUint",
+				Format: "non-negative integer",
+			},
+		}, docs)
+	})
+
+	t.Run("bad tag", func(t *testing.T) {
+		_, err := DescribeVars(testStructWithBadTag{}, true, VarDocOptions{})
+		assert.Equal(t, ValidationError{
+			Path: NewValidationPath("F1"),
+			Err:  errors.New(`unrecognized field tag option "whatever"`),
+		}, err)
+	})
+
+	t.Run("not a struct", func(t *testing.T) {
+		_, err := DescribeVars(3, true, VarDocOptions{})
+		assert.Error(t, err)
+	})
+}
+
+func TestWriteVarDocs(t *testing.T) {
+	s := testStructForDocs{Timeout: NewOptDuration(90000000000)}
+	docs, err := DescribeVars(&s, true, VarDocOptions{})
+	require.NoError(t, err)
+
+	t.Run("Markdown", func(t *testing.T) {
+		var b strings.Builder
+		require.NoError(t, WriteVarDocs(&b, docs[:2], VarDocFormatMarkdown))
+		assert.Equal(t, strings.Join([]string{
+			"| Variable | Type | Format | Required | Default | Description |",
+			"| --- | --- | --- | --- | --- | --- |",
+			"| `PORT` | `configtypes.OptIntGreaterThanZero` | integer greater than zero | yes |  | " +
+				"port for the HTTP server, if any |",
+			"| `TIMEOUT` | `configtypes.OptDuration` | duration like 1m30s | no | `1m30s` |  |",
+			"",
+		}, "\n"), b.String())
+	})
+
+	t.Run("Markdown escapes pipes and omits empty description column", func(t *testing.T) {
+		var b strings.Builder
+		require.NoError(t, WriteVarDocs(&b, docs[4:], VarDocFormatMarkdown))
+		assert.Equal(t, strings.Join([]string{
+			"| Variable | Type | Format | Required | Default |",
+			"| --- | --- | --- | --- | --- |",
+			"| `URL\\|PIPE` | `configtypes.OptURLAbsolute` | absolute URL | no |  |",
+			"",
+		}, "\n"), b.String())
+	})
+
+	t.Run("text", func(t *testing.T) {
+		var b strings.Builder
+		require.NoError(t, WriteVarDocs(&b, docs[1:4], VarDocFormatText))
+		assert.Equal(t, strings.Join([]string{
+			"Variable  Type                     Format                                Required  Default",
+			"TIMEOUT   configtypes.OptDuration  duration like 1m30s                   no        1m30s",
+			"DEBUG     bool                     boolean (true/false, yes/no, or 1/0)  no",
+			"LIMIT     *int                     integer                               no",
+			"",
+		}, "\n"), b.String())
+	})
+}
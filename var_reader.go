@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"reflect"
+	"sort"
 	"strings"
 )
 
@@ -19,15 +20,33 @@ import (
 // You may specify the variable name for each target value programmatically, or use struct field
 // tags as described in ReadStruct(), or both.
 type VarReader struct {
-	values map[string]string
-	result *ValidationResult
-	prefix string
-	suffix string
+	values         map[string]string
+	result         *ValidationResult
+	provenance     *[]VarProvenance
+	prefix         string
+	suffix         string
+	source         string
+	varSources     map[string]string   // for NewVarReaderFromSources, the source name of each variable
+	alternateNames map[string]string   // for NewVarReaderFromSources; see alternateNamesVarSource
+	repeatedValues map[string][]string // for NewVarReaderFromSources; see repeatedValuesVarSource
+	secret         bool
+}
+
+// VarProvenance describes a variable that was found by a VarReader. See VarReader.Provenance.
+type VarProvenance struct {
+	// VarName is the variable name, including any prefix or suffix.
+	VarName string
+	// Source is the source name of the VarReader, if any; see WithSourceName.
+	Source string
+	// Value is the value of the variable, or a redacted form of it if it is a secret.
+	Value string
 }
 
 // NewVarReaderFromEnvironment creates a VarReader that reads from environment variables.
+//
+// Errors recorded by this VarReader have a Source of "environment".
 func NewVarReaderFromEnvironment() *VarReader {
-	r := &VarReader{result: new(ValidationResult)}
+	r := &VarReader{result: new(ValidationResult), provenance: new([]VarProvenance), source: "environment"}
 	vars := os.Environ()
 	r.values = make(map[string]string, len(vars))
 	for _, s := range vars {
@@ -39,7 +58,7 @@ func NewVarReaderFromEnvironment() *VarReader {
 
 // NewVarReaderFromValues creates a VarReader that reads from the specified name-value map.
 func NewVarReaderFromValues(values map[string]string) *VarReader {
-	r := &VarReader{result: new(ValidationResult)}
+	r := &VarReader{result: new(ValidationResult), provenance: new([]VarProvenance)}
 	r.values = make(map[string]string, len(values))
 	for k, v := range values {
 		r.values[k] = v
@@ -52,6 +71,17 @@ func (r VarReader) Result() ValidationResult {
 	return *r.result
 }
 
+// Provenance returns a description of every variable that has been found so far by this VarReader,
+// or by any other VarReader that was derived from it with a method such as WithVarNamePrefix, in the
+// order they were read. This can be used to show where each configuration value came from.
+//
+// The value of a variable is redacted, as described for OptSecret, if it was read into a type that
+// implements SecretValue, or into a struct field whose tag has the "secret" option, or if it was read
+// by a VarReader returned by WithSecretValues.
+func (r VarReader) Provenance() []VarProvenance {
+	return append([]VarProvenance(nil), *r.provenance...)
+}
+
 // Read attempts to read an environment variable into a target value.
 //
 // The varName may be modified by any previous calls to WithVarNamePrefix or WithVarNameSuffix.
@@ -68,31 +98,52 @@ func (r VarReader) Result() ValidationResult {
 // The method returns true if the variable was found (regardless of whether unmarshaling succeeded)
 // or false if it was not found.
 func (r *VarReader) Read(varName string, target interface{}) bool {
-	return r.readInternal(varName, target, false)
+	return r.readInternal(varName, target, false, false)
 }
 
 // ReadRequired is the same as Read, except that if the variable was not found, it records an
 // error for that variable name.
 func (r *VarReader) ReadRequired(varName string, target interface{}) bool {
-	return r.readInternal(varName, target, true)
+	return r.readInternal(varName, target, true, false)
 }
 
-func (r *VarReader) readInternal(varName string, target interface{}, required bool) bool {
+func (r *VarReader) readInternal(varName string, target interface{}, required, secret bool) bool {
 	setter := setterForTarget(target)
 	if setter == nil {
-		r.AddError(ValidationPath{varName}, varReaderBadTargetTypeError(target))
+		r.AddError(NewValidationPath(varName), varReaderBadTargetTypeError(target))
 		return false
 	}
 	s, ok := r.get(varName)
 	if !ok {
 		if required {
-			r.AddError(ValidationPath{varName}, errRequired())
+			r.AddError(NewValidationPath(varName), errRequired())
 		}
 		return false
 	}
+	if values, ok := r.repeatedValues[r.prefix+varName+r.suffix]; ok {
+		if _, isList := target.(*OptStringList); !isList {
+			s = values[len(values)-1]
+		}
+	}
+	if _, ok := target.(SecretValue); ok || r.secret {
+		secret = true
+	}
+	if source, ok := r.varSources[r.prefix+varName+r.suffix]; ok {
+		sr := *r
+		sr.source = source
+		r = &sr
+	}
+	provenance := VarProvenance{VarName: r.prefix + varName + r.suffix, Source: r.source, Value: s}
+	if secret {
+		provenance.Value = redactSecret(s)
+	}
+	*r.provenance = append(*r.provenance, provenance)
 	err := setter([]byte(s))
 	if err != nil {
-		r.AddError(ValidationPath{varName}, err)
+		if secret {
+			err = redactSecretInError(err, s)
+		}
+		r.AddError(NewValidationPath(varName), err)
 	}
 	return true
 }
@@ -115,6 +166,14 @@ func (r *VarReader) readInternal(varName string, target interface{}, required bo
 // undefined (VAR1 was not set), true, or false. MyPrimitiveBool is a simple bool so there is no way
 // to distinguish between its default value and "not set". MyRequiredBool is a simple bool but will
 // cause VarReader to log an error if the variable is not set.
+//
+// A field tag can also have a "secret" option, such as `conf:"VAR1,secret"`, which means that the
+// value will be redacted in error messages and in Provenance. This is automatic for types that
+// implement SecretValue, such as OptSecret. Types that implement RequiredValue, such as ReqSecret, are
+// always treated as if they had the "required" option.
+//
+// A field tag can also end with a "desc=" option, such as `conf:"VAR1,desc=enables the thing"`.
+// This is ignored by ReadStruct, but is used by DescribeVars.
 func (r *VarReader) ReadStruct(target interface{}, recursive bool) {
 	ok := r.readStructFields(target, recursive)
 	if !ok {
@@ -127,36 +186,46 @@ func (r *VarReader) readStructFields(target interface{}, recursive bool) bool {
 	if !ok {
 		return false
 	}
+	var visited visitSet
+	r.readFields(refStruct, recursive, &visited)
+	return true
+}
 
-	structType := refStruct.Type()
-	for i := 0; i < structType.NumField(); i++ {
-		fieldInType := structType.Field(i)
-		if !isFieldExported(fieldInType) {
-			continue
-		}
-		tagInfo, err := getFieldTagInfo(fieldInType)
-		if err != nil {
-			r.AddError(ValidationPath{fieldInType.Name}, err)
+func (r *VarReader) readFields(refStruct reflect.Value, recursive bool, visited *visitSet) {
+	for _, field := range getStructPlan(refStruct.Type()).fields {
+		if field.tagErr != nil {
+			r.AddError(NewValidationPath(field.name), field.tagErr)
 			continue
 		}
-		fieldInInstancePtr := refStruct.FieldByName(fieldInType.Name)
-		if fieldInInstancePtr.Kind() != reflect.Ptr {
-			fieldInInstancePtr = fieldInInstancePtr.Addr()
-		}
-		fieldValuePtr := fieldInInstancePtr.Interface()
+		fieldInInstance := refStruct.Field(field.index)
 		switch {
-		case tagInfo.varName == "":
-			if recursive {
-				r.readStructFields(fieldValuePtr, true) // harmless if this isn't a struct
+		case field.tagInfo.varName == "":
+			if recursive && field.kind == fieldKindStruct {
+				_ = walkNestedStruct(fieldInInstance, visited, func(refStruct reflect.Value) error {
+					r.readFields(refStruct, true, visited)
+					return nil
+				})
 			}
-		case tagInfo.required:
-			r.ReadRequired(tagInfo.varName, fieldValuePtr)
+		case fieldInInstance.Kind() == reflect.Ptr:
+			r.readIntoPointerField(field.tagInfo, fieldInInstance)
 		default:
-			r.Read(tagInfo.varName, fieldValuePtr)
+			r.readInternal(field.tagInfo.varName, fieldInInstance.Addr().Interface(),
+				field.tagInfo.required, field.tagInfo.secret)
 		}
 	}
+}
 
-	return true
+// readIntoPointerField reads into the value that a pointer field refers to. If the pointer is nil,
+// a new value is allocated, and the field is only set to point to it if the variable was found.
+func (r *VarReader) readIntoPointerField(tagInfo fieldTagInfo, fieldInInstance reflect.Value) {
+	if !fieldInInstance.IsNil() {
+		r.readInternal(tagInfo.varName, fieldInInstance.Interface(), tagInfo.required, tagInfo.secret)
+		return
+	}
+	newValue := reflect.New(fieldInInstance.Type().Elem())
+	if r.readInternal(tagInfo.varName, newValue.Interface(), tagInfo.required, tagInfo.secret) {
+		fieldInInstance.Set(newValue)
+	}
 }
 
 // WithVarNamePrefix returns a new VarReader based on the current one, which accumulates errors
@@ -166,12 +235,9 @@ func (r *VarReader) readStructFields(target interface{}, recursive bool) bool {
 //	r1 := r.WithVarNamePrefix("b_")
 //	r1.Read(&x, "x")  // x is set to "2"
 func (r *VarReader) WithVarNamePrefix(prefix string) *VarReader {
-	return &VarReader{
-		values: r.values,
-		result: r.result,
-		prefix: prefix + r.prefix,
-		suffix: r.suffix,
-	}
+	ret := *r
+	ret.prefix = prefix + r.prefix
+	return &ret
 }
 
 // WithVarNameSuffix returns a new VarReader based on the current one, which accumulates errors
@@ -181,12 +247,30 @@ func (r *VarReader) WithVarNamePrefix(prefix string) *VarReader {
 //	r1 := r.WithVarNameSuffix("_x")
 //	r1.Read(&b, "b")  // b is set to "3"
 func (r *VarReader) WithVarNameSuffix(suffix string) *VarReader {
-	return &VarReader{
-		values: r.values,
-		result: r.result,
-		prefix: r.prefix,
-		suffix: r.suffix + suffix,
-	}
+	ret := *r
+	ret.suffix = r.suffix + suffix
+	return &ret
+}
+
+// WithSourceName returns a new VarReader based on the current one, which accumulates errors in the
+// same ValidationResult, but sets the Source of each error to the given description.
+//
+//	r := NewVarReaderFromValues(valuesFromFile).WithSourceName("settings.env")
+func (r *VarReader) WithSourceName(source string) *VarReader {
+	ret := *r
+	ret.source = source
+	return &ret
+}
+
+// WithSecretValues returns a new VarReader based on the current one, which accumulates errors in the
+// same ValidationResult, but treats every value that it reads as a secret. This has the same effect
+// as the "secret" field tag option: the value is redacted in error messages and in Provenance.
+//
+//	r.WithSecretValues().Read("DB_PASSWORD", &password)
+func (r *VarReader) WithSecretValues() *VarReader {
+	ret := *r
+	ret.secret = true
+	return &ret
 }
 
 // FindPrefixedValues finds all named values in the VarReader that have the specified name prefix,
@@ -206,8 +290,45 @@ func (r *VarReader) FindPrefixedValues(prefix string) map[string]string {
 }
 
 // AddError records an error in the VarReader's result.
+//
+// If the VarReader has a source name, the error's Source is set to that name.
 func (r *VarReader) AddError(path ValidationPath, e error) {
-	r.result.AddError(r.transformPath(path), e)
+	r.result.Add(ValidationError{Path: r.transformPath(path), Err: e, Source: r.source})
+}
+
+// AddUnknownVarErrors records an error for every variable from the specified source that has not been
+// found by any Read method of this VarReader, or of any other VarReader derived from it. The source
+// name is the Name of a VarSource, if the VarReader was created with NewVarReaderFromSources, or
+// otherwise the VarReader's own source name. Call this after reading all of the variables that the
+// application uses, to detect misspelled variable names; this is especially useful with
+// CommandLineVarSource, since an unrecognized command-line flag is usually a mistake.
+//
+//	r, err := configtypes.NewVarReaderFromSources(configtypes.EnvironmentVarSource(),
+//	    configtypes.CommandLineVarSource(os.Args[1:]))
+//	if err != nil { ... }
+//	r.ReadStruct(&config, true)
+//	r.AddUnknownVarErrors("command line")
+//
+// The errors have the variable name as their Path, and are in alphabetical order.
+func (r *VarReader) AddUnknownVarErrors(sourceName string) {
+	found := make(map[string]bool, len(*r.provenance))
+	for _, p := range *r.provenance {
+		found[p.VarName] = true
+	}
+	names := make([]string, 0, len(r.values))
+	for name := range r.values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		source, ok := r.varSources[name]
+		if !ok {
+			source = r.source
+		}
+		if source == sourceName && !found[name] && !found[r.alternateNames[name]] {
+			r.result.Add(ValidationError{Path: NewValidationPath(name), Err: errUnknownVar(), Source: source})
+		}
+	}
 }
 
 func (r VarReader) get(varName string) (string, bool) {
@@ -216,15 +337,31 @@ func (r VarReader) get(varName string) (string, bool) {
 }
 
 func (r VarReader) transformPath(path ValidationPath) ValidationPath {
-	if r.prefix == "" && r.suffix == "" {
+	return addVarNamePrefixAndSuffix(path, r.prefix, r.suffix)
+}
+
+// addVarNamePrefixAndSuffix adds a prefix and suffix to the last element of a path, which is a
+// variable name.
+func addVarNamePrefixAndSuffix(path ValidationPath, prefix, suffix string) ValidationPath {
+	if prefix == "" && suffix == "" {
 		return path
 	}
 	ret := make(ValidationPath, len(path))
 	copy(ret, path)
-	ret[len(ret)-1] = r.prefix + ret[len(ret)-1] + r.suffix
+	ret[len(ret)-1] = PathField(prefix + ret[len(ret)-1].String() + suffix)
 	return ret
 }
 
+// redactSecretInError returns an error that does not reveal a secret value. A parser's error message
+// may quote the value in a form that cannot be reliably found and replaced, so if the message contains
+// the value at all, it is replaced by a fixed message that only includes the redacted form.
+func redactSecretInError(err error, value string) error {
+	if value == "" || !strings.Contains(err.Error(), value) {
+		return err
+	}
+	return errSecretNotValid(redactSecret(value))
+}
+
 func parseVar(s string) (string, string) {
 	p := strings.Index(s, "=")
 	return s[:p], s[p+1:]
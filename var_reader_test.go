@@ -2,11 +2,14 @@ package configtypes
 
 import (
 	"errors"
+	"fmt"
+	"math"
 	"os"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestVarReader(t *testing.T) {
@@ -25,6 +28,19 @@ func TestVarReader(t *testing.T) {
 		})
 	})
 
+	t.Run("errors from environment have a source", func(t *testing.T) {
+		withCleanEnvVars(func() {
+			os.Setenv("NAME", "x")
+			r := NewVarReaderFromEnvironment()
+
+			var n int
+			r.Read("NAME", &n)
+			assert.Equal(t, []ValidationError{
+				{Path: NewValidationPath("NAME"), Err: errIntFormat(), Source: "environment"},
+			}, r.Result().Errors())
+		})
+	})
+
 	t.Run("reads into TextUnmarshaler", func(t *testing.T) {
 		r := NewVarReaderFromValues(map[string]string{"NAME": "value"})
 		var v mockTextUnmarshaler
@@ -41,7 +57,25 @@ func TestVarReader(t *testing.T) {
 		v.err = errors.New("sorry")
 		assert.True(t, r.Read("NAME", &v))
 
-		assert.Equal(t, ValidationError{Path: ValidationPath{"NAME"}, Err: v.err}, r.Result().GetError())
+		assert.Equal(t, ValidationError{Path: NewValidationPath("NAME"), Err: v.err}, r.Result().GetError())
+	})
+
+	t.Run("reads 64-bit and unsigned integer types exactly", func(t *testing.T) {
+		var s struct {
+			Offset   OptInt64  `conf:"OFFSET"`
+			MaxBytes OptUint64 `conf:"MAX_BYTES"`
+			Workers  OptUint   `conf:"WORKERS"`
+		}
+		r := NewVarReaderFromValues(map[string]string{
+			"OFFSET": "-9223372036854775808", "MAX_BYTES": "18446744073709551615", "WORKERS": "-1",
+		})
+		r.ReadStruct(&s, false)
+
+		assert.Equal(t, NewOptInt64(math.MinInt64), s.Offset)
+		assert.Equal(t, NewOptUint64(math.MaxUint64), s.MaxBytes)
+		assert.Equal(t, []ValidationError{
+			{Path: NewValidationPath("WORKERS"), Err: errIntOutOfRange(0, math.MaxUint)},
+		}, r.Result().Errors())
 	})
 
 	t.Run("reads into simple types", func(t *testing.T) {
@@ -71,9 +105,9 @@ func TestVarReader(t *testing.T) {
 		assert.Equal(t, "x", s)
 		assert.Equal(t,
 			[]ValidationError{
-				{Path: ValidationPath{"BAD_BOOL"}, Err: errBoolFormat()},
-				{Path: ValidationPath{"BAD_INT"}, Err: errIntFormat()},
-				{Path: ValidationPath{"BAD_FLOAT"}, Err: errFloatFormat()},
+				{Path: NewValidationPath("BAD_BOOL"), Err: errBoolFormat()},
+				{Path: NewValidationPath("BAD_INT"), Err: errIntFormat()},
+				{Path: NewValidationPath("BAD_FLOAT"), Err: errFloatFormat()},
 			},
 			r.Result().Errors(),
 		)
@@ -86,8 +120,8 @@ func TestVarReader(t *testing.T) {
 		assert.False(t, r.Read("NAME", &f))
 		assert.False(t, r.Read("NAME", &s))
 		assert.Equal(t, []ValidationError{
-			{ValidationPath{"NAME"}, varReaderBadTargetTypeError(&f)},
-			{ValidationPath{"NAME"}, varReaderBadTargetTypeError(&s)},
+			{Path: NewValidationPath("NAME"), Err: varReaderBadTargetTypeError(&f)},
+			{Path: NewValidationPath("NAME"), Err: varReaderBadTargetTypeError(&s)},
 		}, r.Result().Errors())
 	})
 
@@ -106,7 +140,7 @@ func TestVarReader(t *testing.T) {
 		assert.False(t, r.ReadRequired("UNKNOWN", &v2))
 
 		assert.Equal(t, []ValidationError{
-			{ValidationPath{"UNKNOWN"}, errRequired()},
+			{Path: NewValidationPath("UNKNOWN"), Err: errRequired()},
 		}, r.Result().Errors())
 	})
 
@@ -118,7 +152,7 @@ func TestVarReader(t *testing.T) {
 		assert.True(t, r.Read("NAME1", &v1))
 		assert.True(t, r.Read("NAME2", &v2))
 
-		assert.Equal(t, []ValidationError{{ValidationPath{"NAME1"}, v1.err}, {ValidationPath{"NAME2"}, v2.err}},
+		assert.Equal(t, []ValidationError{{Path: NewValidationPath("NAME1"), Err: v1.err}, {Path: NewValidationPath("NAME2"), Err: v2.err}},
 			r.Result().Errors())
 	})
 
@@ -133,7 +167,7 @@ func TestVarReader(t *testing.T) {
 				s)
 
 			result := r.Result()
-			assert.Equal(t, []ValidationError{{ValidationPath{"BAD_INT_VAR"}, errIntFormat()}}, result.Errors())
+			assert.Equal(t, []ValidationError{{Path: NewValidationPath("BAD_INT_VAR"), Err: errIntFormat()}}, result.Errors())
 		})
 
 		t.Run("enforces requiredness for fields with conf tag", func(t *testing.T) {
@@ -146,7 +180,7 @@ func TestVarReader(t *testing.T) {
 				s)
 
 			result := r.Result()
-			assert.Equal(t, []ValidationError{{ValidationPath{"NOT_SET_VAR1"}, errRequired()}}, result.Errors())
+			assert.Equal(t, []ValidationError{{Path: NewValidationPath("NOT_SET_VAR1"), Err: errRequired()}}, result.Errors())
 		})
 
 		t.Run("logs error for invalid conf tag", func(t *testing.T) {
@@ -184,6 +218,47 @@ func TestVarReader(t *testing.T) {
 			assert.Equal(t, "newF1", s.Nested.F1)
 		})
 
+		t.Run("reads into nested struct pointers when recursive is true", func(t *testing.T) {
+			s := testStructWithNestedPointers{Nested: &testStructWithTags1{F1: "oldF1"}}
+			r := NewVarReaderFromValues(map[string]string{"STRING_VAR": "newF1"})
+			r.ReadStruct(&s, true)
+			assert.NoError(t, r.Result().GetError())
+			assert.Equal(t, "newF1", s.Nested.F1)
+			assert.Nil(t, s.NilNested)
+		})
+
+		t.Run("does not loop forever on a reference cycle", func(t *testing.T) {
+			a := &testStructWithSelfReference{}
+			a.Next = a
+			r := NewVarReaderFromValues(map[string]string{"STRING_VAR": "x"})
+			r.ReadStruct(a, true)
+			assert.NoError(t, r.Result().GetError())
+			assert.Equal(t, "x", a.F1)
+		})
+
+		t.Run("allocates nil pointer field only if variable is found", func(t *testing.T) {
+			var s testStructWithPointerFields
+			r := NewVarReaderFromValues(map[string]string{"INT_VAR": "3", "OPT_VAR": "true"})
+			r.ReadStruct(&s, false)
+			assert.NoError(t, r.Result().GetError())
+			if assert.NotNil(t, s.Int) {
+				assert.Equal(t, 3, *s.Int)
+			}
+			if assert.NotNil(t, s.Opt) {
+				assert.Equal(t, NewOptBool(true), *s.Opt)
+			}
+			assert.Nil(t, s.Missing)
+		})
+
+		t.Run("reads into non-nil pointer field", func(t *testing.T) {
+			n := 1
+			s := testStructWithPointerFields{Int: &n}
+			r := NewVarReaderFromValues(map[string]string{"INT_VAR": "3"})
+			r.ReadStruct(&s, false)
+			assert.NoError(t, r.Result().GetError())
+			assert.Equal(t, 3, n)
+		})
+
 		t.Run("rejects parameter that is not a struct pointer", func(t *testing.T) {
 			var n int
 			r1 := NewVarReaderFromValues(nil)
@@ -202,6 +277,96 @@ func TestVarReader(t *testing.T) {
 		})
 	})
 
+	t.Run("secrets", func(t *testing.T) {
+		t.Run("reads into OptSecret", func(t *testing.T) {
+			r := NewVarReaderFromValues(map[string]string{"KEY": testLongSecret})
+			var v OptSecret
+			assert.True(t, r.Read("KEY", &v))
+			assert.Equal(t, testLongSecret, v.Reveal())
+			assert.Equal(t, []VarProvenance{{VarName: "KEY", Value: "****abcd"}}, r.Provenance())
+		})
+
+		t.Run("ReqSecret is required in a struct without the required option", func(t *testing.T) {
+			var s struct {
+				Key ReqSecret `conf:"KEY"`
+			}
+			r := NewVarReaderFromValues(nil)
+			r.ReadStruct(&s, false)
+			assert.Equal(t, []ValidationError{
+				{Path: NewValidationPath("KEY"), Err: errRequired()},
+			}, r.Result().Errors())
+		})
+
+		t.Run("type that implements RequiredValue is required", func(t *testing.T) {
+			var s struct {
+				F mockRequiredTextUnmarshaler `conf:"F"`
+			}
+			r := NewVarReaderFromValues(nil)
+			r.ReadStruct(&s, false)
+			assert.Equal(t, []ValidationError{{Path: NewValidationPath("F"), Err: errRequired()}}, r.Result().Errors())
+		})
+
+		t.Run("secret tag option redacts provenance and errors", func(t *testing.T) {
+			var s struct {
+				Port  mockEchoingTextUnmarshaler `conf:"PORT,secret"`
+				Token string                     `conf:"TOKEN,secret"`
+				Name  string                     `conf:"NAME"`
+			}
+			r := NewVarReaderFromValues(map[string]string{
+				"PORT": "not-a-number-123456", "TOKEN": testLongSecret, "NAME": "x",
+			})
+			r.ReadStruct(&s, false)
+			assert.Equal(t, testLongSecret, s.Token)
+			assert.Equal(t, []VarProvenance{
+				{VarName: "PORT", Value: "****3456"},
+				{VarName: "TOKEN", Value: "****abcd"},
+				{VarName: "NAME", Value: "x"},
+			}, r.Provenance())
+			err := r.Result().GetError()
+			require.Error(t, err)
+			assert.Equal(t, []ValidationError{
+				{Path: NewValidationPath("PORT"), Err: errSecretNotValid("****3456")},
+			}, r.Result().Errors())
+		})
+
+		t.Run("secret tag option with a short value that appears in the error message", func(t *testing.T) {
+			var s struct {
+				N OptInt `conf:"N,secret"`
+			}
+			r := NewVarReaderFromValues(map[string]string{"N": "e"})
+			r.ReadStruct(&s, false)
+			assert.Equal(t, []ValidationError{
+				{Path: NewValidationPath("N"), Err: errSecretNotValid("****")},
+			}, r.Result().Errors())
+			assert.Equal(t, "N: not a valid value (****); details are not shown because the value is secret",
+				r.Result().GetError().Error())
+		})
+
+		t.Run("secret tag option keeps an error message that does not contain the value", func(t *testing.T) {
+			var s struct {
+				N OptInt `conf:"N,secret"`
+			}
+			r := NewVarReaderFromValues(map[string]string{"N": "x"})
+			r.ReadStruct(&s, false)
+			assert.Equal(t, []ValidationError{{Path: NewValidationPath("N"), Err: errIntFormat()}}, r.Result().Errors())
+		})
+
+		t.Run("WithSecretValues", func(t *testing.T) {
+			r := NewVarReaderFromValues(map[string]string{"PORT": "secret-value-1"}).WithSourceName("file")
+			var v mockEchoingTextUnmarshaler
+			r.WithSecretValues().Read("PORT", &v)
+			r.Read("PORT", &v)
+			errs := r.Result().Errors()
+			require.Len(t, errs, 2)
+			assert.NotContains(t, errs[0].Error(), "secret-value-1")
+			assert.Contains(t, errs[1].Error(), "secret-value-1")
+			assert.Equal(t, []VarProvenance{
+				{VarName: "PORT", Source: "file", Value: "****ue-1"},
+				{VarName: "PORT", Source: "file", Value: "secret-value-1"},
+			}, r.Provenance())
+		})
+	})
+
 	t.Run("WithVarNamePrefix", func(t *testing.T) {
 		r := NewVarReaderFromValues(map[string]string{"PRE_NAME": "value"})
 		r1 := r.WithVarNamePrefix("PRE_")
@@ -213,7 +378,7 @@ func TestVarReader(t *testing.T) {
 		var n int
 		r1.Read("NAME", &n)
 		assert.Equal(t, []ValidationError{
-			{ValidationPath{"PRE_NAME"}, errIntFormat()},
+			{Path: NewValidationPath("PRE_NAME"), Err: errIntFormat()},
 		}, r.Result().Errors())
 	})
 
@@ -227,7 +392,34 @@ func TestVarReader(t *testing.T) {
 		var n int
 		r1.Read("NAME", &n)
 		assert.Equal(t, []ValidationError{
-			{ValidationPath{"NAME_SUF"}, errIntFormat()},
+			{Path: NewValidationPath("NAME_SUF"), Err: errIntFormat()},
+		}, r.Result().Errors())
+	})
+
+	t.Run("WithSourceName", func(t *testing.T) {
+		r := NewVarReaderFromValues(map[string]string{"NAME": "value"})
+		r1 := r.WithSourceName("settings.env").WithVarNamePrefix("")
+		var n int
+		r1.Read("NAME", &n)
+		r1.ReadRequired("MISSING", &n)
+		assert.Equal(t, []ValidationError{
+			{Path: NewValidationPath("NAME"), Err: errIntFormat(), Source: "settings.env"},
+			{Path: NewValidationPath("MISSING"), Err: errRequired(), Source: "settings.env"},
+		}, r.Result().Errors())
+	})
+
+	t.Run("AddUnknownVarErrors", func(t *testing.T) {
+		r := NewVarReaderFromValues(map[string]string{"PRE_A": "1", "PRE_B": "x", "C": "2", "D": "3"}).
+			WithSourceName("file")
+		var n int
+		r.WithVarNamePrefix("PRE_").Read("A", &n)
+		r.Read("C", &n)
+		r.WithVarNamePrefix("PRE_").Read("B", &n)
+		r.AddUnknownVarErrors("other")
+		r.AddUnknownVarErrors("file")
+		assert.Equal(t, []ValidationError{
+			{Path: NewValidationPath("PRE_B"), Err: errIntFormat(), Source: "file"},
+			{Path: NewValidationPath("D"), Err: errUnknownVar(), Source: "file"},
 		}, r.Result().Errors())
 	})
 
@@ -262,6 +454,22 @@ type testStructWithNestedVars struct {
 	Nested testStructWithTags1
 }
 
+type testStructWithNestedPointers struct {
+	Nested    *testStructWithTags1
+	NilNested *testStructWithTags1
+}
+
+type testStructWithSelfReference struct {
+	F1   string `conf:"STRING_VAR"`
+	Next *testStructWithSelfReference
+}
+
+type testStructWithPointerFields struct {
+	Int     *int     `conf:"INT_VAR"`
+	Opt     *OptBool `conf:"OPT_VAR"`
+	Missing *int     `conf:"MISSING_VAR"`
+}
+
 func withCleanEnvVars(action func()) {
 	oldVars := os.Environ()
 	os.Clearenv()
@@ -290,6 +498,13 @@ func (m *mockTextUnmarshaler) UnmarshalText(data []byte) error {
 	return nil
 }
 
+// mockEchoingTextUnmarshaler always fails with an error that contains the input.
+type mockEchoingTextUnmarshaler struct{}
+
+func (m *mockEchoingTextUnmarshaler) UnmarshalText(data []byte) error {
+	return fmt.Errorf("bad value %q", data)
+}
+
 type mockRequiredTextUnmarshaler struct {
 	value  string
 	inited bool
@@ -0,0 +1,471 @@
+package configtypes
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// VarSource is a source of named string values that a VarReader can read from, such as environment
+// variables or a .env file. See NewVarReaderFromSources.
+type VarSource interface {
+	// Name returns a description of the source, such as a file path. This is used as the Source of
+	// any errors for variables that came from this source.
+	Name() string
+	// Vars returns all of the named values in the source. It is called each time the source is read,
+	// so a source that is backed by a file will see any changes to the file.
+	Vars() (map[string]string, error)
+}
+
+// watchableVarSource is implemented by sources that are backed by files, so that Watcher can watch
+// for changes to those files.
+type watchableVarSource interface {
+	watchPaths() []string
+}
+
+// alternateNamesVarSource is implemented by sources in which a value can stand for either of two
+// variables, so that VarReader.AddUnknownVarErrors does not report one of them as unknown when the
+// other one was read. The map has an entry in each direction for every such pair.
+type alternateNamesVarSource interface {
+	alternateVarNames() map[string]string
+}
+
+// repeatedValuesVarSource is implemented by sources in which a variable can be given more than once.
+// Vars joins the values with commas, so that they are all added to an OptStringList; but a VarReader
+// that reads the variable into any other type uses only the last value. The map has the separate
+// values of every variable that was given more than once.
+type repeatedValuesVarSource interface {
+	repeatedVarValues() map[string][]string
+}
+
+// NewVarReaderFromSources creates a VarReader that reads from a combination of sources. If the same
+// variable exists in more than one source, the value from the last source in the list is used.
+//
+// Errors recorded by this VarReader, and the entries returned by Provenance, have a Source that is
+// the Name of the source that the variable came from. A variable that was not found in any source,
+// such as a missing required variable, has no Source.
+//
+// An error is returned if any of the sources cannot be read.
+func NewVarReaderFromSources(sources ...VarSource) (*VarReader, error) {
+	r := &VarReader{
+		values:     make(map[string]string),
+		varSources: make(map[string]string),
+		result:     new(ValidationResult),
+		provenance: new([]VarProvenance),
+	}
+	for _, source := range sources {
+		values, err := source.Vars()
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range values {
+			r.values[k] = v
+			r.varSources[k] = source.Name()
+			delete(r.repeatedValues, k)
+		}
+		if as, ok := source.(alternateNamesVarSource); ok {
+			for k, v := range as.alternateVarNames() {
+				if r.alternateNames == nil {
+					r.alternateNames = make(map[string]string)
+				}
+				r.alternateNames[k] = v
+			}
+		}
+		if rs, ok := source.(repeatedValuesVarSource); ok {
+			for k, v := range rs.repeatedVarValues() {
+				if r.repeatedValues == nil {
+					r.repeatedValues = make(map[string][]string)
+				}
+				r.repeatedValues[k] = v
+			}
+		}
+	}
+	return r, nil
+}
+
+type environmentVarSource struct{}
+
+// EnvironmentVarSource returns a VarSource for environment variables. Its name is "environment".
+func EnvironmentVarSource() VarSource {
+	return environmentVarSource{}
+}
+
+func (s environmentVarSource) Name() string {
+	return "environment"
+}
+
+func (s environmentVarSource) Vars() (map[string]string, error) {
+	vars := os.Environ()
+	ret := make(map[string]string, len(vars))
+	for _, v := range vars {
+		k, v := parseVar(v)
+		ret[k] = v
+	}
+	return ret, nil
+}
+
+type dotEnvFileVarSource struct {
+	path string
+}
+
+// DotEnvFileVarSource returns a VarSource that reads a file in the .env format. Its name is the file
+// path.
+//
+// Each line of the file is either blank, a comment beginning with "#", or "NAME=value", optionally
+// preceded by "export ". A value may be unquoted, in which case surrounding whitespace and any
+// comment beginning with " #" are removed; or in single quotes, in which case it is used literally;
+// or in double quotes, in which case the backslash escapes \n, \r, \t, \", \\, \$, and \` are
+// recognized. This is compatible with the output of VarWriter.WriteDotEnv.
+func DotEnvFileVarSource(path string) VarSource {
+	return dotEnvFileVarSource{path: path}
+}
+
+func (s dotEnvFileVarSource) Name() string {
+	return s.path
+}
+
+func (s dotEnvFileVarSource) Vars() (map[string]string, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, err
+	}
+	values, err := parseDotEnv(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", s.path, err)
+	}
+	return values, nil
+}
+
+func (s dotEnvFileVarSource) watchPaths() []string {
+	return []string{s.path}
+}
+
+type directoryVarSource struct {
+	path string
+}
+
+// DirectoryVarSource returns a VarSource that reads a directory in which each file represents one
+// variable: the file name is the variable name, and the file content is the value, with any single
+// trailing newline removed. Its name is the directory path.
+//
+// Subdirectories, and files whose names begin with ".", are ignored. This is compatible with the way
+// Kubernetes and Docker make secrets and configuration maps available as files.
+func DirectoryVarSource(path string) VarSource {
+	return directoryVarSource{path: path}
+}
+
+func (s directoryVarSource) Name() string {
+	return s.path
+}
+
+func (s directoryVarSource) Vars() (map[string]string, error) {
+	entries, err := os.ReadDir(s.path)
+	if err != nil {
+		return nil, err
+	}
+	ret := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		filePath := filepath.Join(s.path, entry.Name())
+		info, err := os.Stat(filePath) // follows symlinks, unlike entry.Info
+		if err != nil {
+			return nil, err
+		}
+		if !info.Mode().IsRegular() {
+			continue
+		}
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			return nil, err
+		}
+		value := strings.TrimSuffix(string(data), "\n")
+		ret[entry.Name()] = strings.TrimSuffix(value, "\r")
+	}
+	return ret, nil
+}
+
+func (s directoryVarSource) watchPaths() []string {
+	return []string{s.path}
+}
+
+type jsonFileVarSource struct {
+	path string
+}
+
+// JSONFileVarSource returns a VarSource that reads a JSON file containing an object. Each property of
+// the object is a variable. Its name is the file path.
+//
+// A string property value is used as it is. A number or boolean is used in its JSON form, such as
+// "3" or "true". An array of such values is converted to a comma-delimited list, as used by
+// OptStringList. A property whose value is null is ignored. Any other value is an error.
+func JSONFileVarSource(path string) VarSource {
+	return jsonFileVarSource{path: path}
+}
+
+func (s jsonFileVarSource) Name() string {
+	return s.path
+}
+
+func (s jsonFileVarSource) Vars() (map[string]string, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var props map[string]interface{}
+	if err := decoder.Decode(&props); err != nil {
+		return nil, fmt.Errorf("%s: %w", s.path, err)
+	}
+	if props == nil {
+		return nil, fmt.Errorf("%s: JSON value must be an object", s.path)
+	}
+	ret := make(map[string]string, len(props))
+	for name, value := range props {
+		if value == nil {
+			continue
+		}
+		text, ok := jsonVarText(value)
+		if !ok {
+			return nil, fmt.Errorf("%s: property %q must be a string, number, boolean, array, or null", s.path, name)
+		}
+		ret[name] = text
+	}
+	return ret, nil
+}
+
+func (s jsonFileVarSource) watchPaths() []string {
+	return []string{s.path}
+}
+
+type commandLineVarSource struct {
+	args []string
+}
+
+// CommandLineVarSource returns a VarSource that reads command-line arguments, such as os.Args[1:]. Its
+// name is "command line".
+//
+// Each argument that begins with "-" or "--" is a flag, whose name is converted to a variable name in
+// the reverse of the way that BindFlags does it: "--http-read-timeout" is the variable
+// HTTP_READ_TIMEOUT. A flag can have a value in the form "--name=value" or "--name value". A flag that
+// has no value, because it is followed by another flag or is the last argument, has the value "true";
+// this is parsed in the same way as any other boolean value, for instance by OptBool. A value that
+// begins with "-" must use the "--name=value" form.
+//
+// A flag "--no-name" without a value sets both the variable NAME to "false" and the variable NO_NAME
+// to "true", since it could be either the negation of a boolean flag or a flag of its own, such as
+// NO_CACHE. With NewVarReaderFromSources, VarReader.AddUnknownVarErrors reports neither of them as
+// unknown as long as one of them was read.
+//
+// If a flag is repeated, Vars joins its values with commas, which is the format of OptStringList, so
+// "--host a --host b" adds both values to an OptStringList. With NewVarReaderFromSources, a variable
+// that is read into any other type uses only the last value, so "--port 1 --port 2" sets it to 2 and
+// "--verbose --no-verbose" sets it to false.
+//
+// The argument "--" ends the flags, and any arguments after it are ignored, so they can be used by
+// the application for some other purpose. Any other argument that is not a flag or a flag's value
+// causes Vars to return an error.
+//
+// To report flags that do not correspond to any variable that the application reads, use
+// VarReader.AddUnknownVarErrors.
+func CommandLineVarSource(args []string) VarSource {
+	return commandLineVarSource{args: args}
+}
+
+func (s commandLineVarSource) Name() string {
+	return "command line"
+}
+
+func (s commandLineVarSource) Vars() (map[string]string, error) {
+	args, err := s.parse()
+	return args.values, err
+}
+
+func (s commandLineVarSource) alternateVarNames() map[string]string {
+	args, _ := s.parse()
+	return args.negated
+}
+
+func (s commandLineVarSource) repeatedVarValues() map[string][]string {
+	args, _ := s.parse()
+	return args.repeated
+}
+
+// commandLineArgs is the result of commandLineVarSource.parse. The negated map has the pairs of
+// variable names NAME and NO_NAME that were set by "--no-name" flags, with an entry in each direction;
+// the repeated map has the separate values of each variable that was set more than once.
+type commandLineArgs struct {
+	values   map[string]string
+	negated  map[string]string
+	repeated map[string][]string
+}
+
+func (s commandLineVarSource) parse() (commandLineArgs, error) {
+	ret := commandLineArgs{
+		values:   make(map[string]string),
+		negated:  make(map[string]string),
+		repeated: make(map[string][]string),
+	}
+	add := func(varName, value string) {
+		if previous, ok := ret.values[varName]; ok {
+			if _, ok := ret.repeated[varName]; !ok {
+				ret.repeated[varName] = []string{previous}
+			}
+			ret.repeated[varName] = append(ret.repeated[varName], value)
+			value = previous + "," + value
+		}
+		ret.values[varName] = value
+	}
+	for i := 0; i < len(s.args); i++ {
+		arg := s.args[i]
+		if arg == "--" {
+			break
+		}
+		if len(arg) < 2 || arg[0] != '-' {
+			return commandLineArgs{}, fmt.Errorf("%s: unexpected argument %q", s.Name(), arg)
+		}
+		name, value, hasValue := strings.Cut(strings.TrimPrefix(arg[1:], "-"), "=")
+		switch {
+		case name == "" || strings.HasPrefix(name, "-"):
+			return commandLineArgs{}, fmt.Errorf("%s: invalid flag %q", s.Name(), arg)
+		case hasValue:
+		case strings.HasPrefix(name, "no-") && len(name) > len("no-"):
+			negatedName, varName := varNameForFlag(strings.TrimPrefix(name, "no-")), varNameForFlag(name)
+			ret.negated[negatedName], ret.negated[varName] = varName, negatedName
+			add(negatedName, "false")
+			value = "true"
+		case i+1 < len(s.args) && !strings.HasPrefix(s.args[i+1], "-"):
+			i++
+			value = s.args[i]
+		default:
+			value = "true"
+		}
+		add(varNameForFlag(name), value)
+	}
+	return ret, nil
+}
+
+func jsonVarText(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case json.Number:
+		return v.String(), true
+	case bool:
+		return fmt.Sprint(v), true
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			if _, isArray := item.([]interface{}); isArray {
+				return "", false
+			}
+			text, ok := jsonVarText(item)
+			if !ok {
+				return "", false
+			}
+			items = append(items, text)
+		}
+		return strings.Join(items, ","), true
+	}
+	return "", false
+}
+
+// parseDotEnv parses the .env format described for DotEnvFileVarSource.
+func parseDotEnv(data []byte) (map[string]string, error) {
+	ret := make(map[string]string)
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		p := strings.Index(line, "=")
+		if p <= 0 {
+			return nil, fmt.Errorf("line %d: expected NAME=value", i+1)
+		}
+		name := strings.TrimSpace(line[:p])
+		value, err := parseDotEnvValue(strings.TrimSpace(line[p+1:]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		ret[name] = value
+	}
+	return ret, nil
+}
+
+func parseDotEnvValue(s string) (string, error) {
+	switch {
+	case strings.HasPrefix(s, "'"):
+		end := strings.Index(s[1:], "'")
+		if end < 0 {
+			return "", errors.New("unterminated quoted value")
+		}
+		return s[1 : end+1], checkDotEnvTrailingText(s[end+2:])
+	case strings.HasPrefix(s, `"`):
+		var b strings.Builder
+		for i := 1; i < len(s); i++ {
+			switch ch := s[i]; ch {
+			case '"':
+				return b.String(), checkDotEnvTrailingText(s[i+1:])
+			case '\\':
+				if i+1 == len(s) {
+					return "", errors.New("unterminated quoted value")
+				}
+				i++
+				switch s[i] {
+				case 'n':
+					b.WriteByte('\n')
+				case 'r':
+					b.WriteByte('\r')
+				case 't':
+					b.WriteByte('\t')
+				case '"', '\\', '$', '`':
+					b.WriteByte(s[i])
+				default:
+					b.WriteByte('\\')
+					b.WriteByte(s[i])
+				}
+			default:
+				b.WriteByte(ch)
+			}
+		}
+		return "", errors.New("unterminated quoted value")
+	}
+	if p := strings.Index(s, " #"); p >= 0 {
+		s = strings.TrimSpace(s[:p])
+	}
+	return s, nil
+}
+
+func checkDotEnvTrailingText(s string) error {
+	s = strings.TrimSpace(s)
+	if s != "" && !strings.HasPrefix(s, "#") {
+		return errors.New("unexpected text after quoted value")
+	}
+	return nil
+}
+
+// sortedVarSourcePaths returns the distinct paths that should be watched for a set of sources.
+func sortedVarSourcePaths(sources []VarSource) []string {
+	seen := make(map[string]bool)
+	var ret []string
+	for _, source := range sources {
+		if ws, ok := source.(watchableVarSource); ok {
+			for _, path := range ws.watchPaths() {
+				if !seen[path] {
+					seen[path] = true
+					ret = append(ret, path)
+				}
+			}
+		}
+	}
+	sort.Strings(ret)
+	return ret
+}
@@ -0,0 +1,270 @@
+package configtypes
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestEnvironmentVarSource(t *testing.T) {
+	t.Setenv("CONFIGTYPES_TEST_VAR", "a=b")
+	values, err := EnvironmentVarSource().Vars()
+	require.NoError(t, err)
+	assert.Equal(t, "a=b", values["CONFIGTYPES_TEST_VAR"])
+	assert.Equal(t, "environment", EnvironmentVarSource().Name())
+}
+
+func TestDotEnvFileVarSource(t *testing.T) {
+	t.Run("parses values", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "test.env")
+		writeTestFile(t, path, strings.Join([]string{
+			"# comment",
+			"",
+			"PLAIN=value",
+			"  SPACED  =  two words   # comment",
+			"export EXPORTED=1",
+			"EMPTY=",
+			`SINGLE='no \n escapes # here'`,
+			`DOUBLE="line1\nline2 \"quoted\" \$x \\ \q" # comment`,
+			"HASH=a#b",
+		}, "\r\n"))
+		source := DotEnvFileVarSource(path)
+		assert.Equal(t, path, source.Name())
+		values, err := source.Vars()
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"PLAIN":    "value",
+			"SPACED":   "two words",
+			"EXPORTED": "1",
+			"EMPTY":    "",
+			"SINGLE":   `no \n escapes # here`,
+			"DOUBLE":   "line1\nline2 \"quoted\" $x \\ \\q",
+			"HASH":     "a#b",
+		}, values)
+	})
+
+	t.Run("reads the output of VarWriter", func(t *testing.T) {
+		w := NewVarWriter()
+		w.Write("A", "quote\" dollar$ backslash\\ newline\n tab\t hash# backtick` 'single'")
+		w.Write("B", " leading and trailing spaces ")
+		w.Write("C", "")
+		var b strings.Builder
+		require.NoError(t, w.WriteDotEnv(&b))
+		path := filepath.Join(t.TempDir(), "test.env")
+		writeTestFile(t, path, b.String())
+		values, err := DotEnvFileVarSource(path).Vars()
+		require.NoError(t, err)
+		assert.Equal(t, w.Values(), values)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, content := range []string{
+			"NOEQUALS", "=value", `A="unterminated`, `A='unterminated`, `A="x" y`, `A="x\`,
+		} {
+			t.Run(content, func(t *testing.T) {
+				path := filepath.Join(t.TempDir(), "test.env")
+				writeTestFile(t, path, "OK=1\n"+content)
+				_, err := DotEnvFileVarSource(path).Vars()
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), path+": line 2: ")
+			})
+		}
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := DotEnvFileVarSource(filepath.Join(t.TempDir(), "missing")).Vars()
+		assert.True(t, os.IsNotExist(err))
+	})
+}
+
+func TestDirectoryVarSource(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "PLAIN"), "value\n")
+	writeTestFile(t, filepath.Join(dir, "CRLF"), "value\r\n")
+	writeTestFile(t, filepath.Join(dir, "MULTILINE"), "a\nb\n\n")
+	writeTestFile(t, filepath.Join(dir, ".hidden"), "x")
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "subdir"), 0o700))
+	require.NoError(t, os.Symlink(filepath.Join(dir, "PLAIN"), filepath.Join(dir, "LINK")))
+
+	source := DirectoryVarSource(dir)
+	assert.Equal(t, dir, source.Name())
+	values, err := source.Vars()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"PLAIN": "value", "CRLF": "value", "MULTILINE": "a\nb\n", "LINK": "value",
+	}, values)
+
+	_, err = DirectoryVarSource(filepath.Join(dir, "missing")).Vars()
+	assert.Error(t, err)
+}
+
+func TestJSONFileVarSource(t *testing.T) {
+	t.Run("parses values", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "test.json")
+		writeTestFile(t, path, `{"S": "x y", "N": 1.50, "B": true, "L": ["a", 2, false], "EMPTY": [], "NULL": null}`)
+		source := JSONFileVarSource(path)
+		assert.Equal(t, path, source.Name())
+		values, err := source.Vars()
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"S": "x y", "N": "1.50", "B": "true", "L": "a,2,false", "EMPTY": ""}, values)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, content := range []string{`[]`, `null`, `{"A": {}}`, `{"A": [[]]}`, `{"A": [null]}`, `{`} {
+			t.Run(content, func(t *testing.T) {
+				path := filepath.Join(t.TempDir(), "test.json")
+				writeTestFile(t, path, content)
+				_, err := JSONFileVarSource(path).Vars()
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), path)
+			})
+		}
+	})
+}
+
+func TestCommandLineVarSource(t *testing.T) {
+	t.Run("parses flags", func(t *testing.T) {
+		source := CommandLineVarSource([]string{
+			"--http-read-timeout=5s", "-port", "8080", "--debug", "--no-cache", "--hosts", "a", "--hosts=b,c",
+			"--offset=-1", "--empty=", "--no-x=y", "--verbose", "--", "positional", "--ignored",
+		})
+		assert.Equal(t, "command line", source.Name())
+		values, err := source.Vars()
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"HTTP_READ_TIMEOUT": "5s",
+			"PORT":              "8080",
+			"DEBUG":             "true",
+			"CACHE":             "false",
+			"NO_CACHE":          "true",
+			"HOSTS":             "a,b,c",
+			"OFFSET":            "-1",
+			"EMPTY":             "",
+			"NO_X":              "y",
+			"VERBOSE":           "true",
+		}, values)
+	})
+
+	t.Run("negated flag for a variable whose name begins with NO_", func(t *testing.T) {
+		var config struct {
+			NoCache OptBool `conf:"NO_CACHE"`
+		}
+		source := CommandLineVarSource([]string{"--no-cache"})
+		r, err := NewVarReaderFromSources(source)
+		require.NoError(t, err)
+		r.ReadStruct(&config, false)
+		r.AddUnknownVarErrors(source.Name())
+		assert.Equal(t, NewOptBool(true), config.NoCache)
+		assert.True(t, r.Result().OK())
+	})
+
+	t.Run("repeated flags add to a list, and the last value wins for other types", func(t *testing.T) {
+		var config struct {
+			Port    OptInt        `conf:"PORT"`
+			Verbose bool          `conf:"VERBOSE"`
+			Quiet   OptBool       `conf:"QUIET"`
+			Hosts   OptStringList `conf:"HOSTS"`
+		}
+		source := CommandLineVarSource([]string{
+			"--port", "1", "--port=2", "--verbose", "--no-verbose", "--no-quiet", "--quiet",
+			"--hosts", "a", "--hosts=b,c",
+		})
+		r, err := NewVarReaderFromSources(source)
+		require.NoError(t, err)
+		r.ReadStruct(&config, false)
+		require.True(t, r.Result().OK(), r.Result().Errors())
+		assert.Equal(t, NewOptInt(2), config.Port)
+		assert.False(t, config.Verbose)
+		assert.Equal(t, NewOptBool(true), config.Quiet)
+		assert.Equal(t, NewOptStringList([]string{"a", "b", "c"}), config.Hosts)
+	})
+
+	t.Run("a later source replaces all of the repeated values", func(t *testing.T) {
+		dir := t.TempDir()
+		envPath := filepath.Join(dir, "test.env")
+		writeTestFile(t, envPath, "PORT=3\n")
+		var port OptInt
+		r, err := NewVarReaderFromSources(CommandLineVarSource([]string{"--port", "1", "--port", "2"}),
+			DotEnvFileVarSource(envPath))
+		require.NoError(t, err)
+		r.Read("PORT", &port)
+		assert.Equal(t, NewOptInt(3), port)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, arg := range []string{"positional", "-", "---x", "--=x"} {
+			t.Run(arg, func(t *testing.T) {
+				_, err := CommandLineVarSource([]string{"--ok=1", arg}).Vars()
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "command line: ")
+				assert.Contains(t, err.Error(), arg)
+			})
+		}
+	})
+
+	t.Run("reads into a struct with environment variables as a fallback", func(t *testing.T) {
+		t.Setenv("CONFIGTYPES_TEST_PORT", "80")
+		t.Setenv("CONFIGTYPES_TEST_DEBUG", "true")
+		t.Setenv("CONFIGTYPES_TEST_UNUSED", "x")
+		var config struct {
+			Port  int     `conf:"CONFIGTYPES_TEST_PORT"`
+			Debug OptBool `conf:"CONFIGTYPES_TEST_DEBUG"`
+			Limit OptInt  `conf:"CONFIGTYPES_TEST_LIMIT"`
+		}
+		source := CommandLineVarSource([]string{
+			"--configtypes-test-port", "8080", "--no-configtypes-test-debug", "--configtypes-test-limt", "3",
+		})
+		r, err := NewVarReaderFromSources(EnvironmentVarSource(), source)
+		require.NoError(t, err)
+		r.ReadStruct(&config, false)
+		r.AddUnknownVarErrors(source.Name())
+		assert.Equal(t, 8080, config.Port)
+		assert.Equal(t, NewOptBool(false), config.Debug)
+		assert.Equal(t, []ValidationError{
+			{Path: NewValidationPath("CONFIGTYPES_TEST_LIMT"), Err: errUnknownVar(), Source: "command line"},
+		}, r.Result().Errors())
+	})
+}
+
+func TestNewVarReaderFromSources(t *testing.T) {
+	dir := t.TempDir()
+	envPath := filepath.Join(dir, "test.env")
+	writeTestFile(t, envPath, "A=1\nB=2\nC=x\n")
+	jsonPath := filepath.Join(dir, "test.json")
+	writeTestFile(t, jsonPath, `{"B": 3, "D": "y"}`)
+
+	t.Run("later sources take precedence", func(t *testing.T) {
+		r, err := NewVarReaderFromSources(DotEnvFileVarSource(envPath), JSONFileVarSource(jsonPath))
+		require.NoError(t, err)
+		var a, b, c int
+		r.Read("A", &a)
+		r.Read("B", &b)
+		r.Read("C", &c)
+		r.ReadRequired("MISSING", &c)
+		assert.Equal(t, 1, a)
+		assert.Equal(t, 3, b)
+		assert.Equal(t, []VarProvenance{
+			{VarName: "A", Source: envPath, Value: "1"},
+			{VarName: "B", Source: jsonPath, Value: "3"},
+			{VarName: "C", Source: envPath, Value: "x"},
+		}, r.Provenance())
+		assert.Equal(t, []ValidationError{
+			{Path: NewValidationPath("C"), Err: errIntFormat(), Source: envPath},
+			{Path: NewValidationPath("MISSING"), Err: errRequired()},
+		}, r.Result().Errors())
+	})
+
+	t.Run("source error", func(t *testing.T) {
+		_, err := NewVarReaderFromSources(DotEnvFileVarSource(envPath), JSONFileVarSource(envPath))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,300 @@
+package configtypes
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// VarWriter does the reverse of VarReader: it translates values of any supported type into named
+// string values, such as environment variables. It accumulates errors as it goes.
+//
+// The supported types are the same as for VarReader: any type that implements TextMarshaler (which
+// includes all of the Opt types defined in this package), and also the primitive types bool, int,
+// float64, and string.
+//
+// Values are always written in a form that VarReader will read back as an equal value. If that is
+// impossible, VarWriter records an error instead: for instance, an OptStringList cannot be written
+// if any of its values contains a comma, since VarReader uses a comma as the delimiter.
+//
+//	w := NewVarWriter()
+//	w.WriteStruct(&config, true)
+//	if !w.Result().OK() { ... }
+//	cmd.Env = append(os.Environ(), w.Environ()...)
+type VarWriter struct {
+	values        map[string]string
+	result        *ValidationResult
+	prefix        string
+	suffix        string
+	revealSecrets bool
+}
+
+// NewVarWriter creates a VarWriter with no values.
+func NewVarWriter() *VarWriter {
+	return &VarWriter{values: make(map[string]string), result: new(ValidationResult)}
+}
+
+// Result returns a ValidationResult containing all of the errors encountered so far.
+func (w VarWriter) Result() ValidationResult {
+	return *w.result
+}
+
+// Values returns a copy of all of the named values that have been written.
+func (w VarWriter) Values() map[string]string {
+	ret := make(map[string]string, len(w.values))
+	for k, v := range w.values {
+		ret[k] = v
+	}
+	return ret
+}
+
+// Environ returns all of the named values that have been written, as strings in the form
+// "NAME=value" sorted by name. This is the same format as os.Environ.
+func (w VarWriter) Environ() []string {
+	ret := make([]string, 0, len(w.values))
+	for _, name := range w.sortedNames() {
+		ret = append(ret, name+"="+w.values[name])
+	}
+	return ret
+}
+
+// WriteDotEnv writes all of the named values that have been written, in the .env file format, with
+// one "NAME=value" line for each sorted by name. A value is quoted if it contains whitespace or any
+// characters that have a special meaning in that format.
+func (w VarWriter) WriteDotEnv(out io.Writer) error {
+	for _, name := range w.sortedNames() {
+		if _, err := fmt.Fprintf(out, "%s=%s\n", name, dotEnvQuote(w.values[name])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w VarWriter) sortedNames() []string {
+	names := make([]string, 0, len(w.values))
+	for name := range w.values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Write translates a value to a string and stores it with the specified name.
+//
+// The varName may be modified by any previous calls to WithVarNamePrefix or WithVarNameSuffix.
+//
+// An Opt value that is empty, or a nil pointer, is not written. If the value is a non-nil pointer,
+// the value that it points to is written. If a different value was already written with the same
+// name, or if the type is not supported, Write records an error.
+//
+// If the value implements SecretValue, such as OptSecret, Write records an error instead of writing
+// it, unless the VarWriter was returned by WithSecretsRevealed.
+//
+// The method returns true if a value was written, or false if it was not.
+func (w *VarWriter) Write(varName string, value interface{}) bool {
+	return w.writeInternal(varName, value, false)
+}
+
+func (w *VarWriter) writeInternal(varName string, value interface{}, secret bool) bool {
+	refValue := reflect.ValueOf(value)
+	if refValue.Kind() == reflect.Ptr {
+		if refValue.IsNil() {
+			return false
+		}
+		value = refValue.Elem().Interface()
+	}
+	if sv, ok := value.(SingleValue); ok && !sv.IsDefined() {
+		return false
+	}
+	if _, ok := value.(SecretValue); (ok || secret) && !w.revealSecrets {
+		w.AddError(NewValidationPath(varName), errVarWriterSecretNotRevealed())
+		return false
+	}
+	s, err := textForValue(value)
+	if err != nil {
+		w.AddError(NewValidationPath(varName), err)
+		return false
+	}
+	name := w.prefix + varName + w.suffix
+	if existing, ok := w.values[name]; ok && existing != s {
+		w.AddError(NewValidationPath(varName), errVarWriterConflict())
+		return false
+	}
+	w.values[name] = s
+	return true
+}
+
+// WriteStruct uses reflection to write all exported fields of the source struct that have a tag of
+// `conf:"VAR_NAME"`, as described for VarReader.ReadStruct. It does the reverse of ReadStruct: for
+// each such field, it calls Write with the variable name and the field value. The source can be a
+// struct or a struct pointer.
+//
+// If the recursive parameter is true, then WriteStruct will be called recursively on any embedded
+// structs, and on struct pointer fields that are not nil, just as ReadStruct would.
+//
+// Fields that are secrets, either because their type implements SecretValue or because the field tag
+// has the "secret" option, are only written if the VarWriter was returned by WithSecretsRevealed;
+// otherwise an error is recorded for each of them.
+func (w *VarWriter) WriteStruct(source interface{}, recursive bool) {
+	refStruct, ok := getReflectValueForStruct(source)
+	if !ok {
+		w.AddError(nil, errors.New("WriteStruct was called on something other than a struct or struct pointer"))
+		return
+	}
+	var visited visitSet
+	w.writeFields(refStruct, recursive, &visited)
+}
+
+func (w *VarWriter) writeFields(refStruct reflect.Value, recursive bool, visited *visitSet) {
+	for _, field := range getStructPlan(refStruct.Type()).fields {
+		if field.tagErr != nil {
+			w.AddError(NewValidationPath(field.name), field.tagErr)
+			continue
+		}
+		fieldInInstance := refStruct.Field(field.index)
+		switch {
+		case field.tagInfo.varName != "":
+			w.writeInternal(field.tagInfo.varName, fieldInInstance.Interface(), field.tagInfo.secret)
+		case recursive && field.kind == fieldKindStruct:
+			_ = walkNestedStruct(fieldInInstance, visited, func(refStruct reflect.Value) error {
+				w.writeFields(refStruct, true, visited)
+				return nil
+			})
+		}
+	}
+}
+
+// WithVarNamePrefix returns a new VarWriter based on the current one, which stores values and
+// accumulates errors in the same place, but with the given prefix added to all variable names.
+func (w *VarWriter) WithVarNamePrefix(prefix string) *VarWriter {
+	ret := *w
+	ret.prefix = prefix + w.prefix
+	return &ret
+}
+
+// WithVarNameSuffix returns a new VarWriter based on the current one, which stores values and
+// accumulates errors in the same place, but with the given suffix added to all variable names.
+func (w *VarWriter) WithVarNameSuffix(suffix string) *VarWriter {
+	ret := *w
+	ret.suffix = w.suffix + suffix
+	return &ret
+}
+
+// WithSecretsRevealed returns a new VarWriter based on the current one, which stores values and
+// accumulates errors in the same place, but writes the actual values of secrets. For a type that
+// implements SecretValue, the value is obtained with Reveal.
+//
+// This is an explicit opt-in, since the output will contain sensitive values.
+func (w *VarWriter) WithSecretsRevealed() *VarWriter {
+	ret := *w
+	ret.revealSecrets = true
+	return &ret
+}
+
+// AddError records an error in the VarWriter's result.
+func (w *VarWriter) AddError(path ValidationPath, e error) {
+	w.result.Add(ValidationError{Path: addVarNamePrefixAndSuffix(path, w.prefix, w.suffix), Err: e})
+}
+
+// textForValue converts a value to the text that VarReader would parse to get the same value.
+func textForValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64), nil
+	case string:
+		return v, nil
+	case SecretValue:
+		revealed := v.Reveal()
+		// As below, make sure that reading the text back will produce the same value.
+		if !textParsesAsSameValue(value, []byte(revealed)) {
+			return "", errVarWriterCannotRepresent()
+		}
+		return revealed, nil
+	case encoding.TextMarshaler:
+		data, err := v.MarshalText()
+		if err != nil {
+			return "", err
+		}
+		// Make sure that reading the text back will produce the same value. This is not true for
+		// some values, such as an OptStringList whose values contain commas.
+		if !textParsesAsSameValue(value, data) {
+			return "", errVarWriterCannotRepresent()
+		}
+		return string(data), nil
+	}
+	return "", fmt.Errorf("could not write value of type %T", value)
+}
+
+// textParsesAsSameValue returns true if parsing the text with the UnmarshalText method of the value's
+// type produces an equivalent value, or if the type has no such method.
+//
+// Values are not compared with reflect.DeepEqual, since some equivalent values are not identical: an
+// OptTime parsed from text has lost the monotonic clock reading and Location of the original. Instead,
+// secrets are compared by their revealed values, and other values by their text and, if the type has
+// a MarshalJSON method, their JSON representation, which tells apart values such as the OptStringList
+// values ["a,b"] and ["a", "b"] that have the same text.
+func textParsesAsSameValue(value interface{}, text []byte) bool {
+	parsed := reflect.New(reflect.TypeOf(value))
+	tu, ok := parsed.Interface().(encoding.TextUnmarshaler)
+	if !ok {
+		return true
+	}
+	if tu.UnmarshalText(text) != nil {
+		return false
+	}
+	parsedValue := parsed.Elem().Interface()
+	if secret, ok := value.(SecretValue); ok {
+		parsedSecret, ok := parsedValue.(SecretValue)
+		return ok && parsedSecret.Reveal() == secret.Reveal()
+	}
+	if tm, ok := parsedValue.(encoding.TextMarshaler); ok {
+		parsedText, err := tm.MarshalText()
+		if err != nil || !bytes.Equal(parsedText, text) {
+			return false
+		}
+	}
+	if jm, ok := value.(json.Marshaler); ok {
+		data, err := jm.MarshalJSON()
+		parsedData, parsedErr := parsedValue.(json.Marshaler).MarshalJSON()
+		return err == nil && parsedErr == nil && bytes.Equal(data, parsedData)
+	}
+	return true
+}
+
+// dotEnvQuote returns the value as it should appear in a .env file: either unchanged, or in double
+// quotes with backslash escapes.
+func dotEnvQuote(value string) string {
+	if !strings.ContainsAny(value, " \t\r\n\"'`#$\\=") {
+		return value
+	}
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, ch := range value {
+		switch ch {
+		case '"', '\\', '$', '`':
+			b.WriteByte('\\')
+			b.WriteRune(ch)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			b.WriteRune(ch)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
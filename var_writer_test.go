@@ -0,0 +1,268 @@
+package configtypes
+
+import (
+	"errors"
+	"math"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alecthomas/units"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testStructForVarWriter struct {
+	Bool        OptBool                `conf:"BOOL"`
+	Int         OptInt                 `conf:"INT"`
+	IntPositive OptIntGreaterThanZero  `conf:"INT_POSITIVE"`
+	Float       OptFloat64             `conf:"FLOAT"`
+	Duration    OptDuration            `conf:"DURATION"`
+	NonNegative OptDurationNonNegative `conf:"NON_NEGATIVE"`
+	String      OptString              `conf:"STRING"`
+	NonEmpty    OptStringNonEmpty      `conf:"NON_EMPTY"`
+	List        OptStringList          `conf:"LIST"`
+	URL         OptURL                 `conf:"URL"`
+	AbsURL      OptURLAbsolute         `conf:"ABS_URL"`
+	Bytes       OptBase2Bytes          `conf:"BYTES"`
+	RawBool     bool                   `conf:"RAW_BOOL"`
+	RawInt      int                    `conf:"RAW_INT"`
+	RawFloat    float64                `conf:"RAW_FLOAT"`
+	RawString   string                 `conf:"RAW_STRING"`
+	Pointer     *int                   `conf:"POINTER"`
+	NilPointer  *int                   `conf:"NIL_POINTER"`
+	Untagged    string
+	Nested      testStructForVarWriterNested
+	NestedPtr   *testStructForVarWriterNested
+}
+
+type testStructForVarWriterNested struct {
+	Name OptString `conf:"NESTED_NAME"`
+}
+
+func makeTestStructForVarWriter() testStructForVarWriter {
+	pointerValue := 5
+	u, _ := url.Parse("https://example.com/a?b=c")
+	relative, _ := url.Parse("/relative")
+	return testStructForVarWriter{
+		Bool:        NewOptBool(false),
+		Int:         NewOptInt(-3),
+		IntPositive: mustOptIntGreaterThanZero(2),
+		Float:       NewOptFloat64(0.1),
+		Duration:    NewOptDuration(-90 * time.Second),
+		NonNegative: mustOptDurationNonNegative(time.Millisecond),
+		String:      NewOptString(""),
+		NonEmpty:    NewOptStringNonEmpty("x y"),
+		List:        NewOptStringList([]string{"a", "", "b"}),
+		URL:         NewOptURL(relative),
+		AbsURL:      mustOptURLAbsolute(u),
+		Bytes:       NewOptBase2Bytes(10 * units.MiB),
+		RawBool:     true,
+		RawFloat:    math.Pi,
+		RawString:   "has spaces",
+		Pointer:     &pointerValue,
+		Nested:      testStructForVarWriterNested{Name: NewOptString("n1")},
+	}
+}
+
+func TestVarWriter(t *testing.T) {
+	t.Run("WriteStruct writes defined values", func(t *testing.T) {
+		s := makeTestStructForVarWriter()
+		w := NewVarWriter()
+		w.WriteStruct(&s, true)
+		require.True(t, w.Result().OK(), w.Result().GetError())
+		assert.Equal(t, map[string]string{
+			"BOOL":         "false",
+			"INT":          "-3",
+			"INT_POSITIVE": "2",
+			"FLOAT":        "0.1",
+			"DURATION":     "-1m30s",
+			"NON_NEGATIVE": "1ms",
+			"STRING":       "",
+			"NON_EMPTY":    "x y",
+			"LIST":         "a,,b",
+			"URL":          "/relative",
+			"ABS_URL":      "https://example.com/a?b=c",
+			"BYTES":        "10MiB",
+			"RAW_BOOL":     "true",
+			"RAW_INT":      "0",
+			"RAW_FLOAT":    "3.141592653589793",
+			"RAW_STRING":   "has spaces",
+			"POINTER":      "5",
+			"NESTED_NAME":  "n1",
+		}, w.Values())
+	})
+
+	t.Run("values read back by ReadStruct are equal", func(t *testing.T) {
+		s := makeTestStructForVarWriter()
+		w := NewVarWriter()
+		w.WriteStruct(s, true)
+		require.True(t, w.Result().OK(), w.Result().GetError())
+
+		var s1 testStructForVarWriter
+		r := NewVarReaderFromValues(w.Values())
+		r.ReadStruct(&s1, true)
+		require.True(t, r.Result().OK(), r.Result().GetError())
+		assert.Equal(t, s, s1)
+	})
+
+	t.Run("empty values are not written", func(t *testing.T) {
+		w := NewVarWriter()
+		w.WriteStruct(testStructForVarWriter{}, false)
+		assert.Equal(t, map[string]string{
+			"RAW_BOOL": "false", "RAW_INT": "0", "RAW_FLOAT": "0", "RAW_STRING": "",
+		}, w.Values())
+	})
+
+	t.Run("non-recursive", func(t *testing.T) {
+		s := makeTestStructForVarWriter()
+		w := NewVarWriter()
+		w.WriteStruct(&s, false)
+		assert.NotContains(t, w.Values(), "NESTED_NAME")
+	})
+
+	t.Run("struct pointer", func(t *testing.T) {
+		s := testStructForVarWriter{NestedPtr: &testStructForVarWriterNested{Name: NewOptString("n2")}}
+		w := NewVarWriter()
+		w.WriteStruct(&s, true)
+		assert.Equal(t, "n2", w.Values()["NESTED_NAME"])
+	})
+
+	t.Run("self-reference", func(t *testing.T) {
+		s := testStructWithSelfReference{F1: "x"}
+		s.Next = &s
+		w := NewVarWriter()
+		w.WriteStruct(&s, true)
+		assert.True(t, w.Result().OK())
+		assert.Equal(t, map[string]string{"STRING_VAR": "x"}, w.Values())
+	})
+
+	t.Run("prefix and suffix", func(t *testing.T) {
+		w0 := NewVarWriter()
+		w := w0.WithVarNamePrefix("APP_").WithVarNameSuffix("_1")
+		w.Write("X", NewOptInt(1))
+		w.Write("Y", "not a list")
+		w.Write("Z", NewOptStringList([]string{"a,b"}))
+		assert.Equal(t, map[string]string{"APP_X_1": "1", "APP_Y_1": "not a list"}, w0.Values())
+		assert.Equal(t, []ValidationError{
+			{Path: NewValidationPath("APP_Z_1"), Err: errVarWriterCannotRepresent()},
+		}, w0.Result().Errors())
+	})
+
+	t.Run("values that would not be read back the same", func(t *testing.T) {
+		w := NewVarWriter()
+		assert.False(t, w.Write("A", NewOptStringList([]string{"a,b"})))
+		assert.False(t, w.Write("B", NewOptStringList([]string{})))
+		assert.False(t, w.Write("C", NewOptStringList([]string{""})))
+		assert.Len(t, w.Result().Errors(), 3)
+		assert.Empty(t, w.Values())
+	})
+
+	t.Run("times that are equivalent but not identical after reading back", func(t *testing.T) {
+		newYork, err := time.LoadLocation("America/New_York")
+		require.NoError(t, err)
+		inNewYork := time.Date(2020, time.March, 1, 12, 30, 0, 500, newYork)
+		now := time.Now()
+
+		w := NewVarWriter()
+		assert.True(t, w.Write("A", NewOptTime(inNewYork)))
+		assert.True(t, w.Write("B", NewOptTime(now)))
+		require.True(t, w.Result().OK())
+		assert.Equal(t, "2020-03-01T12:30:00.0000005-05:00", w.Values()["A"])
+
+		var s struct {
+			A OptTime `conf:"A"`
+			B OptTime `conf:"B"`
+		}
+		r := NewVarReaderFromValues(w.Values())
+		r.ReadStruct(&s, false)
+		require.True(t, r.Result().OK())
+		assert.True(t, s.A.GetOrElse(time.Time{}).Equal(inNewYork))
+		assert.True(t, s.B.GetOrElse(time.Time{}).Equal(now))
+	})
+
+	t.Run("conflicting values", func(t *testing.T) {
+		w := NewVarWriter()
+		assert.True(t, w.Write("A", 1))
+		assert.True(t, w.Write("A", NewOptInt(1)))
+		assert.False(t, w.Write("A", 2))
+		assert.Equal(t, []ValidationError{
+			{Path: NewValidationPath("A"), Err: errVarWriterConflict()},
+		}, w.Result().Errors())
+		assert.Equal(t, map[string]string{"A": "1"}, w.Values())
+	})
+
+	t.Run("secrets are not written unless revealed", func(t *testing.T) {
+		s := struct {
+			Key      ReqSecret `conf:"KEY"`
+			Password OptSecret `conf:"PASSWORD"`
+			Token    string    `conf:"TOKEN,secret"`
+			Name     string    `conf:"NAME"`
+		}{Key: mustReqSecret(testLongSecret), Token: testShortSecret, Name: "x"}
+
+		w := NewVarWriter()
+		w.WriteStruct(s, false)
+		assert.False(t, w.Write("OTHER", NewOptSecret(testShortSecret)))
+		assert.Equal(t, map[string]string{"NAME": "x"}, w.Values())
+		assert.Equal(t, []ValidationError{
+			{Path: NewValidationPath("KEY"), Err: errVarWriterSecretNotRevealed()},
+			{Path: NewValidationPath("TOKEN"), Err: errVarWriterSecretNotRevealed()},
+			{Path: NewValidationPath("OTHER"), Err: errVarWriterSecretNotRevealed()},
+		}, w.Result().Errors())
+
+		w = NewVarWriter()
+		w.WithVarNamePrefix("APP_").WithSecretsRevealed().WriteStruct(s, false)
+		require.True(t, w.Result().OK(), w.Result().GetError())
+		assert.Equal(t, map[string]string{
+			"APP_KEY": testLongSecret, "APP_TOKEN": testShortSecret, "APP_NAME": "x",
+		}, w.Values())
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		w := NewVarWriter()
+		assert.False(t, w.Write("A", []int{1}))
+		assert.Equal(t, []ValidationError{
+			{Path: NewValidationPath("A"), Err: errors.New("could not write value of type []int")},
+		}, w.Result().Errors())
+	})
+
+	t.Run("bad tag", func(t *testing.T) {
+		w := NewVarWriter()
+		w.WriteStruct(testStructWithBadTag{}, true)
+		assert.Equal(t, []ValidationError{
+			{Path: NewValidationPath("F1"), Err: errors.New(`unrecognized field tag option "whatever"`)},
+		}, w.Result().Errors())
+	})
+
+	t.Run("not a struct", func(t *testing.T) {
+		w := NewVarWriter()
+		w.WriteStruct(3, true)
+		assert.Len(t, w.Result().Errors(), 1)
+	})
+}
+
+func TestVarWriterOutputFormats(t *testing.T) {
+	w := NewVarWriter()
+	w.Write("B", "plain")
+	w.Write("A", "two words")
+	w.Write("C", "")
+	w.Write("D", "quote\" dollar$ backslash\\ newline\n tab\t hash# backtick`")
+
+	assert.Equal(t, []string{
+		"A=two words",
+		"B=plain",
+		"C=",
+		"D=quote\" dollar$ backslash\\ newline\n tab\t hash# backtick`",
+	}, w.Environ())
+
+	var b strings.Builder
+	require.NoError(t, w.WriteDotEnv(&b))
+	assert.Equal(t, strings.Join([]string{
+		`A="two words"`,
+		`B=plain`,
+		`C=`,
+		`D="quote\" dollar\$ backslash\\ newline\n tab\t hash# backtick` + "\\`" + `"`,
+		``,
+	}, "\n"), b.String())
+}
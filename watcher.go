@@ -0,0 +1,233 @@
+package configtypes
+
+import (
+	"reflect"
+	"sync"
+	"time"
+)
+
+// DefaultWatcherInterval is the interval at which a Watcher re-reads its sources, if
+// WatcherOptions.Interval is zero.
+const DefaultWatcherInterval = 30 * time.Second
+
+// fileEventDebounce is how long a Watcher waits after a file change before reloading, so that it is
+// less likely to see a file that is only partly written and so that a burst of changes causes only
+// one reload.
+const fileEventDebounce = 100 * time.Millisecond
+
+// WatcherOptions specifies optional behavior for a Watcher.
+type WatcherOptions struct {
+	// Interval is how often the sources are re-read. If it is zero, DefaultWatcherInterval is used.
+	// If it is negative, the sources are only re-read when a file changes or when Reload is called.
+	Interval time.Duration
+	// Recursive is passed to VarReader.ReadStruct and ValidateStruct.
+	Recursive bool
+	// OnReject, if not nil, is called whenever a reload is rejected because a source could not be
+	// read or the new configuration was not valid. The previous configuration remains in effect. As
+	// with subscribers, it must not call Reload or Close.
+	OnReject func(ValidationResult)
+	// DisableFileEvents turns off the watching of files for changes. By default, on Linux, a Watcher
+	// uses inotify to reload promptly whenever a file used by one of its sources changes, in
+	// addition to re-reading the sources at the regular interval. On other platforms, the sources
+	// are only re-read at the regular interval.
+	DisableFileEvents bool
+}
+
+// Watcher keeps a configuration struct up to date by periodically re-reading a set of sources, so
+// that some settings can be changed without restarting the application.
+//
+// Each time it reloads, the Watcher starts with a fresh copy of the default struct, reads the sources
+// into it with VarReader.ReadStruct, and checks it with ValidateStruct. If both succeed and the
+// resulting struct is different from the current one, it becomes the current configuration and is
+// published to all subscribers. Otherwise, the reload is rejected and reported to
+// WatcherOptions.OnReject, and the current configuration does not change.
+//
+//	w, err := configtypes.NewWatcher(defaultConfig,
+//	    []configtypes.VarSource{configtypes.DotEnvFileVarSource("app.env"), configtypes.EnvironmentVarSource()},
+//	    configtypes.WatcherOptions{OnReject: func(r configtypes.ValidationResult) { log.Println(r.GetError()) }})
+//	if err != nil { ... }
+//	defer w.Close()
+//	w.Subscribe(func(c Config) { limiter.SetLimit(c.RateLimit.GetOrElse(100)) })
+//
+// Each copy of the default struct has its own storage for everything that its exported pointer,
+// slice, map, and interface fields refer to, so a reload never modifies a configuration that has
+// already been published.
+type Watcher[T any] struct {
+	defaults    T
+	sources     []VarSource
+	options     WatcherOptions
+	reloadLock  sync.Mutex
+	lock        sync.RWMutex
+	current     T
+	subscribers []watcherSubscriber[T]
+	lastID      int
+	notifier    fileEventNotifier
+	closeOnce   sync.Once
+	closed      chan struct{}
+	done        chan struct{}
+}
+
+type watcherSubscriber[T any] struct {
+	id int
+	fn func(T)
+}
+
+// fileEventNotifier is implemented on platforms that support watching files for changes.
+type fileEventNotifier interface {
+	events() <-chan struct{}
+	close()
+}
+
+// NewWatcher creates a Watcher and reads its sources for the first time. The defaults parameter must
+// be a struct, as described for VarReader.ReadStruct. If there is more than one source, they are
+// combined as described for NewVarReaderFromSources.
+//
+// If the initial configuration cannot be read or is not valid, NewWatcher returns the error from the
+// ValidationResult, and does not start watching.
+func NewWatcher[T any](defaults T, sources []VarSource, options WatcherOptions) (*Watcher[T], error) {
+	w := &Watcher[T]{
+		defaults: defaults,
+		sources:  append([]VarSource(nil), sources...),
+		options:  options,
+		closed:   make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	config, result := w.load()
+	if !result.OK() {
+		return nil, result.GetError()
+	}
+	w.current = config
+
+	var events <-chan struct{}
+	if !options.DisableFileEvents {
+		// If file events are unavailable, we still have polling, so an error here is not fatal.
+		if notifier, err := newFileEventNotifier(sortedVarSourcePaths(sources)); err == nil && notifier != nil {
+			w.notifier = notifier
+			events = notifier.events()
+		}
+	}
+	go w.run(events)
+	return w, nil
+}
+
+// Current returns the current configuration.
+func (w *Watcher[T]) Current() T {
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+	return w.current
+}
+
+// Subscribe adds a function that will be called with the new configuration each time a reload
+// succeeds and the configuration has changed. Subscribers are called one at a time, in the order
+// they were added, on the Watcher's goroutine (or on the goroutine that called Reload).
+//
+// A subscriber must not call Reload or Close. Reloads are serialized so that subscribers always see
+// configurations in the order they were loaded, and a reload is not finished until every subscriber
+// has returned, so either call would wait forever.
+//
+// The returned function removes the subscription.
+func (w *Watcher[T]) Subscribe(fn func(T)) (unsubscribe func()) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.lastID++
+	id := w.lastID
+	w.subscribers = append(w.subscribers, watcherSubscriber[T]{id: id, fn: fn})
+	return func() {
+		w.lock.Lock()
+		defer w.lock.Unlock()
+		for i, s := range w.subscribers {
+			if s.id == id {
+				w.subscribers = append(w.subscribers[:i:i], w.subscribers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// Reload re-reads the sources immediately, rather than waiting for the next interval. It returns the
+// result of reading and validating the new configuration; if this result is not OK, the reload was
+// rejected and OnReject has been called.
+func (w *Watcher[T]) Reload() ValidationResult {
+	w.reloadLock.Lock()
+	defer w.reloadLock.Unlock()
+
+	config, result := w.load()
+	if !result.OK() {
+		if w.options.OnReject != nil {
+			w.options.OnReject(result)
+		}
+		return result
+	}
+
+	w.lock.Lock()
+	if reflect.DeepEqual(config, w.current) {
+		w.lock.Unlock()
+		return result
+	}
+	w.current = config
+	subscribers := append([]watcherSubscriber[T](nil), w.subscribers...)
+	w.lock.Unlock()
+
+	for _, s := range subscribers {
+		s.fn(config)
+	}
+	return result
+}
+
+// Close stops the Watcher. It does not return until the Watcher's goroutine has exited, so it must
+// not be called from a subscriber or from OnReject.
+func (w *Watcher[T]) Close() {
+	w.closeOnce.Do(func() {
+		close(w.closed)
+		<-w.done
+		if w.notifier != nil {
+			w.notifier.close()
+		}
+	})
+}
+
+func (w *Watcher[T]) load() (T, ValidationResult) {
+	config := deepCopy(w.defaults)
+	r, err := NewVarReaderFromSources(w.sources...)
+	if err != nil {
+		var result ValidationResult
+		result.AddError(nil, err)
+		return config, result
+	}
+	r.ReadStruct(&config, w.options.Recursive)
+	result := r.Result()
+	if result.OK() {
+		result = ValidateStruct(&config, w.options.Recursive)
+	}
+	return config, result
+}
+
+func (w *Watcher[T]) run(events <-chan struct{}) {
+	defer close(w.done)
+
+	var tick <-chan time.Time
+	interval := w.options.Interval
+	if interval == 0 {
+		interval = DefaultWatcherInterval
+	}
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	var debounce <-chan time.Time
+	for {
+		select {
+		case <-w.closed:
+			return
+		case <-tick:
+			w.Reload()
+		case <-events:
+			debounce = time.After(fileEventDebounce)
+		case <-debounce:
+			debounce = nil
+			w.Reload()
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package configtypes
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testStructForWatcher struct {
+	Limit OptIntGreaterThanZero `conf:"LIMIT"`
+	Level OptString             `conf:"LEVEL,required"`
+}
+
+type watcherTestRecorder struct {
+	lock      sync.Mutex
+	published []testStructForWatcher
+	rejected  []ValidationResult
+	changed   chan struct{}
+}
+
+func newWatcherTestRecorder() *watcherTestRecorder {
+	return &watcherTestRecorder{changed: make(chan struct{}, 10)}
+}
+
+func (r *watcherTestRecorder) publish(c testStructForWatcher) {
+	r.lock.Lock()
+	r.published = append(r.published, c)
+	r.lock.Unlock()
+	r.changed <- struct{}{}
+}
+
+func (r *watcherTestRecorder) reject(result ValidationResult) {
+	r.lock.Lock()
+	r.rejected = append(r.rejected, result)
+	r.lock.Unlock()
+	r.changed <- struct{}{}
+}
+
+func (r *watcherTestRecorder) await(t *testing.T) {
+	t.Helper()
+	select {
+	case <-r.changed:
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "timed out waiting for reload")
+	}
+}
+
+func TestWatcher(t *testing.T) {
+	defaults := testStructForWatcher{Limit: mustOptIntGreaterThanZero(10)}
+
+	t.Run("initial load", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "test.env")
+		writeTestFile(t, path, "LEVEL=info\n")
+		w, err := NewWatcher(defaults, []VarSource{DotEnvFileVarSource(path)}, WatcherOptions{Interval: -1})
+		require.NoError(t, err)
+		defer w.Close()
+		assert.Equal(t, testStructForWatcher{Limit: defaults.Limit, Level: NewOptString("info")}, w.Current())
+	})
+
+	t.Run("initial load fails", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "test.env")
+		writeTestFile(t, path, "LIMIT=0\n")
+		_, err := NewWatcher(defaults, []VarSource{DotEnvFileVarSource(path)}, WatcherOptions{Interval: -1})
+		assert.Equal(t, ValidationAggregateError{
+			{Path: NewValidationPath("LIMIT"), Err: errMustBeGreaterThanZero(), Source: path},
+			{Path: NewValidationPath("LEVEL"), Err: errRequired()},
+		}, err)
+	})
+
+	t.Run("Reload publishes valid changes and rejects invalid ones", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "test.env")
+		writeTestFile(t, path, "LEVEL=info\n")
+		rec := newWatcherTestRecorder()
+		w, err := NewWatcher(defaults, []VarSource{DotEnvFileVarSource(path)},
+			WatcherOptions{Interval: -1, DisableFileEvents: true, OnReject: rec.reject})
+		require.NoError(t, err)
+		defer w.Close()
+		w.Subscribe(rec.publish)
+
+		assert.True(t, w.Reload().OK()) // unchanged, so not published
+
+		writeTestFile(t, path, "LEVEL=debug\nLIMIT=5\n")
+		assert.True(t, w.Reload().OK())
+
+		writeTestFile(t, path, "LEVEL=warn\nLIMIT=-1\n")
+		assert.False(t, w.Reload().OK())
+
+		writeTestFile(t, path, "LIMIT=5\n")
+		assert.False(t, w.Reload().OK())
+
+		require.NoError(t, os.Remove(path))
+		assert.False(t, w.Reload().OK())
+
+		expected := testStructForWatcher{Limit: mustOptIntGreaterThanZero(5), Level: NewOptString("debug")}
+		assert.Equal(t, []testStructForWatcher{expected}, rec.published)
+		assert.Equal(t, expected, w.Current())
+		require.Len(t, rec.rejected, 3)
+		assert.Equal(t, []ValidationError{
+			{Path: NewValidationPath("LIMIT"), Err: errMustBeGreaterThanZero(), Source: path},
+		}, rec.rejected[0].Errors())
+		assert.Equal(t, []ValidationError{
+			{Path: NewValidationPath("LEVEL"), Err: errRequired()},
+		}, rec.rejected[1].Errors())
+		assert.Len(t, rec.rejected[2].Errors(), 1)
+	})
+
+	t.Run("reload does not modify published configurations", func(t *testing.T) {
+		type config struct {
+			Server *testStructForWatcher
+		}
+		path := filepath.Join(t.TempDir(), "test.env")
+		writeTestFile(t, path, "LEVEL=info\n")
+		sharedDefaults := config{Server: &testStructForWatcher{Limit: mustOptIntGreaterThanZero(10)}}
+		w, err := NewWatcher(sharedDefaults, []VarSource{DotEnvFileVarSource(path)},
+			WatcherOptions{Interval: -1, DisableFileEvents: true, Recursive: true})
+		require.NoError(t, err)
+		defer w.Close()
+		first := w.Current()
+
+		writeTestFile(t, path, "LEVEL=debug\n")
+		require.True(t, w.Reload().OK())
+		assert.Equal(t, NewOptString("debug"), w.Current().Server.Level)
+		assert.Equal(t, NewOptString("info"), first.Server.Level)
+		assert.Equal(t, OptString{}, sharedDefaults.Server.Level)
+	})
+
+	t.Run("unsubscribe", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "test.env")
+		writeTestFile(t, path, "LEVEL=info\n")
+		w, err := NewWatcher(defaults, []VarSource{DotEnvFileVarSource(path)}, WatcherOptions{Interval: -1})
+		require.NoError(t, err)
+		defer w.Close()
+		var calls1, calls2 int
+		unsubscribe1 := w.Subscribe(func(testStructForWatcher) { calls1++ })
+		w.Subscribe(func(testStructForWatcher) { calls2++ })
+		unsubscribe1()
+		unsubscribe1() // no effect
+
+		writeTestFile(t, path, "LEVEL=debug\n")
+		w.Reload()
+		assert.Equal(t, 0, calls1)
+		assert.Equal(t, 1, calls2)
+	})
+
+	t.Run("polling", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "test.env")
+		writeTestFile(t, path, "LEVEL=info\n")
+		rec := newWatcherTestRecorder()
+		w, err := NewWatcher(defaults, []VarSource{DotEnvFileVarSource(path)},
+			WatcherOptions{Interval: 10 * time.Millisecond, DisableFileEvents: true, OnReject: rec.reject})
+		require.NoError(t, err)
+		defer w.Close()
+		w.Subscribe(rec.publish)
+
+		writeTestFile(t, path, "LEVEL=debug\n")
+		rec.await(t)
+		assert.Equal(t, NewOptString("debug"), w.Current().Level)
+	})
+
+	t.Run("file events", func(t *testing.T) {
+		if runtime.GOOS != "linux" {
+			t.Skip("file events are only supported on Linux")
+		}
+		dir := t.TempDir()
+		path := filepath.Join(dir, "test.env")
+		writeTestFile(t, path, "LEVEL=info\n")
+		secretsDir := filepath.Join(dir, "secrets")
+		require.NoError(t, os.Mkdir(secretsDir, 0o700))
+		rec := newWatcherTestRecorder()
+		w, err := NewWatcher(defaults, []VarSource{DotEnvFileVarSource(path), DirectoryVarSource(secretsDir)},
+			WatcherOptions{Interval: -1, OnReject: rec.reject})
+		require.NoError(t, err)
+		defer w.Close()
+		w.Subscribe(rec.publish)
+
+		// replace the file with a rename, as an editor or Kubernetes would
+		tempPath := filepath.Join(dir, "new.env")
+		writeTestFile(t, tempPath, "LEVEL=debug\n")
+		require.NoError(t, os.Rename(tempPath, path))
+		rec.await(t)
+		assert.Equal(t, NewOptString("debug"), w.Current().Level)
+
+		writeTestFile(t, filepath.Join(secretsDir, "LIMIT"), "3\n")
+		rec.await(t)
+		assert.Equal(t, mustOptIntGreaterThanZero(3), w.Current().Limit)
+	})
+
+	t.Run("Close is idempotent", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "test.env")
+		writeTestFile(t, path, "LEVEL=info\n")
+		w, err := NewWatcher(defaults, []VarSource{DotEnvFileVarSource(path)}, WatcherOptions{})
+		require.NoError(t, err)
+		w.Close()
+		w.Close()
+	})
+}
@@ -0,0 +1,219 @@
+package configtypes
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LoadYAML reads a YAML configuration file into a struct, and then checks the struct with
+// ValidateStruct. The target must be a struct pointer.
+//
+// YAML keys are matched to struct fields in the same way as gopkg.in/yaml.v3: by the name in the
+// field's "yaml:" tag if any, or otherwise by the field name in lowercase, and the fields of a
+// struct field with the "inline" option are treated as if they were fields of the outer struct.
+// Values are decoded with the field type's UnmarshalYAML method if it has one; all of the Opt types
+// do, with the same rules as for JSON, so for instance an OptStringList can be either a single
+// string or a sequence of strings. A null value for a field whose type implements json.Unmarshaler,
+// such as an Opt type, is decoded as a JSON null, so it makes the value empty as it would with
+// LoadJSON, whereas yaml.v3 would leave the field unchanged. Also unlike yaml.v3, LoadYAML reports
+// each field that could not be decoded, and each unknown key if options.DisallowUnknownKeys is set,
+// as a separate ValidationError whose Path is the path of YAML keys and sequence indexes, and whose
+// Line and Column are the location of the value in the file. Errors from ValidateStruct, which is
+// called with recursive set to true, are added to the same result; their paths use Go field names.
+//
+// If the data is not valid YAML, or the top-level value is not a mapping, the result contains only
+// one error and the struct is not validated. An empty file is valid. Fields that do not appear in
+// the file are not modified, so you can set default values in the struct before calling LoadYAML.
+func LoadYAML(r io.Reader, target interface{}, options LoadOptions) ValidationResult {
+	return loadFile(r, target, options, func(data []byte, refStruct reflect.Value, result *ValidationResult) bool {
+		l := yamlLoader{options: options, result: result}
+		var doc yaml.Node
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			line, err := parseYAMLSyntaxError(err)
+			result.Add(ValidationError{Err: err, Source: options.Source, Line: line})
+			return false
+		}
+		if len(doc.Content) == 0 {
+			return true
+		}
+		root := resolveYAMLAlias(doc.Content[0])
+		if root.Kind != yaml.MappingNode {
+			l.addError(nil, root, errYAMLTopLevelNotMapping())
+			return false
+		}
+		l.decode(root, refStruct, nil)
+		return true
+	})
+}
+
+// unmarshalYAMLAsJSON implements UnmarshalYAML for types that implement json.Unmarshaler, by converting
+// the YAML value to JSON.
+func unmarshalYAMLAsJSON(node *yaml.Node, target json.Unmarshaler) error {
+	var value interface{}
+	if err := node.Decode(&value); err != nil {
+		return err
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return target.UnmarshalJSON(data)
+}
+
+var yamlSyntaxErrorRegex = regexp.MustCompile(`^yaml: line (\d+): (.*)$`) //nolint:gochecknoglobals
+
+func parseYAMLSyntaxError(err error) (int, error) {
+	if m := yamlSyntaxErrorRegex.FindStringSubmatch(err.Error()); m != nil {
+		line, _ := strconv.Atoi(m[1])
+		return line, errors.New(m[2])
+	}
+	return 0, err
+}
+
+func resolveYAMLAlias(node *yaml.Node) *yaml.Node {
+	for node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	return node
+}
+
+type yamlLoader struct {
+	options LoadOptions
+	result  *ValidationResult
+}
+
+func (l *yamlLoader) addError(path ValidationPath, node *yaml.Node, err error) {
+	var te *yaml.TypeError
+	if errors.As(err, &te) {
+		// yaml.v3 includes the line number in each message, but we report it separately
+		messages := make([]string, 0, len(te.Errors))
+		for _, m := range te.Errors {
+			_, e := parseYAMLSyntaxError(errors.New("yaml: " + m))
+			messages = append(messages, e.Error())
+		}
+		err = errors.New(strings.Join(messages, "; "))
+	}
+	l.result.Add(ValidationError{
+		Path:   append(ValidationPath(nil), path...),
+		Err:    err,
+		Source: l.options.Source,
+		Line:   node.Line,
+		Column: node.Column,
+	})
+}
+
+// decode sets an addressable value from a YAML value.
+func (l *yamlLoader) decode(node *yaml.Node, target reflect.Value, path ValidationPath) {
+	node = resolveYAMLAlias(node)
+	if target.Kind() == reflect.Ptr {
+		if node.Kind == yaml.ScalarNode && node.Tag == "!!null" {
+			target.Set(reflect.Zero(target.Type()))
+			return
+		}
+		if target.IsNil() {
+			target.Set(reflect.New(target.Type().Elem()))
+		}
+		l.decode(node, target.Elem(), path)
+		return
+	}
+	if u, ok := target.Addr().Interface().(json.Unmarshaler); ok && node.Kind == yaml.ScalarNode &&
+		node.Tag == "!!null" {
+		// yaml.v3 does not call UnmarshalYAML for a null, so it would leave the value unchanged; treat
+		// it the same as a JSON null, as LoadJSON does, so that an Opt value becomes empty.
+		if err := u.UnmarshalJSON([]byte("null")); err != nil {
+			l.addError(path, node, err)
+		}
+		return
+	}
+	if _, ok := target.Addr().Interface().(yaml.Unmarshaler); !ok {
+		switch {
+		case target.Kind() == reflect.Struct && node.Kind == yaml.MappingNode:
+			l.decodeStruct(node, target, path)
+			return
+		case target.Kind() == reflect.Slice && node.Kind == yaml.SequenceNode:
+			slice := reflect.MakeSlice(target.Type(), len(node.Content), len(node.Content))
+			for i, elem := range node.Content {
+				l.decode(elem, slice.Index(i), append(path, PathIndex(i)))
+			}
+			target.Set(slice)
+			return
+		case target.Kind() == reflect.Map && target.Type().Key().Kind() == reflect.String &&
+			node.Kind == yaml.MappingNode:
+			if target.IsNil() {
+				target.Set(reflect.MakeMap(target.Type()))
+			}
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				key := node.Content[i].Value
+				value := reflect.New(target.Type().Elem()).Elem()
+				l.decode(node.Content[i+1], value, append(path, PathKey(key)))
+				target.SetMapIndex(reflect.ValueOf(key).Convert(target.Type().Key()), value)
+			}
+			return
+		}
+	}
+	if err := node.Decode(target.Addr().Interface()); err != nil {
+		l.addError(path, node, err)
+	}
+}
+
+func (l *yamlLoader) decodeStruct(node *yaml.Node, target reflect.Value, path ValidationPath) {
+	fields := yamlFieldsOfType(target.Type())
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keyNode, valueNode := node.Content[i], node.Content[i+1]
+		if keyNode.Tag == "!!merge" { // "<<: *defaults"
+			merged := resolveYAMLAlias(valueNode)
+			if merged.Kind == yaml.SequenceNode {
+				for _, m := range merged.Content {
+					l.decode(m, target, path)
+				}
+			} else {
+				l.decode(merged, target, path)
+			}
+			continue
+		}
+		keyPath := append(path, PathField(keyNode.Value))
+		index, ok := fields[keyNode.Value]
+		if !ok {
+			if l.options.DisallowUnknownKeys {
+				l.addError(keyPath, keyNode, errUnknownKey())
+			}
+			continue
+		}
+		l.decode(valueNode, target.FieldByIndex(index), keyPath)
+	}
+}
+
+// yamlFieldsOfType returns the fields that yaml.v3 would use for a struct type, including the fields of
+// inline structs, as a map of keys to field indexes.
+func yamlFieldsOfType(t reflect.Type) map[string][]int {
+	ret := make(map[string][]int)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, options, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		switch {
+		case name == "-":
+			continue
+		case strings.Contains(options, "inline") && f.Type.Kind() == reflect.Struct:
+			for key, index := range yamlFieldsOfType(f.Type) {
+				if _, exists := ret[key]; !exists {
+					ret[key] = append([]int{i}, index...)
+				}
+			}
+			continue
+		case name == "":
+			name = strings.ToLower(f.Name)
+		}
+		ret[name] = []int{i}
+	}
+	return ret
+}
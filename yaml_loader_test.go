@@ -0,0 +1,177 @@
+package configtypes
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+type testStructForYAML struct {
+	Port     OptIntGreaterThanZero `yaml:"port"`
+	Timeout  OptDuration
+	Hosts    OptStringList
+	Key      OptSecret
+	Name     OptString `conf:",required"`
+	Count    int
+	Limit    *int
+	Server   testStructForYAMLServer
+	Replicas []testStructForYAMLServer
+	Headers  map[string]string
+	Ignored  string                  `yaml:"-"`
+	Inline   testStructForYAMLInline `yaml:",inline"`
+}
+
+type testStructForYAMLServer struct {
+	Host OptURLAbsolute
+}
+
+type testStructForYAMLInline struct {
+	Region string
+}
+
+func TestOptTypesAsYAML(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		s := testStructForYAML{
+			Port:    mustOptIntGreaterThanZero(8080),
+			Timeout: NewOptDuration(5 * time.Second),
+			Hosts:   NewOptStringList([]string{"a", "b"}),
+			Name:    NewOptString(""),
+			// yaml.v3 decodes "[]" and "{}" as empty rather than nil
+			Replicas: []testStructForYAMLServer{},
+			Headers:  map[string]string{},
+		}
+		data, err := yaml.Marshal(s)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "port: 8080\ntimeout: 5s\nhosts:\n    - a\n    - b\nkey: null\nname: \"\"\n")
+
+		var s1 testStructForYAML
+		require.NoError(t, yaml.Unmarshal(data, &s1))
+		assert.Equal(t, s, s1)
+	})
+
+	t.Run("OptStringList can be a scalar or a sequence", func(t *testing.T) {
+		var s testStructForYAML
+		require.NoError(t, yaml.Unmarshal([]byte("hosts: a"), &s))
+		assert.Equal(t, NewOptStringList([]string{"a"}), s.Hosts)
+		require.NoError(t, yaml.Unmarshal([]byte("hosts:\n  - a\n  - b"), &s))
+		assert.Equal(t, NewOptStringList([]string{"a", "b"}), s.Hosts)
+		assert.Error(t, yaml.Unmarshal([]byte("hosts:\n  - [a]"), &s))
+	})
+
+	t.Run("secrets are redacted", func(t *testing.T) {
+		data, err := yaml.Marshal(testStructForYAML{Key: NewOptSecret(testLongSecret)})
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "key: '****abcd'\n")
+	})
+}
+
+func TestLoadYAML(t *testing.T) {
+	t.Run("valid file", func(t *testing.T) {
+		s := testStructForYAML{Count: 1, Ignored: "x"}
+		result := LoadYAML(strings.NewReader(`
+defaults: &defaults
+  host: http://a
+port: 8080
+timeout: 5s
+hosts: a
+name: n
+limit: 3
+server:
+  <<: *defaults
+replicas:
+  - host: http://b
+  - {}
+headers: {X: y}
+ignored: y
+region: r
+`), &s, LoadOptions{})
+		assert.True(t, result.OK(), result.Errors())
+		limit := 3
+		hostA, _ := NewOptURLAbsoluteFromString("http://a")
+		hostB, _ := NewOptURLAbsoluteFromString("http://b")
+		assert.Equal(t, testStructForYAML{
+			Port:     mustOptIntGreaterThanZero(8080),
+			Timeout:  NewOptDuration(5 * time.Second),
+			Hosts:    NewOptStringList([]string{"a"}),
+			Name:     NewOptString("n"),
+			Count:    1,
+			Limit:    &limit,
+			Server:   testStructForYAMLServer{Host: hostA},
+			Replicas: []testStructForYAMLServer{{Host: hostB}, {}},
+			Headers:  map[string]string{"X": "y"},
+			Ignored:  "x",
+			Inline:   testStructForYAMLInline{Region: "r"},
+		}, s)
+	})
+
+	t.Run("field errors", func(t *testing.T) {
+		var s testStructForYAML
+		result := LoadYAML(strings.NewReader(strings.Join([]string{
+			"port: 0",
+			"count: x",
+			"server:",
+			"  host: not/absolute",
+			"  other: 1",
+			"replicas:",
+			"  - {}",
+			"  - host: [1]",
+			"unknown: {a: [1, 2]}",
+			"name: n",
+		}, "\n")), &s, LoadOptions{Source: "config.yaml", DisallowUnknownKeys: true})
+		assert.Equal(t, []ValidationError{
+			{
+				Path: NewValidationPath("port"), Err: errMustBeGreaterThanZero(),
+				Source: "config.yaml", Line: 1, Column: 7,
+			},
+			{
+				Path: NewValidationPath("count"), Err: errors.New("cannot unmarshal !!str `x` into int"),
+				Source: "config.yaml", Line: 2, Column: 8,
+			},
+			{
+				Path: NewValidationPath("server", "host"), Err: errURLNotAbsolute(),
+				Source: "config.yaml", Line: 4, Column: 9,
+			},
+			{
+				Path: NewValidationPath("server", "other"), Err: errUnknownKey(),
+				Source: "config.yaml", Line: 5, Column: 3,
+			},
+			{
+				Path: ValidationPath{PathField("replicas"), PathIndex(1), PathField("host")}, Err: errURLFormat(),
+				Source: "config.yaml", Line: 8, Column: 11,
+			},
+			{Path: NewValidationPath("unknown"), Err: errUnknownKey(), Source: "config.yaml", Line: 9, Column: 1},
+		}, result.Errors())
+	})
+
+	t.Run("ValidateStruct errors are added", func(t *testing.T) {
+		var s testStructForYAML
+		result := LoadYAML(strings.NewReader("port: 1"), &s, LoadOptions{})
+		assert.Equal(t, []ValidationError{{Path: NewValidationPath("Name"), Err: errRequired()}}, result.Errors())
+	})
+
+	t.Run("empty file", func(t *testing.T) {
+		s := testStructForYAML{Name: NewOptString("n")}
+		assert.True(t, LoadYAML(strings.NewReader(""), &s, LoadOptions{}).OK())
+	})
+
+	t.Run("syntax error", func(t *testing.T) {
+		var s testStructForYAML
+		errs := LoadYAML(strings.NewReader("a: 1\nb: [\n"), &s, LoadOptions{Source: "x"}).Errors()
+		if assert.Len(t, errs, 1) {
+			assert.Equal(t, "x", errs[0].Source)
+			assert.Equal(t, 2, errs[0].Line)
+			assert.NotContains(t, errs[0].Err.Error(), "line")
+		}
+	})
+
+	t.Run("top-level value is not a mapping", func(t *testing.T) {
+		var s testStructForYAML
+		result := LoadYAML(strings.NewReader("\n- a"), &s, LoadOptions{})
+		assert.Equal(t, []ValidationError{{Err: errYAMLTopLevelNotMapping(), Line: 2, Column: 1}}, result.Errors())
+	})
+}